@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// aliasEntry is one row of alias list output
+type aliasEntry struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+// aliasCmd represents the alias command
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage command aliases",
+	Long: `Define shortcuts for frequently used commands.
+
+An alias expands to a saved argument string, so 'lirt bugs' can run
+'lirt issue list --label bug'. Use $1, $2, ... in the expansion to place
+arguments; any arguments not referenced are appended at the end.`,
+}
+
+// aliasSetCmd represents the alias set command
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <expansion>",
+	Short: "Create or update an alias",
+	Long: `Save an alias. Quote the expansion so it is passed as one argument.
+
+Examples:
+  lirt alias set overdue 'issue list --overdue --sort priority'
+  lirt alias set mine 'issue list --created-by me --team $1'
+  lirt mine ENG`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, expansion := args[0], args[1]
+
+		if isBuiltinCommand(name) {
+			return fmt.Errorf("%q is a lirt command and cannot be used as an alias", name)
+		}
+		if words, err := config.SplitArgs(expansion); err != nil {
+			return fmt.Errorf("invalid alias expansion: %w", err)
+		} else if len(words) == 0 {
+			return fmt.Errorf("alias expansion is empty")
+		}
+
+		if err := config.SaveAlias(name, expansion); err != nil {
+			return fmt.Errorf("failed to save alias: %w", err)
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Set alias %s = %s\n", name, expansion)
+		}
+		return nil
+	},
+}
+
+// aliasListCmd represents the alias list command
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Long:  `List all configured aliases and their expansions.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases, err := config.LoadAliases()
+		if err != nil {
+			return err
+		}
+
+		entries := make([]aliasEntry, 0, len(aliases))
+		for name, expansion := range aliases {
+			entries = append(entries, aliasEntry{Name: name, Expansion: expansion})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+
+		return formatter.Output(entries)
+	},
+}
+
+// aliasDeleteCmd represents the alias delete command
+var aliasDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete an alias",
+	Long:  `Remove an alias from the config file.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.DeleteAlias(args[0]); err != nil {
+			return err
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Deleted alias %s\n", args[0])
+		}
+		return nil
+	},
+}
+
+// isBuiltinCommand reports whether name is a top-level lirt command or one
+// of its aliases, which user aliases may not shadow
+func isBuiltinCommand(name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandIndex returns the index of the first argument after any leading
+// global flags (e.g. "-P work" in "lirt -P work myalias"), or -1 if there
+// is none or an argument is not a global flag
+func commandIndex(args []string) int {
+	flags := rootCmd.PersistentFlags()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+
+		if name, ok := strings.CutPrefix(arg, "--"); ok {
+			name, _, hasValue := strings.Cut(name, "=")
+			flag := flags.Lookup(name)
+			if flag == nil {
+				return -1
+			}
+			if flag.NoOptDefVal == "" && !hasValue {
+				i++ // skip the flag's value
+			}
+			continue
+		}
+
+		// Shorthands may be combined (-qv) and the last may carry its value
+		// inline (-Pwork) or take the next argument (-P work)
+		shorthands := arg[1:]
+		for j := 0; j < len(shorthands); j++ {
+			flag := flags.ShorthandLookup(shorthands[j : j+1])
+			if flag == nil {
+				return -1
+			}
+			if flag.NoOptDefVal == "" {
+				if j == len(shorthands)-1 {
+					i++ // skip the flag's value
+				}
+				break
+			}
+		}
+	}
+	return -1
+}
+
+// expandAliasArgs rewrites the command line when its first argument after
+// any global flags names a user alias. It returns nil if no alias applies.
+func expandAliasArgs(args []string) ([]string, error) {
+	i := commandIndex(args)
+	if i < 0 || isBuiltinCommand(args[i]) {
+		return nil, nil
+	}
+
+	aliases, err := config.LoadAliases()
+	if err != nil {
+		// A broken config is reported by the command itself
+		return nil, nil
+	}
+	expansion, ok := aliases[args[i]]
+	if !ok {
+		return nil, nil
+	}
+
+	expanded, err := config.ExpandAlias(expansion, args[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("alias %s: %w", args[i], err)
+	}
+	return append(append([]string{}, args[:i]...), expanded...), nil
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+
+	// Add subcommands
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasDeleteCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestExpandAliasArgs verifies an alias is expanded after any leading global
+// flags and that builtin commands and unknown flags are left alone.
+func TestExpandAliasArgs(t *testing.T) {
+	dir := t.TempDir()
+	config := "[alias]\nbugs = issue list --label bug\n"
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LIRT_CONFIG_DIR", dir)
+	t.Setenv("LIRT_CONFIG_FILE", "")
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "First argument", args: []string{"bugs", "--limit", "5"}, want: []string{"issue", "list", "--label", "bug", "--limit", "5"}},
+		{name: "After valued flag", args: []string{"-P", "work", "bugs"}, want: []string{"-P", "work", "issue", "list", "--label", "bug"}},
+		{name: "After inline value", args: []string{"--profile=work", "-Pwork", "bugs"}, want: []string{"--profile=work", "-Pwork", "issue", "list", "--label", "bug"}},
+		{name: "After boolean flags", args: []string{"-qv", "--no-cache", "bugs"}, want: []string{"-qv", "--no-cache", "issue", "list", "--label", "bug"}},
+		{name: "Combined shorthands with value", args: []string{"-qP", "work", "bugs"}, want: []string{"-qP", "work", "issue", "list", "--label", "bug"}},
+		{name: "Flag value named like alias", args: []string{"-P", "bugs", "issue", "list"}},
+		{name: "Builtin command", args: []string{"-P", "work", "issue", "list"}},
+		{name: "Unknown flag", args: []string{"--label", "x", "bugs"}},
+		{name: "After --", args: []string{"--", "bugs"}},
+		{name: "No arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandAliasArgs(tt.args)
+			if err != nil {
+				t.Fatalf("expandAliasArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandAliasArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
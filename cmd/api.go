@@ -2,15 +2,20 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/dixson3/lirt/internal/client"
 	"github.com/spf13/cobra"
 )
 
 var (
-	apiInputFlag string
-	apiVarsFlag  []string
+	apiInputFlag        string
+	apiVarsFlag         []string
+	apiTemplateFileFlag string
+	apiOperationFlag    string
 )
 
 // apiCmd represents the api command
@@ -30,7 +35,16 @@ Examples:
   lirt api --input query.graphql
 
   # Query with variables
-  lirt api 'query($id: String!) { issue(id: $id) { title } }' -f id=abc123`,
+  lirt api 'query($id: String!) { issue(id: $id) { title } }' -F id=abc123
+
+  # Typed variables: numbers, booleans, and JSON are inferred; key:type=value forces a type
+  lirt api 'query($first: Int, $ids: [ID!]) { issues(first: $first, filter: {id: {in: $ids}}) { nodes { title } } }' -F first=5 -F 'ids=["a","b"]'
+
+  # Filter the response ({"data": ...}) with a jq path
+  lirt api 'query { issues(first: 10) { nodes { id } } }' --jq '.data.issues.nodes[].id'
+
+  # Named operation from a file of reusable queries
+  lirt api --template-file ops.graphql --operation GetIssue -F id=abc123`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
@@ -40,7 +54,16 @@ Examples:
 
 		// Get query from arg or file
 		var query string
-		if apiInputFlag != "" {
+		if apiTemplateFileFlag != "" {
+			if apiInputFlag != "" || len(args) > 0 {
+				return fmt.Errorf("--template-file cannot be combined with --input or a query argument")
+			}
+			content, err := os.ReadFile(apiTemplateFileFlag)
+			if err != nil {
+				return fmt.Errorf("failed to read file %s: %w", apiTemplateFileFlag, err)
+			}
+			query = string(content)
+		} else if apiInputFlag != "" {
 			content, err := os.ReadFile(apiInputFlag)
 			if err != nil {
 				return fmt.Errorf("failed to read file %s: %w", apiInputFlag, err)
@@ -52,41 +75,69 @@ Examples:
 			return fmt.Errorf("query is required (provide as argument or use --input)")
 		}
 
+		// Validate the selected operation against the document
+		operations := client.OperationNames(query)
+		if apiOperationFlag != "" && !client.HasOperation(query, apiOperationFlag) {
+			return fmt.Errorf("operation %q not found (available: %s)", apiOperationFlag, listOperations(operations))
+		}
+		if apiOperationFlag == "" && len(operations) > 1 {
+			return fmt.Errorf("document defines multiple operations; select one with --operation (available: %s)", listOperations(operations))
+		}
+
 		// Parse variables
 		variables := make(map[string]interface{})
 		for _, v := range apiVarsFlag {
-			parts := splitOnce(v, "=")
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid variable format: %s (expected key=value)", v)
+			key, value, err := client.ParseVariable(v)
+			if err != nil {
+				return err
 			}
-			variables[parts[0]] = parts[1]
+			variables[key] = value
 		}
 
 		// Execute raw query
-		var result interface{}
-		if err := apiClient.Query(getContext(), query, variables); err != nil {
+		data, err := apiClient.Exec(getContext(), query, apiOperationFlag, variables)
+		var queryErr *client.QueryErrors
+		if err != nil && !errors.As(err, &queryErr) {
 			return fmt.Errorf("query failed: %w", err)
 		}
 
-		// Always output as JSON
-		output, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return err
+		// Print any (possibly partial) data, then the GraphQL errors
+		if len(data) > 0 && string(data) != "null" {
+			var result interface{}
+			if err := json.Unmarshal(data, &result); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			if jqFlag != "" {
+				// --jq sees the full response, as in '.data.viewer.name'
+				if err := formatter.Output(map[string]interface{}{"data": result}); err != nil {
+					return err
+				}
+			} else {
+				// Always output as JSON
+				output, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(output))
+			}
 		}
 
-		fmt.Println(string(output))
+		if queryErr != nil {
+			fmt.Fprint(os.Stderr, queryErr.Details())
+			return queryErr
+		}
 		return nil
 	},
 }
 
-// Helper to split string on first occurrence of separator
-func splitOnce(s, sep string) []string {
-	for i := 0; i < len(s); i++ {
-		if i+len(sep) <= len(s) && s[i:i+len(sep)] == sep {
-			return []string{s[:i], s[i+len(sep):]}
-		}
+// listOperations formats operation names for error messages
+func listOperations(operations []string) string {
+	if len(operations) == 0 {
+		return "none"
 	}
-	return []string{s}
+	return strings.Join(operations, ", ")
 }
 
 func init() {
@@ -94,5 +145,7 @@ func init() {
 
 	// Flags
 	apiCmd.Flags().StringVar(&apiInputFlag, "input", "", "Read query from file")
-	apiCmd.Flags().StringSliceVarP(&apiVarsFlag, "var", "f", []string{}, "Query variables (key=value)")
+	apiCmd.Flags().StringArrayVarP(&apiVarsFlag, "var", "F", []string{}, "Query variables (key=value, types inferred; or key:type=value)")
+	apiCmd.Flags().StringVar(&apiTemplateFileFlag, "template-file", "", "Read a document of named operations from file")
+	apiCmd.Flags().StringVar(&apiOperationFlag, "operation", "", "Name of the operation to run")
 }
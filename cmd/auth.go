@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
@@ -14,8 +13,11 @@ import (
 )
 
 var (
-	authAPIKeyFlag string
-	authProfileFlag string
+	authAPIKeyFlag      string
+	authAPIKeyFileFlag  string
+	authProfileFlag     string
+	authTokenRawFlag    bool
+	authTokenRevealFlag bool
 )
 
 // authCmd represents the auth command
@@ -41,12 +43,26 @@ Examples:
   # Non-interactive login
   lirt auth login --api-key lin_api_xxxxx...
 
+  # Read the key from a file, keeping it out of shell history
+  lirt auth login --api-key-file /run/secrets/linear
+
   # Login to named profile
   lirt auth login --profile work`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profile := config.GetProfile(authProfileFlag)
+		profile := authProfile()
+		if err := config.ValidateProfileName(profile); err != nil {
+			return err
+		}
 		apiKey := authAPIKeyFlag
 
+		if authAPIKeyFileFlag != "" {
+			key, err := config.ReadAPIKeyFile(authAPIKeyFileFlag)
+			if err != nil {
+				return err
+			}
+			apiKey = key
+		}
+
 		// If no API key provided, prompt for it
 		if apiKey == "" {
 			fmt.Print("Enter your Linear API key: ")
@@ -67,7 +83,7 @@ Examples:
 			fmt.Println("Validating API key...")
 		}
 
-		testClient, err := client.New(apiKey)
+		testClient, err := client.New(apiKey, clientOptions(profile)...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -79,15 +95,8 @@ Examples:
 
 		// Check if profile exists and confirm overwrite
 		if _, err := config.LoadAPIKey(profile); err == nil {
-			if !quietFlag {
-				fmt.Printf("Profile '%s' already exists. Overwrite? (y/N): ", profile)
-				reader := bufio.NewReader(os.Stdin)
-				response, _ := reader.ReadString('\n')
-				response = strings.TrimSpace(strings.ToLower(response))
-				if response != "y" && response != "yes" {
-					fmt.Println("Cancelled.")
-					return nil
-				}
+			if ok, err := confirm(fmt.Sprintf("Profile '%s' already exists. Overwrite?", profile)); err != nil || !ok {
+				return err
 			}
 		}
 
@@ -102,9 +111,9 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Authenticated as %s (%s)\n", viewer.Name, viewer.Email)
-			fmt.Printf("✓ Workspace: %s\n", viewer.Organization.Name)
-			fmt.Printf("✓ Saved to profile '%s'\n", profile)
+			formatter.Statusf("✓ Authenticated as %s (%s)\n", viewer.Name, viewer.Email)
+			formatter.Statusf("✓ Workspace: %s\n", viewer.Organization.Name)
+			formatter.Statusf("✓ Saved to profile '%s'\n", profile)
 		}
 
 		return nil
@@ -117,7 +126,7 @@ var authStatusCmd = &cobra.Command{
 	Short: "Show authentication status",
 	Long:  `Display the current authentication state including profile, workspace, and user information.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profile := config.GetProfile(authProfileFlag)
+		profile := authProfile()
 
 		cfg, err := config.LoadConfig(profile)
 		if err != nil {
@@ -131,7 +140,7 @@ var authStatusCmd = &cobra.Command{
 		}
 
 		// Get viewer info
-		apiClient, err := client.New(cfg.APIKey)
+		apiClient, err := client.New(cfg.APIKey, clientOptions(cfg.Profile)...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -157,19 +166,41 @@ var authTokenCmd = &cobra.Command{
 	Short: "Print API key to stdout",
 	Long: `Print the API key to stdout for piping to other tools.
 
-Warning: The API key will be visible in your terminal.
+The key is masked by default so it is not accidentally exposed in shared
+terminals, screen recordings, or scrollback. Pass --reveal to print the
+full key.
 
 Example:
+  # Show masked key prefix
+  lirt auth token
+
   # Use with curl
-  curl -H "Authorization: Bearer $(lirt auth token)" https://api.linear.app/graphql`,
+  curl -H "Authorization: Bearer $(lirt auth token --reveal --raw)" https://api.linear.app/graphql
+
+  # Token for a named profile
+  lirt auth token --profile work --reveal --raw`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profile := config.GetProfile(authProfileFlag)
+		profile := authProfile()
 
 		apiKey, err := config.LoadAPIKey(profile)
 		if err != nil {
 			return fmt.Errorf("no API key found: %w", err)
 		}
 
+		// Mask unless explicitly revealed
+		if !authTokenRevealFlag {
+			apiKey = client.MaskAPIKey(apiKey)
+			if !quietFlag {
+				fmt.Fprintln(os.Stderr, "Key is masked; use --reveal to print the full key")
+			}
+		}
+
+		// Raw output omits the trailing newline for header construction
+		if authTokenRawFlag {
+			fmt.Print(apiKey)
+			return nil
+		}
+
 		fmt.Println(apiKey)
 		return nil
 	},
@@ -181,7 +212,7 @@ var authLogoutCmd = &cobra.Command{
 	Short: "Remove credentials for a profile",
 	Long:  `Remove a profile from both the credentials and config files.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profile := config.GetProfile(authProfileFlag)
+		profile := authProfile()
 
 		// Check if profile exists
 		if _, err := config.LoadAPIKey(profile); err != nil {
@@ -189,15 +220,8 @@ var authLogoutCmd = &cobra.Command{
 		}
 
 		// Confirm deletion
-		if !quietFlag {
-			fmt.Printf("Remove profile '%s'? (y/N): ", profile)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Remove profile '%s'?", profile)); err != nil || !ok {
+			return err
 		}
 
 		// Delete profile
@@ -206,7 +230,7 @@ var authLogoutCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Profile '%s' removed\n", profile)
+			formatter.Statusf("✓ Profile '%s' removed\n", profile)
 		}
 
 		return nil
@@ -285,6 +309,15 @@ Example:
 	},
 }
 
+// authProfile returns the profile for auth subcommands, honoring both the
+// subcommand --profile flag and the global -P/--profile flag
+func authProfile() string {
+	if authProfileFlag != "" {
+		return config.GetProfile(authProfileFlag)
+	}
+	return config.GetProfile(profileFlag)
+}
+
 func init() {
 	rootCmd.AddCommand(authCmd)
 
@@ -298,10 +331,14 @@ func init() {
 
 	// Flags for auth login
 	authLoginCmd.Flags().StringVar(&authAPIKeyFlag, "api-key", "", "API key (non-interactive)")
+	authLoginCmd.Flags().StringVar(&authAPIKeyFileFlag, "api-key-file", "", "Read the API key from a file (non-interactive)")
+	authLoginCmd.MarkFlagsMutuallyExclusive("api-key", "api-key-file")
 	authLoginCmd.Flags().StringVar(&authProfileFlag, "profile", "", "Profile name (default: default)")
 
 	// Flags for other commands
 	authStatusCmd.Flags().StringVar(&authProfileFlag, "profile", "", "Profile name")
 	authTokenCmd.Flags().StringVar(&authProfileFlag, "profile", "", "Profile name")
+	authTokenCmd.Flags().BoolVar(&authTokenRawFlag, "raw", false, "Print without trailing newline (for scripting)")
+	authTokenCmd.Flags().BoolVar(&authTokenRevealFlag, "reveal", false, "Print the full API key instead of a masked prefix")
 	authLogoutCmd.Flags().StringVar(&authProfileFlag, "profile", "", "Profile name")
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the local cache",
+	Long:  `Inspect the API responses cached for the current profile.`,
+}
+
+// cacheInfoCmd represents the cache info command
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "List cached entries",
+	Long: `List the entries cached for the current profile: the key each was stored
+under, when it was fetched, and its size in bytes. Cache files are named
+after a hash of their key, so this is where the keys can be read.
+
+Examples:
+  lirt cache info
+  lirt cache info --format json --jq '.[].key'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := cacheInstance.Entries()
+		if err != nil {
+			return err
+		}
+
+		formatter.Statusf("%s (%d entries)\n", cacheInstance.GetCacheDir(), len(entries))
+		return formatter.Output(entries)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheInfoCmd)
+}
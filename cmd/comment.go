@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +16,9 @@ var (
 	commentIssueFlag string
 	commentBodyFlag  string
 	commentFileFlag  string
+
+	commentMentionFlag []string
+	commentOrderFlag   string
 )
 
 // commentCmd represents the comment command
@@ -27,9 +32,20 @@ var commentCmd = &cobra.Command{
 var commentListCmd = &cobra.Command{
 	Use:   "list <issue-id>",
 	Short: "List comments on an issue",
-	Long:  `List all comments on a specific issue.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List all comments on a specific issue.
+
+Comments are listed oldest first; --order newest lists the latest first,
+and with --limit keeps the latest ones.
+
+Table and plain formats render each comment as an "author · time" header
+followed by the indented body with line breaks preserved. JSON and CSV
+output the raw comment fields.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateField("--order", commentOrderFlag, client.CommentOrders); err != nil {
+			return err
+		}
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
@@ -42,16 +58,16 @@ var commentListCmd = &cobra.Command{
 		}
 
 		// Check cache
-		cacheKey := fmt.Sprintf("comments-%s", issueID)
-		var comments interface{}
+		cacheKey := listCacheKey(fmt.Sprintf("comments-%s-%s", issueID, commentOrderFlag))
+		var comments []model.Comment
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &comments); err == nil && found {
-				return formatter.Output(comments)
+				return outputComments(comments)
 			}
 		}
 
 		// Fetch from API
-		comments, err = apiClient.ListIssueComments(getContext(), issueID)
+		comments, err = apiClient.ListIssueComments(listContext(), issueID, client.CommentOrder(commentOrderFlag))
 		if err != nil {
 			return fmt.Errorf("failed to list comments: %w", err)
 		}
@@ -61,7 +77,7 @@ var commentListCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, comments)
 		}
 
-		return formatter.Output(comments)
+		return outputComments(comments)
 	},
 }
 
@@ -73,7 +89,14 @@ var commentAddCmd = &cobra.Command{
 
 Examples:
   lirt comment add ENG-123 --body "This looks good"
-  lirt comment add ENG-123 --body-file comment.md`,
+  lirt comment add ENG-123 --body-file comment.md
+  lirt comment add ENG-123 --body "@alice can you review?" --mention alice
+
+--mention turns a plain @name in the body into a real mention, which
+notifies the user. Without a matching @name the mention is added in front.
+Mentions are written in Linear's markup, @[name](user-id), which can also
+be typed into the body directly. @name matches a whole name only, so
+--mention alice leaves @alice.smith alone.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
@@ -101,6 +124,20 @@ Examples:
 			return fmt.Errorf("comment body is required (use --body or --body-file)")
 		}
 
+		if len(commentMentionFlag) > 0 {
+			users, err := getUsers(apiClient)
+			if err != nil {
+				return err
+			}
+			for _, ref := range commentMentionFlag {
+				user, err := client.FindUser(users, strings.TrimPrefix(ref, "@"))
+				if err != nil {
+					return err
+				}
+				body = client.InsertMention(body, ref, user)
+			}
+		}
+
 		// Create comment
 		input := &client.CreateCommentInput{
 			IssueID: &issueID,
@@ -113,7 +150,7 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Added comment to %s\n", args[0])
+			formatter.Statusf("✓ Added comment to %s\n", args[0])
 		}
 
 		return formatter.Output(comment)
@@ -162,7 +199,7 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Updated comment %s\n", commentID)
+			formatter.Statusf("✓ Updated comment %s\n", commentID)
 		}
 
 		return nil
@@ -184,15 +221,8 @@ var commentDeleteCmd = &cobra.Command{
 		commentID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Delete comment %s? This cannot be undone. (y/N): ", commentID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Delete comment %s? This cannot be undone.", commentID)); err != nil || !ok {
+			return err
 		}
 
 		// Delete comment
@@ -201,13 +231,41 @@ var commentDeleteCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Deleted comment %s\n", commentID)
+			formatter.Statusf("✓ Deleted comment %s\n", commentID)
 		}
 
 		return nil
 	},
 }
 
+// outputComments writes comments, rendering a readable discussion view in
+// table and plain formats
+func outputComments(comments []model.Comment) error {
+	switch formatter.Format() {
+	case output.FormatTable, output.FormatPlain:
+	default:
+		return formatter.Output(comments)
+	}
+
+	w := formatter.Writer()
+	now := time.Now()
+	for i, comment := range comments {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		author := "Unknown"
+		if comment.User != nil && comment.User.Name != "" {
+			author = comment.User.Name
+		}
+
+		fmt.Fprintf(w, "%s · %s\n", author, output.RelativeTime(comment.CreatedAt, now))
+		fmt.Fprintln(w, output.Indent(comment.Body, "  "))
+	}
+
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(commentCmd)
 
@@ -217,9 +275,13 @@ func init() {
 	commentCmd.AddCommand(commentEditCmd)
 	commentCmd.AddCommand(commentDeleteCmd)
 
+	// Flags for comment list
+	commentListCmd.Flags().StringVar(&commentOrderFlag, "order", string(client.CommentsOldest), "Comment order: oldest or newest")
+
 	// Flags for comment add
 	commentAddCmd.Flags().StringVar(&commentBodyFlag, "body", "", "Comment body text")
 	commentAddCmd.Flags().StringVar(&commentFileFlag, "body-file", "", "File containing comment body (markdown)")
+	commentAddCmd.Flags().StringSliceVar(&commentMentionFlag, "mention", []string{}, "Mention a user by name, display name, or email (repeatable)")
 
 	// Flags for comment edit
 	commentEditCmd.Flags().StringVar(&commentBodyFlag, "body", "", "Comment body text")
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/dixson3/lirt/internal/cache"
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// completionTTL is how long cached completion candidates count as fresh.
+// Stale candidates are still offered while a background refresh runs.
+const completionTTL = 1 * time.Minute
+
+// completionTimeout bounds the one fetch made when nothing is cached yet, so
+// Tab never hangs on a slow network
+const completionTimeout = 2 * time.Second
+
+// completionSources fetch the candidates for each kind of dynamic value, as
+// "value\tdescription" strings
+var completionSources = map[string]func(context.Context, *client.Client) ([]string, error){
+	"teams":  teamCandidates,
+	"labels": labelCandidates,
+}
+
+// refreshCompletionsCmd refetches every kind of completion candidate into
+// the cache. It is started in the background by completion itself.
+var refreshCompletionsCmd = &cobra.Command{
+	Use:    "__refresh-completions",
+	Short:  "Refresh cached shell completion candidates",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		for kind, fetch := range completionSources {
+			candidates, err := fetch(getContext(), apiClient)
+			if err != nil {
+				return fmt.Errorf("failed to refresh %s completions: %w", kind, err)
+			}
+			cacheInstance.Set(completionKey(kind), candidates)
+		}
+		return nil
+	},
+}
+
+// completeFrom returns a flag completion function offering cached values of
+// the given kind (see completionSources)
+func completeFrom(kind string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completionCandidates(kind), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionCandidates returns the candidates of a kind from the file cache,
+// starting a background refresh when they are stale. With nothing cached
+// they are fetched once under completionTimeout, but only if an API key is
+// at hand; otherwise there are simply no candidates.
+func completionCandidates(kind string) []string {
+	profile := config.GetProfile(profileFlag)
+	c := cache.New(profile, completionTTL)
+	c.SetLogger(logger)
+
+	var candidates []string
+	if fetchedAt, found, err := c.Peek(completionKey(kind), &candidates); err == nil && found {
+		if time.Since(fetchedAt) > completionTTL {
+			refreshCompletionsInBackground(profile)
+		}
+		return candidates
+	}
+
+	apiKey := apiKeyFlag
+	if apiKey == "" {
+		var err error
+		if apiKey, err = config.LoadAPIKey(profile); err != nil {
+			return nil
+		}
+	}
+	apiClient, err := client.New(apiKey, clientOptions(profile)...)
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(getContext(), completionTimeout)
+	defer cancel()
+	candidates, err = completionSources[kind](ctx, apiClient)
+	if err != nil {
+		logger.Debug("completion fetch failed", "kind", kind, "err", err)
+		return nil
+	}
+	c.Set(completionKey(kind), candidates)
+	return candidates
+}
+
+// refreshCompletionsInBackground starts a detached lirt process to refetch
+// the candidates, so the current Tab press returns at once
+func refreshCompletionsInBackground(profile string) {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	refresh := exec.Command(exe, "__refresh-completions", "--profile", profile, "--quiet")
+	if err := refresh.Start(); err != nil {
+		logger.Debug("completion refresh failed to start", "err", err)
+		return
+	}
+	refresh.Process.Release()
+}
+
+// completionKey returns the cache key for a kind of completion candidate
+func completionKey(kind string) string {
+	return "completion-" + kind
+}
+
+// teamCandidates lists team keys described by their names
+func teamCandidates(ctx context.Context, apiClient *client.Client) ([]string, error) {
+	teams, err := apiClient.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]string, 0, len(teams))
+	for _, team := range teams {
+		candidates = append(candidates, team.Key+"\t"+team.Name)
+	}
+	sort.Strings(candidates)
+	return candidates, nil
+}
+
+// labelCandidates lists label names, described by the team they belong to.
+// A name shared by several teams is offered once.
+func labelCandidates(ctx context.Context, apiClient *client.Client) ([]string, error) {
+	labels, err := apiClient.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	candidates := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if seen[label.Name] {
+			continue
+		}
+		seen[label.Name] = true
+
+		scope := "workspace"
+		if label.Team != nil {
+			scope = label.Team.Key
+		}
+		candidates = append(candidates, label.Name+"\t"+scope)
+	}
+	sort.Strings(candidates)
+	return candidates, nil
+}
+
+func init() {
+	rootCmd.AddCommand(refreshCompletionsCmd)
+}
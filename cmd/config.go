@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/dixson3/lirt/internal/config"
 	"github.com/spf13/cobra"
@@ -32,6 +34,9 @@ var configListCmd = &cobra.Command{
 			"workspace": cfg.Workspace,
 			"team":      cfg.Team,
 			"format":    cfg.Format,
+			"favorites": cfg.Favorites,
+			"auto_json": strconv.FormatBool(cfg.AutoJSON),
+			"timezone":  cfg.Timezone,
 		}
 
 		return formatter.Output(configMap)
@@ -61,6 +66,12 @@ var configGetCmd = &cobra.Command{
 			value = cfg.Team
 		case "format":
 			value = cfg.Format
+		case "favorites":
+			value = cfg.Favorites
+		case "auto_json":
+			value = strconv.FormatBool(cfg.AutoJSON)
+		case "timezone":
+			value = cfg.Timezone
 		default:
 			return fmt.Errorf("unknown config key: %s", key)
 		}
@@ -74,43 +85,73 @@ var configGetCmd = &cobra.Command{
 	},
 }
 
+// configKeys are the keys config set accepts
+var configKeys = []string{"workspace", "team", "format", "favorites", "auto_json", "timezone"}
+
 // configSetCmd represents the config set command
 var configSetCmd = &cobra.Command{
-	Use:   "set <key> <value>",
-	Short: "Set a config value",
-	Long:  `Set the value of a specific configuration key.`,
-	Args:  cobra.ExactArgs(2),
+	Use:   "set <key> <value> | <key>=<value>...",
+	Short: "Set config values",
+	Long: `Set one or more configuration keys for the current profile.
+
+Several key=value pairs are validated together and written in one save.
+
+Examples:
+  lirt config set team ENG
+  lirt config set team=ENG format=json`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		profile := config.GetProfile(profileFlag)
-		key := args[0]
-		value := args[1]
-
-		// Validate key
-		validKeys := []string{"workspace", "team", "format"}
-		valid := false
-		for _, k := range validKeys {
-			if key == k {
-				valid = true
-				break
-			}
+
+		values, keys, err := parseConfigAssignments(args)
+		if err != nil {
+			return err
 		}
-		if !valid {
-			return fmt.Errorf("invalid config key: %s (valid keys: workspace, team, format)", key)
+
+		// Validate every key before writing any
+		for _, key := range keys {
+			if err := validateField("config key", key, configKeys); err != nil {
+				return err
+			}
 		}
 
-		// Save config value
-		if err := config.SaveConfigValue(profile, key, value); err != nil {
+		// Save config values
+		if err := config.SaveConfigValues(profile, values); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Set %s = %s\n", key, value)
+			for _, key := range keys {
+				formatter.Statusf("✓ Set %s = %s\n", key, values[key])
+			}
 		}
 
 		return nil
 	},
 }
 
+// parseConfigAssignments accepts either "<key> <value>" or any number of
+// "key=value" arguments, returning the values and the keys in order
+func parseConfigAssignments(args []string) (map[string]string, []string, error) {
+	if len(args) == 2 && args[0] != "" && !strings.Contains(args[0], "=") {
+		return map[string]string{args[0]: args[1]}, []string{args[0]}, nil
+	}
+
+	values := make(map[string]string, len(args))
+	keys := make([]string, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, nil, fmt.Errorf("invalid assignment %q (expected key=value)", arg)
+		}
+		if _, dup := values[key]; !dup {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+	return values, keys, nil
+}
+
 // configUnsetCmd represents the config unset command
 var configUnsetCmd = &cobra.Command{
 	Use:   "unset <key>",
@@ -127,7 +168,38 @@ var configUnsetCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Unset %s\n", key)
+			formatter.Statusf("✓ Unset %s\n", key)
+		}
+
+		return nil
+	},
+}
+
+// configImportCmd represents the config import command
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import settings from a file",
+	Long: `Merge settings from an ini or TOML file (.toml extension) into the config file.
+
+Keys outside any section apply to the current profile. Sections such as
+[default], [profile work] (or [work] in TOML), and [alias] are merged by name;
+settings not in the file are left unchanged. All keys are validated before
+anything is written.
+
+Examples:
+  lirt config import ~/dotfiles/lirt.ini
+  lirt config import team-settings.toml --profile work`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profile := config.GetProfile(profileFlag)
+
+		count, err := config.ImportConfig(args[0], profile)
+		if err != nil {
+			return err
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Imported %d setting(s) from %s\n", count, args[0])
 		}
 
 		return nil
@@ -142,4 +214,5 @@ func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configImportCmd)
 }
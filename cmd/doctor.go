@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dixson3/lirt/internal/cache"
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// doctorStatus is the outcome of a single doctor check
+type doctorStatus int
+
+const (
+	doctorPass doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorSlowLatency is the API round trip above which doctor warns
+const doctorSlowLatency = 2 * time.Second
+
+// doctorReport collects check results and prints them as a checklist
+type doctorReport struct {
+	warnings int
+	failures int
+}
+
+// add prints one checklist line and tallies its status
+func (r *doctorReport) add(status doctorStatus, name, detail string) {
+	symbol := "✓"
+	switch status {
+	case doctorWarn:
+		symbol = "!"
+		r.warnings++
+	case doctorFail:
+		symbol = "✗"
+		r.failures++
+	}
+	fmt.Printf("%s %-14s %s\n", symbol, name, detail)
+}
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the lirt environment",
+	Long: `Check configuration, credentials, API connectivity, rate limits, the cache,
+and editor setup, printing a pass/warn/fail checklist.
+
+Exits non-zero if any check fails. Run this first when lirt isn't working.`,
+	Args: cobra.NoArgs,
+	// Skip the root setup so a broken config is reported instead of aborting
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report := &doctorReport{}
+		profile := config.GetProfile(profileFlag)
+		fmt.Printf("Profile: %s\n\n", profile)
+
+		// Config validity
+		doctorCfg, err := config.LoadConfig(profile)
+		if err != nil {
+			report.add(doctorFail, "config", err.Error())
+		} else if problems := doctorCfg.Validate(); len(problems) > 0 {
+			report.add(doctorFail, "config", strings.Join(problems, "; "))
+		} else {
+			detail := config.GetConfigFile()
+			if doctorCfg.ProjectFile != "" {
+				detail += " (+ " + doctorCfg.ProjectFile + ")"
+			}
+			report.add(doctorPass, "config", detail)
+		}
+
+		// Credentials file permissions
+		checkCredentialsFile(report)
+
+		// API key, reachability, and rate limit
+		apiKey := apiKeyFlag
+		if apiKey == "" && doctorCfg != nil {
+			apiKey = doctorCfg.APIKey
+		}
+		if apiKey == "" {
+			report.add(doctorFail, "api key", "not set - run 'lirt auth login'")
+			report.add(doctorWarn, "api", "skipped (no API key)")
+		} else {
+			report.add(doctorPass, "api key", client.MaskAPIKey(apiKey))
+			checkAPI(report, apiKey, profile)
+		}
+
+		// Cache directory
+		cacheTTL := 5 * time.Minute
+		if doctorCfg != nil {
+			if duration, err := time.ParseDuration(doctorCfg.CacheTTL); err == nil {
+				cacheTTL = duration
+			}
+		}
+		checkCache(report, cache.New(profile, cacheTTL))
+
+		// Editor
+		checkEditor(report)
+
+		fmt.Printf("\n%d failed, %d warnings\n", report.failures, report.warnings)
+		if report.failures > 0 {
+			return fmt.Errorf("doctor found %d failing check(s)", report.failures)
+		}
+		return nil
+	},
+}
+
+// checkCredentialsFile verifies the credentials file exists and is private
+func checkCredentialsFile(report *doctorReport) {
+	path := config.GetCredentialsFile()
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		if os.Getenv("LIRT_API_KEY") != "" || os.Getenv("LINEAR_API_KEY") != "" {
+			report.add(doctorWarn, "credentials", "no credentials file (using API key from environment)")
+		} else {
+			report.add(doctorWarn, "credentials", "no credentials file at "+path)
+		}
+	case err != nil:
+		report.add(doctorFail, "credentials", err.Error())
+	case runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0:
+		report.add(doctorFail, "credentials", fmt.Sprintf("%s has permissions %04o, expected 0600 (run: chmod 600 %s)", path, info.Mode().Perm(), path))
+	default:
+		report.add(doctorPass, "credentials", path+" (0600)")
+	}
+}
+
+// checkAPI pings the API, reporting reachability, latency, and rate limit
+func checkAPI(report *doctorReport, apiKey, profile string) {
+	pingClient, err := client.New(apiKey, append(clientOptions(profile), client.WithTimeout(10*time.Second))...)
+	if err != nil {
+		report.add(doctorFail, "api", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := pingClient.Ping(ctx)
+	if err != nil {
+		report.add(doctorFail, "api", err.Error())
+		return
+	}
+
+	latency := result.Latency.Round(time.Millisecond)
+	if result.Latency > doctorSlowLatency {
+		report.add(doctorWarn, "api", fmt.Sprintf("reachable but slow (%s)", latency))
+	} else {
+		report.add(doctorPass, "api", fmt.Sprintf("reachable (%s)", latency))
+	}
+
+	switch {
+	case result.RateLimitRemaining < 0 || result.RateLimitLimit <= 0:
+		report.add(doctorWarn, "rate limit", "not reported by the API")
+	case result.RateLimitRemaining*10 < result.RateLimitLimit:
+		report.add(doctorWarn, "rate limit", fmt.Sprintf("%d of %d requests remaining", result.RateLimitRemaining, result.RateLimitLimit))
+	default:
+		report.add(doctorPass, "rate limit", fmt.Sprintf("%d of %d requests remaining", result.RateLimitRemaining, result.RateLimitLimit))
+	}
+}
+
+// checkCache verifies the cache directory is writable and reports its size
+func checkCache(report *doctorReport, c *cache.Cache) {
+	if err := c.CheckWritable(); err != nil {
+		report.add(doctorFail, "cache", err.Error())
+		return
+	}
+
+	bytes, entries, err := c.Size()
+	if err != nil {
+		report.add(doctorWarn, "cache", err.Error())
+		return
+	}
+	report.add(doctorPass, "cache", fmt.Sprintf("%s (%d entries, %.1f KB)", c.GetCacheDir(), entries, float64(bytes)/1024))
+}
+
+// checkEditor verifies $VISUAL or $EDITOR names an executable
+func checkEditor(report *doctorReport) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		report.add(doctorWarn, "editor", "$EDITOR is not set (needed for interactive editing)")
+		return
+	}
+
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		report.add(doctorWarn, "editor", "$EDITOR is blank")
+		return
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		report.add(doctorWarn, "editor", fmt.Sprintf("%q not found in PATH", fields[0]))
+		return
+	}
+	report.add(doctorPass, "editor", editor)
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
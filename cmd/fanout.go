@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/dixson3/lirt/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// allProfiles is the --profile value that runs a command against every
+// configured profile
+const allProfiles = "all"
+
+// fanOutCommands are the read-only list commands --profile all accepts,
+// as dotted command paths
+var fanOutCommands = map[string]bool{
+	"comment.list":    true,
+	"fav.list":        true,
+	"initiative.list": true,
+	"issue.list":      true,
+	"milestone.list":  true,
+	"project.list":    true,
+	"team.list":       true,
+	"user.list":       true,
+}
+
+// fanOutSkipFlags are not passed on to the per-profile runs: the profile
+// is set per run, output is shaped once over the merged results, and
+// repeating is done by the parent
+var fanOutSkipFlags = map[string]bool{
+	"profile": true,
+	"format":  true,
+	"jq":      true,
+	"repeat":  true,
+	"jitter":  true,
+}
+
+// fanOutRows build, from one profile's JSON output, the rows a list command
+// shows in table, CSV, and plain output, for lists not shown as their JSON
+// objects. Other lists are merged as the listed objects themselves.
+var fanOutRows = map[string]func(data []byte) (interface{}, error){
+	"initiative.list": rowsOf(initiativeRows),
+	"project.list":    rowsOf(projectRows),
+	"user.list":       rowsOf(userRows),
+}
+
+// rowsOf adapts a command's row builder to decode its input from JSON
+func rowsOf[T, R any](rows func([]T) []R) func(data []byte) (interface{}, error) {
+	return func(data []byte) (interface{}, error) {
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		return rows(items), nil
+	}
+}
+
+// setupFanOut rejects --profile all for commands other than read-only
+// lists and, for lists, replaces the command with a fan-out over every
+// profile
+func setupFanOut(cmd *cobra.Command) error {
+	if profileFlag != allProfiles || cmd.RunE == nil {
+		return nil
+	}
+
+	path := strings.Join(commandPath(cmd), ".")
+	if !fanOutCommands[path] {
+		return fmt.Errorf("--profile all only works with read-only list commands, not %s", strings.Join(commandPath(cmd), " "))
+	}
+	if apiKeyFlag != "" || os.Getenv("LIRT_API_KEY") != "" || os.Getenv("LIRT_API_KEY_FILE") != "" {
+		return fmt.Errorf("--profile all uses each profile's own API key; --api-key, LIRT_API_KEY, and LIRT_API_KEY_FILE would query one workspace for every profile")
+	}
+
+	cmd.RunE = fanOutRun
+	return nil
+}
+
+// profileResult is the output of one profile's run
+type profileResult struct {
+	items  []map[string]interface{}
+	stderr []byte
+	err    error
+}
+
+// fanOutRun runs the command once per configured profile, concurrently and
+// each in its own lirt process (so with its own client, config, and
+// cache), and writes the merged results with PROFILE and WORKSPACE columns.
+// A profile that fails is reported as a warning; the command only fails if
+// every profile does.
+func fanOutRun(cmd *cobra.Command, args []string) error {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	if len(profiles) == 0 {
+		return fmt.Errorf("no profiles configured - run 'lirt auth login' to set one up")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	path := strings.Join(commandPath(cmd), ".")
+	childArgs := append(commandPath(cmd), args...)
+	childArgs = append(childArgs, fanOutFlags(cmd)...)
+
+	results := make([]profileResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runProfile(exe, name, path, childArgs)
+		}()
+	}
+	wg.Wait()
+
+	if err := rootCtx.Err(); err != nil {
+		return err
+	}
+
+	merged := []map[string]interface{}{}
+	failed := 0
+	for i, name := range names {
+		result := results[i]
+		os.Stderr.Write(result.stderr)
+		if result.err != nil {
+			failed++
+			formatter.Statusf("Warning: profile %s: %v\n", name, result.err)
+			continue
+		}
+		for _, item := range result.items {
+			item["profile"] = name
+			item["workspace"] = profiles[name]
+			merged = append(merged, item)
+		}
+	}
+	if failed == len(names) {
+		return fmt.Errorf("%s failed for every profile", strings.Join(commandPath(cmd), " "))
+	}
+
+	// --json was applied by each run; the merged items also carry the
+	// profile and workspace, which it would drop
+	formatter.SetFields(nil)
+	formatter.SetLeadingColumns("PROFILE", "WORKSPACE")
+	return formatter.Output(merged)
+}
+
+// runProfile runs lirt with args for one profile and decodes its JSON list
+// output into the items to merge (see profileItems). The run's stderr is
+// returned so status lines are not lost, except when it fails, where the
+// error message is taken from it instead.
+func runProfile(exe, profile, path string, args []string) profileResult {
+	run := exec.CommandContext(rootCtx, exe, append(args, "--profile", profile, "--format", "json")...)
+	var stdout, stderr bytes.Buffer
+	run.Stdout = &stdout
+	run.Stderr = &stderr
+
+	if err := run.Run(); err != nil {
+		if msg := strings.TrimSpace(strings.TrimPrefix(stderr.String(), "Error: ")); msg != "" {
+			return profileResult{err: fmt.Errorf("%s", msg)}
+		}
+		return profileResult{err: err}
+	}
+
+	items, err := profileItems(path, stdout.Bytes())
+	if err != nil {
+		return profileResult{stderr: stderr.Bytes(), err: fmt.Errorf("output is not a list of objects (--group-by and --count-by are not supported with --profile all)")}
+	}
+	return profileResult{items: items, stderr: stderr.Bytes()}
+}
+
+// profileItems decodes one profile's JSON list output into the items to
+// merge: outside JSON output the rows the command would show (fanOutRows),
+// otherwise the listed objects
+func profileItems(path string, data []byte) ([]map[string]interface{}, error) {
+	var list interface{} = json.RawMessage(data)
+	if rows, ok := fanOutRows[path]; ok && formatter.Format() != output.FormatJSON {
+		built, err := rows(data)
+		if err != nil {
+			return nil, err
+		}
+		list = built
+	}
+
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(encoded, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// fanOutFlags returns the flags set on the command line as arguments for
+// the per-profile runs, leaving out fanOutSkipFlags. Slice flags are passed
+// once per value.
+func fanOutFlags(cmd *cobra.Command) []string {
+	flags := []string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if fanOutSkipFlags[f.Name] {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			for _, value := range slice.GetSlice() {
+				flags = append(flags, "--"+f.Name+"="+value)
+			}
+			return
+		}
+		flags = append(flags, "--"+f.Name+"="+f.Value.String())
+	})
+	return flags
+}
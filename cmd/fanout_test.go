@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/dixson3/lirt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// TestSetupFanOut verifies --profile all replaces only read-only list
+// commands, and is rejected for other commands and with an API key override.
+func TestSetupFanOut(t *testing.T) {
+	run := func(cmd *cobra.Command, args []string) error { return nil }
+
+	tests := []struct {
+		name    string
+		path    []string
+		profile string
+		apiKey  string
+		env     map[string]string
+		wantErr bool
+		wantFan bool
+	}{
+		{name: "List", path: []string{"issue", "list"}, profile: allProfiles, wantFan: true},
+		{name: "Other profile", path: []string{"issue", "create"}, profile: "work"},
+		{name: "Mutating command", path: []string{"issue", "create"}, profile: allProfiles, wantErr: true},
+		{name: "View command", path: []string{"project", "view"}, profile: allProfiles, wantErr: true},
+		{name: "API key flag", path: []string{"issue", "list"}, profile: allProfiles, apiKey: "lin_api_x", wantErr: true},
+		{name: "API key env", path: []string{"issue", "list"}, profile: allProfiles, env: map[string]string{"LIRT_API_KEY": "lin_api_x"}, wantErr: true},
+		{name: "API key file env", path: []string{"issue", "list"}, profile: allProfiles, env: map[string]string{"LIRT_API_KEY_FILE": "/tmp/key"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIRT_API_KEY", "")
+			t.Setenv("LIRT_API_KEY_FILE", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			oldProfile, oldAPIKey := profileFlag, apiKeyFlag
+			profileFlag, apiKeyFlag = tt.profile, tt.apiKey
+			defer func() { profileFlag, apiKeyFlag = oldProfile, oldAPIKey }()
+
+			cmd := &cobra.Command{Use: "lirt"}
+			for _, name := range tt.path {
+				child := &cobra.Command{Use: name}
+				cmd.AddCommand(child)
+				cmd = child
+			}
+			cmd.RunE = run
+
+			err := setupFanOut(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setupFanOut() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			fanned := reflect.ValueOf(cmd.RunE).Pointer() == reflect.ValueOf(fanOutRun).Pointer()
+			if fanned != tt.wantFan {
+				t.Errorf("RunE replaced by fanOutRun = %v, want %v", fanned, tt.wantFan)
+			}
+		})
+	}
+}
+
+// TestFanOutFlags verifies flags set on the command line are passed on,
+// slices once per value, except the ones the parent applies itself.
+func TestFanOutFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringSlice("team", nil, "")
+	cmd.Flags().Bool("all-teams", false, "")
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().String("state", "", "")
+	cmd.Flags().String("profile", "", "")
+	cmd.Flags().String("format", "", "")
+	cmd.Flags().String("jq", "", "")
+	cmd.Flags().Int("repeat", 0, "")
+
+	args := []string{"--team", "ENG,OPS", "--all-teams", "--limit", "5", "--profile", "all", "--format", "table", "--jq", ".[]", "--repeat", "3"}
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	got := fanOutFlags(cmd)
+	want := []string{"--all-teams=true", "--limit=5", "--team=ENG", "--team=OPS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fanOutFlags() = %q, want %q", got, want)
+	}
+}
+
+// TestProfileItems verifies merged lists use the command's own rows outside
+// JSON output and the listed objects in JSON.
+func TestProfileItems(t *testing.T) {
+	data := []byte(`[{"id":"p1","name":"Roadmap","state":"started","priority":2,"progress":0.5}]`)
+
+	tests := []struct {
+		name   string
+		format output.Format
+		path   string
+		want   map[string]interface{}
+	}{
+		{name: "Project rows", format: output.FormatTable, path: "project.list", want: map[string]interface{}{"name": "Roadmap", "state": "started", "priority": float64(2), "progress": "50%"}},
+		{name: "Project JSON", format: output.FormatJSON, path: "project.list", want: map[string]interface{}{"id": "p1", "name": "Roadmap", "state": "started", "priority": float64(2), "progress": 0.5}},
+		{name: "No row builder", format: output.FormatCSV, path: "team.list", want: map[string]interface{}{"id": "p1", "name": "Roadmap", "state": "started", "priority": float64(2), "progress": 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldFormatter := formatter
+			formatter = output.New(tt.format, io.Discard)
+			defer func() { formatter = oldFormatter }()
+
+			items, err := profileItems(tt.path, data)
+			if err != nil {
+				t.Fatalf("profileItems() error = %v", err)
+			}
+			if len(items) != 1 || !reflect.DeepEqual(items[0], tt.want) {
+				t.Errorf("profileItems() = %v, want [%v]", items, tt.want)
+			}
+		})
+	}
+
+	if _, err := profileItems("issue.list", []byte(`{"ENG":[]}`)); err == nil {
+		t.Error("profileItems() accepted grouped output, want an error")
+	}
+}
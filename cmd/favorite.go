@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	favProjectFlag bool
+)
+
+// favoriteEntry is one row of fav list output
+type favoriteEntry struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Ref   string `json:"ref"`   // issue identifier or project ID
+	Title string `json:"title"` // issue title or project name
+}
+
+// favCmd represents the fav command
+var favCmd = &cobra.Command{
+	Use:     "fav",
+	Aliases: []string{"favorite"},
+	Short:   "Manage favorite issues and projects",
+	Long: `Star issues and projects for quick access.
+
+By default favorites are stored in Linear, so they also appear in the web
+app sidebar. Set favorites = local in the profile config to keep them only
+in a local file under the config directory instead:
+
+  lirt config set favorites local`,
+}
+
+// favAddCmd represents the fav add command
+var favAddCmd = &cobra.Command{
+	Use:   "add <issue-id>",
+	Short: "Add a favorite",
+	Long: `Add an issue, or a project with --project, to your favorites.
+
+Examples:
+  lirt fav add ENG-123
+  lirt fav add --project 5f3c9a1e-...`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		var favorite *model.Favorite
+		if cfg.Favorites == config.FavoritesLocal {
+			favorite, err = localFavorite(apiClient, args[0])
+			if err == nil {
+				err = config.AddLocalFavorite(cfg.Profile, *favorite)
+			}
+		} else {
+			favorite, err = createFavorite(apiClient, args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add favorite: %w", err)
+		}
+
+		if !noCacheFlag {
+			cacheInstance.Invalidate("favorites")
+		}
+
+		if !quietFlag {
+			entry := toFavoriteEntry(*favorite)
+			formatter.Statusf("✓ Added %s %s to favorites\n", entry.Type, entry.Ref)
+		}
+		return nil
+	},
+}
+
+// favRemoveCmd represents the fav rm command
+var favRemoveCmd = &cobra.Command{
+	Use:     "rm <issue-id|project-id|favorite-id>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a favorite",
+	Long: `Remove an issue or project from your favorites.
+
+Examples:
+  lirt fav rm ENG-123`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		local := cfg.Favorites == config.FavoritesLocal
+
+		// Match against current favorites rather than a cached list
+		var apiClient *client.Client
+		var favorites []model.Favorite
+		var err error
+		if local {
+			favorites, err = config.LoadLocalFavorites(cfg.Profile)
+		} else if apiClient, err = getClient(); err == nil {
+			favorites, err = apiClient.ListFavorites(getContext())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list favorites: %w", err)
+		}
+
+		favorite, err := client.FindFavorite(favorites, args[0])
+		if err != nil {
+			return err
+		}
+
+		if local {
+			err = config.RemoveLocalFavorite(cfg.Profile, favorite.ID)
+		} else {
+			err = apiClient.DeleteFavorite(getContext(), favorite.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove favorite: %w", err)
+		}
+
+		if !noCacheFlag {
+			cacheInstance.Invalidate("favorites")
+		}
+
+		if !quietFlag {
+			entry := toFavoriteEntry(*favorite)
+			formatter.Statusf("✓ Removed %s %s from favorites\n", entry.Type, entry.Ref)
+		}
+		return nil
+	},
+}
+
+// favListCmd represents the fav list command
+var favListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List favorites",
+	Long:  `List your favorite issues and projects.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		favorites, err := listFavorites()
+		if err != nil {
+			return err
+		}
+
+		entries := make([]favoriteEntry, 0, len(favorites))
+		for _, favorite := range favorites {
+			entries = append(entries, toFavoriteEntry(favorite))
+		}
+		return formatter.Output(entries)
+	},
+}
+
+// listFavorites returns favorites from the local file or from Linear,
+// depending on the favorites setting. Linear favorites are cached.
+func listFavorites() ([]model.Favorite, error) {
+	if cfg.Favorites == config.FavoritesLocal {
+		return config.LoadLocalFavorites(cfg.Profile)
+	}
+
+	apiClient, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := listCacheKey("favorites")
+	var favorites []model.Favorite
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &favorites); err == nil && found {
+			return favorites, nil
+		}
+	}
+
+	favorites, err = apiClient.ListFavorites(listContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list favorites: %w", err)
+	}
+
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, favorites)
+	}
+	return favorites, nil
+}
+
+// createFavorite favorites an issue or, with --project, a project in Linear
+func createFavorite(apiClient *client.Client, ref string) (*model.Favorite, error) {
+	input := &client.CreateFavoriteInput{}
+	if favProjectFlag {
+		input.ProjectID = &ref
+	} else {
+		issueID, err := apiClient.ResolveIssueID(getContext(), ref)
+		if err != nil {
+			return nil, err
+		}
+		input.IssueID = &issueID
+	}
+	return apiClient.CreateFavorite(getContext(), input)
+}
+
+// localFavorite looks up an issue or, with --project, a project and builds
+// a local favorite for it, keyed by the issue or project ID
+func localFavorite(apiClient *client.Client, ref string) (*model.Favorite, error) {
+	favorite := &model.Favorite{CreatedAt: time.Now().UTC()}
+	if favProjectFlag {
+		project, err := apiClient.GetProject(getContext(), ref)
+		if err != nil {
+			return nil, err
+		}
+		favorite.ID = project.ID
+		favorite.Type = "project"
+		favorite.Project = &model.Project{ID: project.ID, Name: project.Name}
+		return favorite, nil
+	}
+
+	issueID, err := apiClient.ResolveIssueID(getContext(), ref)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := apiClient.GetIssue(getContext(), issueID)
+	if err != nil {
+		return nil, err
+	}
+	favorite.ID = issue.ID
+	favorite.Type = "issue"
+	favorite.Issue = &model.Issue{ID: issue.ID, Identifier: issue.Identifier, Title: issue.Title}
+	return favorite, nil
+}
+
+// toFavoriteEntry flattens a favorite into a list row
+func toFavoriteEntry(favorite model.Favorite) favoriteEntry {
+	entry := favoriteEntry{ID: favorite.ID, Type: favorite.Type}
+	switch {
+	case favorite.Issue != nil:
+		entry.Type = "issue"
+		entry.Ref = favorite.Issue.Identifier
+		entry.Title = favorite.Issue.Title
+	case favorite.Project != nil:
+		entry.Type = "project"
+		entry.Ref = favorite.Project.ID
+		entry.Title = favorite.Project.Name
+	}
+	return entry
+}
+
+func init() {
+	rootCmd.AddCommand(favCmd)
+
+	// Add subcommands
+	favCmd.AddCommand(favAddCmd)
+	favCmd.AddCommand(favRemoveCmd)
+	favCmd.AddCommand(favListCmd)
+
+	// Flags for fav add
+	favAddCmd.Flags().BoolVar(&favProjectFlag, "project", false, "Favorite a project instead of an issue")
+}
@@ -1,18 +1,24 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"time"
 
 	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
+// initiativeViewTTL bounds caching of initiative view, which aggregates
+// progress across every project in the initiative
+const initiativeViewTTL = 1 * time.Minute
+
 var (
-	initiativeNameFlag string
-	initiativeDescFlag string
+	initiativeNameFlag    string
+	initiativeDescFlag    string
+	initiativeSortFlag    string
+	initiativeReverseFlag bool
 )
 
 // initiativeCmd represents the initiative command
@@ -26,43 +32,94 @@ var initiativeCmd = &cobra.Command{
 var initiativeListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all initiatives",
-	Long:  `List all initiatives.`,
+	Long: `List all initiatives with their status, owner, and target date.
+
+Initiatives are sorted by target date; those without one are listed last.
+
+Examples:
+  lirt initiative list
+  lirt initiative list --sort name`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateField("--sort", initiativeSortFlag, client.InitiativeSortFields); err != nil {
+			return err
+		}
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
 		}
 
 		// Check cache first
-		cacheKey := "initiatives"
-		var initiatives interface{}
+		cacheKey := listCacheKey("initiatives")
+		var initiatives []model.Initiative
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &initiatives); err == nil && found {
-				return formatter.Output(initiatives)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		initiatives, err = apiClient.ListInitiatives(getContext())
-		if err != nil {
-			return fmt.Errorf("failed to list initiatives: %w", err)
+		if !cached {
+			initiatives, err = apiClient.ListInitiatives(listContext())
+			if err != nil {
+				return fmt.Errorf("failed to list initiatives: %w", err)
+			}
+
+			// Cache results
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, initiatives)
+			}
 		}
 
-		// Cache results
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, initiatives)
+		client.SortInitiatives(initiatives, initiativeSortFlag, initiativeReverseFlag)
+		return outputInitiatives(initiatives)
+	},
+}
+
+// initiativeRow is an initiative as listed in table, CSV, and plain
+// output. JSON output keeps the full model.Initiative.
+type initiativeRow struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	Owner      string     `json:"owner,omitempty"`
+	TargetDate *time.Time `json:"targetDate,omitempty"`
+}
+
+// initiativeRows converts initiatives to the rows listed outside JSON
+func initiativeRows(initiatives []model.Initiative) []initiativeRow {
+	rows := make([]initiativeRow, len(initiatives))
+	for i, initiative := range initiatives {
+		rows[i] = initiativeRow{
+			Name:       initiative.Name,
+			Status:     initiative.Status,
+			TargetDate: initiative.TargetDate,
+		}
+		if initiative.Owner != nil {
+			rows[i].Owner = initiative.Owner.Name
 		}
+	}
+	return rows
+}
 
+// outputInitiatives writes initiatives as a tracking view (name, status,
+// owner, target date) outside JSON, which carries every field
+func outputInitiatives(initiatives []model.Initiative) error {
+	if formatter.Format() == output.FormatJSON {
 		return formatter.Output(initiatives)
-	},
+	}
+	return formatter.Output(initiativeRows(initiatives))
 }
 
 // initiativeViewCmd represents the initiative view command
 var initiativeViewCmd = &cobra.Command{
 	Use:   "view <initiative-id>",
 	Short: "View initiative details",
-	Long:  `View detailed information about a specific initiative.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific initiative.
+
+Includes status, health, and percent complete rolled up across the
+initiative's projects (weighted by project scope when available).`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -71,12 +128,12 @@ var initiativeViewCmd = &cobra.Command{
 
 		initiativeID := args[0]
 
-		// Check cache
+		// Check cache (short TTL since the rollup is expensive but changes often)
 		cacheKey := fmt.Sprintf("initiative-%s", initiativeID)
-		var initiative interface{}
+		var initiative *model.Initiative
 		if !noCacheFlag {
-			if found, err := cacheInstance.Get(cacheKey, &initiative); err == nil && found {
-				return formatter.Output(initiative)
+			if found, err := cacheInstance.GetWithTTL(cacheKey, initiativeViewTTL, &initiative); err == nil && found {
+				return outputInitiative(initiative)
 			}
 		}
 
@@ -91,7 +148,7 @@ var initiativeViewCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, initiative)
 		}
 
-		return formatter.Output(initiative)
+		return outputInitiative(initiative)
 	},
 }
 
@@ -110,7 +167,7 @@ var initiativeProjectsCmd = &cobra.Command{
 		initiativeID := args[0]
 
 		// Check cache
-		cacheKey := fmt.Sprintf("initiative-projects-%s", initiativeID)
+		cacheKey := listCacheKey(fmt.Sprintf("initiative-projects-%s", initiativeID))
 		var projects interface{}
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &projects); err == nil && found {
@@ -119,7 +176,7 @@ var initiativeProjectsCmd = &cobra.Command{
 		}
 
 		// Fetch from API
-		projects, err = apiClient.ListInitiativeProjects(getContext(), initiativeID)
+		projects, err = apiClient.ListInitiativeProjects(listContext(), initiativeID)
 		if err != nil {
 			return fmt.Errorf("failed to list initiative projects: %w", err)
 		}
@@ -169,7 +226,7 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Created initiative %s\n", initiative.Name)
+			formatter.Statusf("✓ Created initiative %s\n", initiative.Name)
 		}
 
 		return formatter.Output(initiative)
@@ -207,7 +264,7 @@ var initiativeEditCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Updated initiative %s\n", initiativeID)
+			formatter.Statusf("✓ Updated initiative %s\n", initiativeID)
 		}
 
 		return nil
@@ -229,15 +286,8 @@ var initiativeArchiveCmd = &cobra.Command{
 		initiativeID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Archive initiative %s? (y/N): ", initiativeID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Archive initiative %s?", initiativeID)); err != nil || !ok {
+			return err
 		}
 
 		// Archive initiative
@@ -246,7 +296,7 @@ var initiativeArchiveCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Archived initiative %s\n", initiativeID)
+			formatter.Statusf("✓ Archived initiative %s\n", initiativeID)
 		}
 
 		return nil
@@ -268,15 +318,8 @@ var initiativeDeleteCmd = &cobra.Command{
 		initiativeID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Delete initiative %s? This cannot be undone. (y/N): ", initiativeID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Delete initiative %s? This cannot be undone.", initiativeID)); err != nil || !ok {
+			return err
 		}
 
 		// Delete initiative
@@ -285,13 +328,62 @@ var initiativeDeleteCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Deleted initiative %s\n", initiativeID)
+			formatter.Statusf("✓ Deleted initiative %s\n", initiativeID)
 		}
 
 		return nil
 	},
 }
 
+// outputInitiative writes an initiative, rendering a progress rollup in
+// table and plain formats
+func outputInitiative(initiative *model.Initiative) error {
+	switch formatter.Format() {
+	case output.FormatTable, output.FormatPlain:
+	default:
+		return formatter.Output(initiative)
+	}
+
+	w := formatter.Writer()
+	health := initiative.Health
+	if health == "" {
+		health = "—"
+	}
+
+	fmt.Fprintf(w, "Name:      %s\n", initiative.Name)
+	fmt.Fprintf(w, "Status:    %s\n", initiative.Status)
+	fmt.Fprintf(w, "Health:    %s\n", health)
+	if initiative.Owner != nil {
+		fmt.Fprintf(w, "Owner:     %s\n", initiative.Owner.Name)
+	}
+	if initiative.TargetDate != nil {
+		fmt.Fprintf(w, "Target:    %s\n", initiative.TargetDate.Format("2006-01-02"))
+	}
+	fmt.Fprintf(w, "Progress:  %s (%d projects)\n", formatProgress(initiative.Progress), len(initiative.Projects))
+
+	if len(initiative.Projects) == 0 {
+		return nil
+	}
+
+	type projectRow struct {
+		Name     string
+		State    string
+		Progress string
+	}
+
+	rows := make([]projectRow, 0, len(initiative.Projects))
+	for _, p := range initiative.Projects {
+		rows = append(rows, projectRow{
+			Name:     p.Name,
+			State:    p.State,
+			Progress: formatProgress(p.Progress),
+		})
+	}
+
+	fmt.Fprintln(w)
+	return formatter.Output(rows)
+}
+
 func init() {
 	rootCmd.AddCommand(initiativeCmd)
 
@@ -304,6 +396,10 @@ func init() {
 	initiativeCmd.AddCommand(initiativeArchiveCmd)
 	initiativeCmd.AddCommand(initiativeDeleteCmd)
 
+	// Flags for initiative list
+	initiativeListCmd.Flags().StringVar(&initiativeSortFlag, "sort", "target-date", "Sort by field (target-date, name)")
+	initiativeListCmd.Flags().BoolVar(&initiativeReverseFlag, "reverse", false, "Reverse the sort order")
+
 	// Flags for initiative create
 	initiativeCreateCmd.Flags().StringVar(&initiativeNameFlag, "name", "", "Initiative name (required)")
 	initiativeCreateCmd.Flags().StringVar(&initiativeDescFlag, "description", "", "Initiative description")
@@ -1,30 +1,83 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dixson3/lirt/internal/cache"
 	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/config"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	issueTeamFlag      string
-	issueStateFlag     string
-	issueAssigneeFlag  string
-	issueLabelFlag     []string
-	issueProjectFlag   string
-	issuePriorityFlag  string
-	issueMilestoneFlag string
-	issueParentFlag    string
-	issueSearchFlag    string
-	issueTitleFlag     string
-	issueDescFlag      string
+	issueTeamFlag        string
+	issueListTeamsFlag   []string
+	issueStateFlag       string
+	issueAssigneeFlag    string
+	issueLabelFlag       []string
+	issueProjectFlag     string
+	issuePriorityFlag    string
+	issueMilestoneFlag   string
+	issueParentFlag      string
+	issueSearchFlag      string
+	issueTitleFlag       string
+	issueDescFlag        string
+	issueSortFlag        string
+	issueGroupByFlag     string
+	issueIncrementalFlag bool
+	issueWebFlag         bool
+	issueOpenFlag        bool
+	issueEmojiFlag       string
+	issueCreatedByFlag   string
+	issueRawFlag         bool
+	issueOverdueFlag     bool
+	issueNoDueDateFlag   bool
+	issueCommentFlag     string
+	issueUntilFlag       string
+	issueUnsnoozeFlag    bool
+	issueAllFlag         bool
+	issueAllTeamsFlag    bool
+	issueStateTypeFlag   string
+	issueTriageFlag      bool
+	issueWithDescFlag    bool
+	issueNoDescFlag      bool
+	issueCountByFlag     string
+	issueCreatedInFlag   string
+	issueCompletedInFlag string
+	issueOlderThanFlag   string
+	issueAppendDescFlag  string
+	issuePrependDescFlag string
+	issueNoLabelFlag     bool
+	issueHasLabelFlag    bool
+	issueRunFlag         bool
+	issueRefsFlag        bool
+
+	// issue edit --clear-* flags
+	issueClearProjectFlag  bool
+	issueClearParentFlag   bool
+	issueClearPriorityFlag bool
+	issueClearDueDateFlag  bool
 )
 
+// issueListFields are the fields accepted by issue list --sort and --group-by
+var issueListFields = []string{"state", "priority", "project", "assignee", "team"}
+
+// issueGroupFields additionally allows grouping by label; an issue with
+// several labels is listed under each of them
+var issueGroupFields = append(issueListFields, "label")
+
+// issueCountFields are the fields accepted by issue list --count-by
+var issueCountFields = []string{"state", "priority", "assignee", "label", "project"}
+
 // issueCmd represents the issue command
 var issueCmd = &cobra.Command{
 	Use:   "issue",
@@ -32,34 +85,163 @@ var issueCmd = &cobra.Command{
 	Long:  `Create, view, edit, and manage Linear issues.`,
 }
 
+// defaultIssueLimit caps issue list when --limit is not given, so a bare
+// issue list does not page through the whole workspace
+const defaultIssueLimit = 50
+
 // issueListCmd represents the issue list command
 var issueListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List issues",
-	Long:  `List issues with optional filters.`,
+	Long: `List issues with optional filters.
+
+Without --limit, at most 50 issues are listed; use --limit 0 to list every
+matching issue. --count-by always counts every matching issue.
+
+Examples:
+  lirt issue list --team ENG
+  lirt issue list --team ENG --team DES
+  lirt issue list --team ENG --assignee none
+  lirt issue list --project none
+  lirt issue list --team ENG --group-by state --sort priority
+  lirt issue list --team ENG --group-by label
+  lirt issue list --created-by me
+  lirt issue list --parent ENG-100
+  lirt issue list --parent .
+  lirt issue list --team ENG --overdue
+  lirt issue list --team ENG --state-type triage
+  lirt issue list --team ENG --include-description --format json
+  lirt issue list --team ENG --count-by state
+  lirt issue list --team ENG --completed-in-cycle current --count-by assignee`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateField("--sort", issueSortFlag, issueListFields); err != nil {
+			return err
+		}
+		if err := validateField("--group-by", issueGroupByFlag, issueGroupFields); err != nil {
+			return err
+		}
+		if err := validateField("--state-type", issueStateTypeFlag, stateTypes); err != nil {
+			return err
+		}
+		if err := validateField("--count-by", issueCountByFlag, issueCountFields); err != nil {
+			return err
+		}
+		groupBy := issueGroupByFlag
+		if groupBy == "label" {
+			groupBy = "labels"
+		}
+
+		// Counting needs every issue; a plain listing is capped by default
+		capped := !cmd.Flags().Changed("limit") && issueCountByFlag == ""
+		if capped {
+			limitFlag = defaultIssueLimit
+		}
+
+		// --count-by prints a count per value instead of the issues
+		outputIssues := func(issues []model.Issue) error {
+			if capped && len(issues) == defaultIssueLimit {
+				formatter.Statusf("Showing the first %d issues; use --limit to list more\n", defaultIssueLimit)
+			}
+			switch issueCountByFlag {
+			case "":
+				return outputList(issues, issueSortFlag, groupBy)
+			case "label":
+				return formatter.OutputCounts(issues, "labels")
+			default:
+				return formatter.OutputCounts(issues, issueCountByFlag)
+			}
+		}
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
 		}
 
 		// Build filters
-		filters := &client.IssueFilters{}
+		filters := &client.IssueFilters{
+			IncludeDescription: issueWithDescFlag && !issueNoDescFlag,
+		}
 
-		if issueTeamFlag != "" {
-			teamID, err := resolveTeamID(apiClient, issueTeamFlag)
+		// Resolve every --team up front; several teams match any of them.
+		// Without one, the default team applies unless --all-teams.
+		teamRefs := issueListTeamsFlag
+		if len(teamRefs) == 0 {
+			if team := defaultTeamRef(issueAllTeamsFlag); team != "" {
+				teamRefs = []string{team}
+			}
+		}
+		teamKey := ""
+		switch len(teamRefs) {
+		case 0:
+		case 1:
+			teamID, err := resolveTeamID(apiClient, teamRefs[0])
 			if err != nil {
 				return err
 			}
 			filters.TeamID = &teamID
+			teamKey = teamID
+		default:
+			teams, err := getTeams(apiClient)
+			if err != nil {
+				return err
+			}
+			teamIDs, err := client.ResolveTeamIDs(teams, teamRefs)
+			if err != nil {
+				return err
+			}
+			filters.TeamIDs = teamIDs
+			sorted := append([]string{}, teamIDs...)
+			sort.Strings(sorted)
+			teamKey = strings.Join(sorted, ",")
 		}
 
 		if issueStateFlag != "" {
 			filters.StateID = &issueStateFlag
 		}
 
-		if issueAssigneeFlag != "" {
-			filters.AssigneeID = &issueAssigneeFlag
+		if issueStateTypeFlag != "" {
+			filters.StateType = &issueStateTypeFlag
+		}
+
+		if isNoneValue(issueAssigneeFlag) {
+			filters.Unassigned = true
+		} else if issueAssigneeFlag != "" {
+			assigneeID, err := resolveUserID(apiClient, issueAssigneeFlag)
+			if err != nil {
+				return err
+			}
+			filters.AssigneeID = &assigneeID
+		}
+
+		if issueCreatedByFlag != "" {
+			creatorID, err := resolveUserID(apiClient, issueCreatedByFlag)
+			if err != nil {
+				return err
+			}
+			filters.CreatorID = &creatorID
+		}
+
+		if isNoneValue(issueProjectFlag) {
+			filters.NoProject = true
+		} else if issueProjectFlag != "" {
+			filters.ProjectID = &issueProjectFlag
+		}
+
+		if issueMilestoneFlag != "" {
+			filters.MilestoneID = &issueMilestoneFlag
+		}
+
+		parentID := ""
+		if issueParentFlag != "" {
+			parentRef, err := branchIssueRef(issueParentFlag)
+			if err != nil {
+				return err
+			}
+			parentID, err = apiClient.ResolveIssueID(getContext(), parentRef)
+			if err != nil {
+				return err
+			}
+			filters.ParentID = &parentID
 		}
 
 		if issuePriorityFlag != "" {
@@ -74,17 +256,72 @@ var issueListCmd = &cobra.Command{
 			filters.Search = &issueSearchFlag
 		}
 
-		// Check cache first
-		cacheKey := fmt.Sprintf("issues-%s-%s-%s-%s-%s", issueTeamFlag, issueStateFlag, issueAssigneeFlag, issuePriorityFlag, issueSearchFlag)
-		var issues interface{}
+		if issueOverdueFlag {
+			today := time.Now()
+			filters.Overdue = &today
+		}
+		filters.NoDueDate = issueNoDueDateFlag
+		filters.NoLabels = issueNoLabelFlag
+		filters.HasLabels = issueHasLabelFlag
+
+		// Open issues not updated within --older-than, highlighted in tables
+		if issueOlderThanFlag != "" {
+			cutoff, err := client.ParseAge(issueOlderThanFlag, time.Now())
+			if err != nil {
+				return fmt.Errorf("--older-than: %w", err)
+			}
+			filters.StaleSince = &cutoff
+			formatter.SetHighlightBefore("updatedAt", cutoff)
+		}
+
+		// Scope to a cycle of the (single) team by creation or completion
+		cycleKey := ""
+		for _, scope := range []struct {
+			flag, ref string
+			target    **client.TimeRange
+		}{
+			{"--created-in-cycle", issueCreatedInFlag, &filters.CreatedIn},
+			{"--completed-in-cycle", issueCompletedInFlag, &filters.CompletedIn},
+		} {
+			if scope.ref == "" {
+				continue
+			}
+			if filters.TeamID == nil {
+				return fmt.Errorf("%s requires a single --team", scope.flag)
+			}
+			cycles, err := getCycles(apiClient, *filters.TeamID)
+			if err != nil {
+				return err
+			}
+			cycle, err := client.FindCycle(cycles, scope.ref, time.Now())
+			if err != nil {
+				return err
+			}
+			*scope.target = &client.TimeRange{Start: cycle.StartsAt, End: cycle.EndsAt}
+			cycleKey += scope.flag + cycle.ID
+		}
+
+		// Check cache first. A larger cached list with the same filters
+		// (uncapped or a bigger --limit) also serves a capped request.
+		baseKey := fmt.Sprintf("issues-%s-%s-%s-%s-%s-%s-%s-%s-%s-%s-%t-%t-%t-%t-%t-%s-%s", teamKey, issueStateFlag, issueStateTypeFlag, issueAssigneeFlag, issueProjectFlag, issueMilestoneFlag, parentID, issuePriorityFlag, issueSearchFlag, issueCreatedByFlag, issueOverdueFlag, issueNoDueDateFlag, issueNoLabelFlag, issueHasLabelFlag, filters.IncludeDescription, cycleKey, issueOlderThanFlag)
+		cacheKey := listCacheKey(baseKey)
 		if !noCacheFlag {
-			if found, err := cacheInstance.Get(cacheKey, &issues); err == nil && found {
-				return formatter.Output(issues)
+			if cached, found, err := cache.GetList[model.Issue](cacheInstance, baseKey, limitFlag); err == nil && found {
+				return outputIssues(cached)
+			}
+		}
+
+		// Refresh only what changed since the stale cached set
+		if issueIncrementalFlag && !noCacheFlag {
+			refreshed, err := refreshIssuesIncremental(apiClient, cacheKey, filters)
+			if err != nil {
+				return fmt.Errorf("failed to list issues: %w", err)
 			}
+			return outputIssues(refreshed)
 		}
 
 		// Fetch from API
-		issues, err = apiClient.ListIssues(getContext(), filters)
+		issues, err := apiClient.ListIssues(listContext(), filters)
 		if err != nil {
 			return fmt.Errorf("failed to list issues: %w", err)
 		}
@@ -94,7 +331,7 @@ var issueListCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, issues)
 		}
 
-		return formatter.Output(issues)
+		return outputIssues(issues)
 	},
 }
 
@@ -102,8 +339,14 @@ var issueListCmd = &cobra.Command{
 var issueViewCmd = &cobra.Command{
 	Use:   "view <issue-id>",
 	Short: "View issue details",
-	Long:  `View detailed information about a specific issue. Accepts issue identifier (e.g., ENG-123) or UUID.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific issue. Accepts issue identifier (e.g., ENG-123) or UUID.
+
+On a terminal the markdown description is rendered with headings, lists, and
+code blocks; use --raw to print it unrendered. Piped and JSON output is
+always raw.
+
+Use --web to open the issue in your browser instead.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -111,35 +354,76 @@ var issueViewCmd = &cobra.Command{
 		}
 
 		// Resolve issue ID
-		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		id, err := resolveIssueRef(apiClient, args[0])
 		if err != nil {
 			return err
 		}
 
 		// Check cache
 		cacheKey := fmt.Sprintf("issue-%s", id)
-		var issue interface{}
+		var issue *model.Issue
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &issue); err == nil && found {
-				return formatter.Output(issue)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		issue, err = apiClient.GetIssue(getContext(), id)
-		if err != nil {
-			return fmt.Errorf("failed to get issue: %w", err)
+		if !cached {
+			issue, err = apiClient.GetIssue(getContext(), id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %w", err)
+			}
+
+			// Cache result
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, issue)
+			}
 		}
 
-		// Cache result
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, issue)
+		if issueWebFlag {
+			url := issue.URL
+			if url == "" {
+				if url, err = workspaceURL(apiClient, "issue", issue.Identifier); err != nil {
+					return err
+				}
+			}
+			return openWeb(url)
 		}
 
-		return formatter.Output(issue)
+		return outputIssue(issue)
 	},
 }
 
+// outputIssue writes an issue. On a terminal in table and plain formats the
+// markdown description is rendered below the other fields unless --raw.
+func outputIssue(issue *model.Issue) error {
+	switch formatter.Format() {
+	case output.FormatTable, output.FormatPlain:
+	default:
+		return formatter.Output(issue)
+	}
+	if issueRawFlag || issue.Description == "" || !isTerminal() {
+		return formatter.Output(issue)
+	}
+
+	rendered, err := output.RenderMarkdown(issue.Description, terminalWidth())
+	if err != nil {
+		return formatter.Output(issue)
+	}
+
+	details := *issue
+	details.Description = ""
+	if err := formatter.Output(&details); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(formatter.Writer())
+	fmt.Fprint(formatter.Writer(), rendered)
+	return nil
+}
+
 // issueCreateCmd represents the issue create command
 var issueCreateCmd = &cobra.Command{
 	Use:   "create",
@@ -148,7 +432,19 @@ var issueCreateCmd = &cobra.Command{
 
 Examples:
   lirt issue create --team ENG --title "Fix bug"
-  lirt issue create --team ENG --title "New feature" --description "Add support for X" --priority high`,
+  lirt issue create --team ENG --title "New feature" --description "Add support for X" --priority high
+  lirt issue create --team ENG --title "Fix bug" --open
+  lirt issue create --team ENG --title "Customer report" --triage
+  lirt issue create --parent ENG-100 --title "Split out API work"
+
+With --parent and no --team, the sub-issue is created in the parent's team
+and, unless --project is given, its project.
+
+An assignee is optional. --triage files the issue in the team's triage
+state for the team to review instead of a person.
+
+A [team.<KEY>] config section can set default_priority and default_labels
+for the team's new issues; --priority and --label override them.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -156,55 +452,119 @@ Examples:
 		}
 
 		// Validate required flags
-		if issueTeamFlag == "" {
-			return fmt.Errorf("--team is required")
+		if issueTeamFlag == "" && issueParentFlag == "" {
+			return fmt.Errorf("--team is required (or --parent to use the parent's team)")
 		}
 		if issueTitleFlag == "" {
 			return fmt.Errorf("--title is required")
 		}
 
-		// Resolve team ID
-		teamID, err := resolveTeamID(apiClient, issueTeamFlag)
-		if err != nil {
-			return err
-		}
-
 		// Build input
 		input := &client.CreateIssueInput{
-			TeamID: teamID,
-			Title:  issueTitleFlag,
+			Title: issueTitleFlag,
+		}
+
+		if issueProjectFlag != "" {
+			input.ProjectID = &issueProjectFlag
+		}
+
+		// A sub-issue without --team goes to its parent's team and, unless
+		// --project is given, its project
+		teamRef := issueTeamFlag
+		if issueParentFlag != "" {
+			parentID, err := resolveIssueRef(apiClient, issueParentFlag)
+			if err != nil {
+				return err
+			}
+			input.ParentID = &parentID
+
+			if teamRef == "" {
+				parent, err := apiClient.GetIssue(getContext(), parentID)
+				if err != nil {
+					return fmt.Errorf("failed to get parent issue: %w", err)
+				}
+				if parent.Team == nil {
+					return fmt.Errorf("parent issue %s has no team; pass --team", issueParentFlag)
+				}
+				input.InheritFromParent(parent)
+				teamRef = parent.Team.Key
+				logger.Debug("inherited from parent", "parent", parent.Identifier, "team", teamRef, "project", input.ProjectID != nil)
+			}
+		}
+
+		// Resolve team ID
+		if input.TeamID == "" {
+			if input.TeamID, err = resolveTeamID(apiClient, teamRef); err != nil {
+				return err
+			}
 		}
 
 		if issueDescFlag != "" {
 			input.Description = &issueDescFlag
 		}
 
+		// Team defaults from config apply where no flag was given
+		defaults := cfg.DefaultsFor(teamRef)
+
 		if issuePriorityFlag != "" {
 			priority, err := parsePriority(issuePriorityFlag)
 			if err != nil {
 				return err
 			}
 			input.Priority = &priority
+		} else if defaults.Priority != "" {
+			priority, err := parsePriority(defaults.Priority)
+			if err != nil {
+				return fmt.Errorf("[team.%s] default_priority: %w", teamRef, err)
+			}
+			input.Priority = &priority
 		}
 
-		if issueStateFlag != "" {
-			input.StateID = &issueStateFlag
+		labelRefs := issueLabelFlag
+		if len(labelRefs) == 0 {
+			labelRefs = defaults.Labels
 		}
+		if len(labelRefs) > 0 {
+			labels, err := getLabels(apiClient)
+			if err != nil {
+				return err
+			}
 
-		if issueAssigneeFlag != "" {
-			input.AssigneeID = &issueAssigneeFlag
+			// Narrow label names to the team's labels when it was given by key
+			teamKey := teamRef
+			if client.IsUUID(teamKey) {
+				teamKey = ""
+			}
+
+			labelIDs := make([]string, 0, len(labelRefs))
+			for _, ref := range labelRefs {
+				label, err := client.FindLabel(labels, ref, teamKey)
+				if err != nil {
+					return err
+				}
+				labelIDs = append(labelIDs, label.ID)
+			}
+			input.LabelIDs = &labelIDs
 		}
 
-		if issueProjectFlag != "" {
-			input.ProjectID = &issueProjectFlag
+		if issueStateFlag != "" {
+			input.StateID = &issueStateFlag
 		}
 
-		if issueParentFlag != "" {
-			parentID, err := apiClient.ResolveIssueID(getContext(), issueParentFlag)
+		if issueTriageFlag {
+			stateID, err := triageStateID(apiClient, input.TeamID, teamRef)
 			if err != nil {
 				return err
 			}
-			input.ParentID = &parentID
+			input.StateID = &stateID
+		}
+
+		if issueAssigneeFlag != "" {
+			assigneeID, err := resolveUserID(apiClient, issueAssigneeFlag)
+			if err != nil {
+				return err
+			}
+			input.AssigneeID = &assigneeID
 		}
 
 		// Create issue
@@ -214,11 +574,18 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Created issue %s: %s\n", issue.Identifier, issue.Title)
-			fmt.Printf("  %s\n", issue.URL)
+			formatter.Statusf("✓ Created issue %s: %s\n", issue.Identifier, issue.Title)
+			formatter.Statusf("  %s\n", issue.URL)
 		}
 
-		return formatter.Output(issue)
+		// Open in browser (quiet still opens, only the text is suppressed)
+		if issueOpenFlag {
+			if err := openWeb(issue.URL); err != nil {
+				return err
+			}
+		}
+
+		return formatter.OutputCreated(issue, issue.Identifier, createdIDOnly())
 	},
 }
 
@@ -235,7 +602,7 @@ var issueEditCmd = &cobra.Command{
 		}
 
 		// Resolve issue ID
-		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		id, err := resolveIssueRef(apiClient, args[0])
 		if err != nil {
 			return err
 		}
@@ -251,6 +618,18 @@ var issueEditCmd = &cobra.Command{
 			input.Description = &issueDescFlag
 		}
 
+		if issueAppendDescFlag != "" || issuePrependDescFlag != "" {
+			issue, err := apiClient.GetIssue(getContext(), id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %w", err)
+			}
+			if issueAppendDescFlag != "" {
+				input.ExtendDescription(issue.Description, issueAppendDescFlag, false)
+			} else {
+				input.ExtendDescription(issue.Description, issuePrependDescFlag, true)
+			}
+		}
+
 		if issuePriorityFlag != "" {
 			priority, err := parsePriority(issuePriorityFlag)
 			if err != nil {
@@ -263,6 +642,21 @@ var issueEditCmd = &cobra.Command{
 			input.StateID = &issueStateFlag
 		}
 
+		if issueTriageFlag {
+			issue, err := apiClient.GetIssue(getContext(), id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %w", err)
+			}
+			if issue.Team == nil {
+				return fmt.Errorf("issue %s has no team", args[0])
+			}
+			stateID, err := triageStateID(apiClient, issue.Team.ID, issue.Team.Key)
+			if err != nil {
+				return err
+			}
+			input.StateID = &stateID
+		}
+
 		if issueAssigneeFlag != "" {
 			input.AssigneeID = &issueAssigneeFlag
 		}
@@ -279,13 +673,35 @@ var issueEditCmd = &cobra.Command{
 			input.ParentID = &parentID
 		}
 
+		// Fields to send as explicit nulls
+		clears := []struct {
+			set   bool
+			value string
+			flag  string
+			field string
+		}{
+			{issueClearProjectFlag, issueProjectFlag, "project", client.ClearProject},
+			{issueClearParentFlag, issueParentFlag, "parent", client.ClearParent},
+			{issueClearPriorityFlag, issuePriorityFlag, "priority", client.ClearPriority},
+			{issueClearDueDateFlag, "", "due-date", client.ClearDueDate},
+		}
+		for _, clear := range clears {
+			if !clear.set {
+				continue
+			}
+			if clear.value != "" {
+				return fmt.Errorf("--%s and --clear-%s cannot be used together", clear.flag, clear.flag)
+			}
+			input.Clear = append(input.Clear, clear.field)
+		}
+
 		// Update issue
 		if err := apiClient.UpdateIssue(getContext(), id, input); err != nil {
 			return fmt.Errorf("failed to update issue: %w", err)
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Updated issue %s\n", args[0])
+			formatter.Statusf("✓ Updated issue %s\n", args[0])
 		}
 
 		return nil
@@ -316,8 +732,8 @@ var issueCloseCmd = &cobra.Command{
 			return err
 		}
 
-		// Get workflow states for team
-		states, err := apiClient.ListWorkflowStates(getContext(), issue.Team.ID)
+		// Get workflow states for team (cached; they rarely change)
+		states, err := getWorkflowStates(apiClient, issue.Team.ID)
 		if err != nil {
 			return err
 		}
@@ -345,9 +761,10 @@ var issueCloseCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Closed issue %s\n", args[0])
+			formatter.Statusf("✓ Closed issue %s\n", args[0])
 		}
 
+		postStateComment(apiClient, id, args[0])
 		return nil
 	},
 }
@@ -376,8 +793,8 @@ var issueReopenCmd = &cobra.Command{
 			return err
 		}
 
-		// Get workflow states for team
-		states, err := apiClient.ListWorkflowStates(getContext(), issue.Team.ID)
+		// Get workflow states for team (cached; they rarely change)
+		states, err := getWorkflowStates(apiClient, issue.Team.ID)
 		if err != nil {
 			return err
 		}
@@ -405,9 +822,10 @@ var issueReopenCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Reopened issue %s\n", args[0])
+			formatter.Statusf("✓ Reopened issue %s\n", args[0])
 		}
 
+		postStateComment(apiClient, id, args[0])
 		return nil
 	},
 }
@@ -440,7 +858,7 @@ var issueTransitionCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Transitioned issue %s to state %s\n", args[0], args[1])
+			formatter.Statusf("✓ Transitioned issue %s to state %s\n", args[0], args[1])
 		}
 
 		return nil
@@ -466,15 +884,8 @@ var issueArchiveCmd = &cobra.Command{
 		}
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Archive issue %s? (y/N): ", args[0])
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Archive issue %s?", args[0])); err != nil || !ok {
+			return err
 		}
 
 		// Archive issue
@@ -483,9 +894,10 @@ var issueArchiveCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Archived issue %s\n", args[0])
+			formatter.Statusf("✓ Archived issue %s\n", args[0])
 		}
 
+		postStateComment(apiClient, id, args[0])
 		return nil
 	},
 }
@@ -509,15 +921,8 @@ var issueDeleteCmd = &cobra.Command{
 		}
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Delete issue %s? This cannot be undone. (y/N): ", args[0])
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Delete issue %s? This cannot be undone.", args[0])); err != nil || !ok {
+			return err
 		}
 
 		// Delete issue
@@ -526,7 +931,7 @@ var issueDeleteCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Deleted issue %s\n", args[0])
+			formatter.Statusf("✓ Deleted issue %s\n", args[0])
 		}
 
 		return nil
@@ -562,7 +967,7 @@ var issueAssignCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Assigned issue %s to %s\n", args[0], args[1])
+			formatter.Statusf("✓ Assigned issue %s to %s\n", args[0], args[1])
 		}
 
 		return nil
@@ -588,9 +993,8 @@ var issueUnassignCmd = &cobra.Command{
 		}
 
 		// Remove assignee (set to null)
-		var nullAssignee *string
 		input := &client.UpdateIssueInput{
-			AssigneeID: nullAssignee,
+			Clear: []string{client.ClearAssignee},
 		}
 
 		if err := apiClient.UpdateIssue(getContext(), id, input); err != nil {
@@ -598,33 +1002,612 @@ var issueUnassignCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Unassigned issue %s\n", args[0])
+			formatter.Statusf("✓ Unassigned issue %s\n", args[0])
+		}
+
+		return nil
+	},
+}
+
+// issueTriageCmd represents the issue triage command
+var issueTriageCmd = &cobra.Command{
+	Use:   "triage <issue-id>",
+	Short: "Send an issue to a team's triage",
+	Long: `Move an issue into its team's triage state, or with --team into another
+team's triage, and remove its assignee. The issue then waits for whoever is
+responsible for that team's triage instead of a person.
+
+Examples:
+  lirt issue triage ENG-123
+  lirt issue triage ENG-123 --team DES`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Resolve issue ID
+		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		if err != nil {
+			return err
+		}
+
+		input := &client.UpdateIssueInput{
+			Clear: []string{client.ClearAssignee},
+		}
+
+		// Route to the issue's own team unless --team names another
+		teamID, teamRef := "", issueTeamFlag
+		if issueTeamFlag != "" {
+			if teamID, err = resolveTeamID(apiClient, issueTeamFlag); err != nil {
+				return err
+			}
+		}
+		issue, err := apiClient.GetIssue(getContext(), id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		if issue.Team == nil {
+			return fmt.Errorf("issue %s has no team", args[0])
+		}
+		if teamID == "" || teamID == issue.Team.ID {
+			teamID, teamRef = issue.Team.ID, issue.Team.Key
+		} else {
+			input.TeamID = &teamID
+		}
+
+		stateID, err := triageStateID(apiClient, teamID, teamRef)
+		if err != nil {
+			return err
+		}
+		input.StateID = &stateID
+
+		if err := apiClient.UpdateIssue(getContext(), id, input); err != nil {
+			return fmt.Errorf("failed to triage issue: %w", err)
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Sent issue %s to %s triage\n", args[0], teamRef)
+		}
+
+		return nil
+	},
+}
+
+// triageStateID returns the ID of a team's triage state, or an error if the
+// team has triage turned off
+func triageStateID(apiClient *client.Client, teamID, teamRef string) (string, error) {
+	states, err := getWorkflowStates(apiClient, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	state := client.FindStateByType(states, "triage")
+	if state == nil {
+		return "", fmt.Errorf("team %s has no triage state (enable triage in the team's Linear settings)", teamRef)
+	}
+	return state.ID, nil
+}
+
+// issueSetProjectCmd represents the issue set-project command
+var issueSetProjectCmd = &cobra.Command{
+	Use:   "set-project <issue-id> <project>",
+	Short: "Move an issue into a project",
+	Long: `Move an issue into a project, given by name (case-insensitive) or ID.
+
+Examples:
+  lirt issue set-project ENG-123 "Mobile App"
+  lirt issue set-project ENG-123 5f3c9a1e-...`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Resolve issue and project
+		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		if err != nil {
+			return err
+		}
+		project, err := resolveProject(apiClient, args[1])
+		if err != nil {
+			return err
+		}
+
+		input := &client.UpdateIssueInput{
+			ProjectID: &project.ID,
+		}
+		if err := apiClient.UpdateIssue(getContext(), id, input); err != nil {
+			return fmt.Errorf("failed to set project: %w", err)
+		}
+
+		// Re-fetch to confirm the move took effect
+		issue, err := apiClient.GetIssue(getContext(), id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		if issue.Project == nil || issue.Project.ID != project.ID {
+			return fmt.Errorf("issue %s was not moved to project %s", args[0], project.Name)
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Moved issue %s to project %s\n", issue.Identifier, project.Name)
+		}
+
+		return nil
+	},
+}
+
+// issueClearProjectCmd represents the issue clear-project command
+var issueClearProjectCmd = &cobra.Command{
+	Use:   "clear-project <issue-id>",
+	Short: "Remove an issue from its project",
+	Long:  `Remove an issue from its project, leaving it in its team.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Resolve issue ID
+		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		if err != nil {
+			return err
+		}
+
+		// Remove project (set to null)
+		input := &client.UpdateIssueInput{
+			Clear: []string{client.ClearProject},
+		}
+		if err := apiClient.UpdateIssue(getContext(), id, input); err != nil {
+			return fmt.Errorf("failed to clear project: %w", err)
+		}
+
+		// Re-fetch to confirm the issue left its project
+		issue, err := apiClient.GetIssue(getContext(), id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		if issue.Project != nil {
+			return fmt.Errorf("issue %s is still in project %s", args[0], issue.Project.Name)
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Removed issue %s from its project\n", issue.Identifier)
+		}
+
+		return nil
+	},
+}
+
+// issueReactCmd represents the issue react command
+var issueReactCmd = &cobra.Command{
+	Use:   "react <issue-id>",
+	Short: "React to an issue with an emoji",
+	Long: `Add an emoji reaction to an issue. The emoji is a shortcode, with or without colons.
+
+Examples:
+  lirt issue react ENG-123 --emoji :eyes:
+  lirt issue react ENG-123 --emoji +1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		if client.NormalizeEmoji(issueEmojiFlag) == "" {
+			return fmt.Errorf("--emoji is required")
+		}
+
+		// Resolve issue ID
+		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := apiClient.ReactIssue(getContext(), id, issueEmojiFlag); err != nil {
+			return fmt.Errorf("failed to react to issue: %w", err)
+		}
+
+		// Invalidate cached issue so view shows the new reaction
+		if !noCacheFlag {
+			cacheInstance.Invalidate(fmt.Sprintf("issue-%s", id))
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Reacted to issue %s with :%s:\n", args[0], client.NormalizeEmoji(issueEmojiFlag))
 		}
 
 		return nil
 	},
 }
 
-// Helper function to resolve team key/ID to ID
+// reminderEntry is one row of issue reminders output
+type reminderEntry struct {
+	Issue string    `json:"issue"`
+	Title string    `json:"title"`
+	State string    `json:"state"`
+	Until time.Time `json:"until"`
+}
+
+// issueSnoozeCmd represents the issue snooze command
+var issueSnoozeCmd = &cobra.Command{
+	Use:   "snooze <issue-id>",
+	Short: "Set a reminder to follow up on an issue",
+	Long: `Snooze an issue until a later time; 'lirt issue reminders' then lists it.
+
+Reminders are kept locally under the config directory and are never sent to
+Linear. Snoozing an issue again replaces its reminder.
+
+Examples:
+  lirt issue snooze ENG-123 --until 2d
+  lirt issue snooze ENG-123 --until 2026-03-01
+  lirt issue snooze ENG-123 --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if issueUntilFlag == "" && !issueUnsnoozeFlag {
+			return fmt.Errorf("--until is required")
+		}
+
+		now := time.Now()
+		var until time.Time
+		if !issueUnsnoozeFlag {
+			var err error
+			if until, err = config.ParseSnoozeUntil(issueUntilFlag, now); err != nil {
+				return err
+			}
+		}
+
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Resolve issue ID
+		id, err := apiClient.ResolveIssueID(getContext(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if issueUnsnoozeFlag {
+			removed, err := config.DeleteReminders(cfg.Profile, id)
+			if err != nil {
+				return err
+			}
+			if removed == 0 {
+				return fmt.Errorf("no reminder set for %s", args[0])
+			}
+			if !quietFlag {
+				formatter.Statusf("✓ Cleared reminder for %s\n", args[0])
+			}
+			return nil
+		}
+
+		issue, err := apiClient.GetIssue(getContext(), id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		reminder := config.Reminder{
+			IssueID:    issue.ID,
+			Identifier: issue.Identifier,
+			Until:      until,
+			CreatedAt:  now,
+		}
+		if err := config.SaveReminder(cfg.Profile, reminder); err != nil {
+			return fmt.Errorf("failed to save reminder: %w", err)
+		}
+
+		if !quietFlag {
+			formatter.Statusf("✓ Snoozed %s until %s\n", issue.Identifier, until.Format("Mon Jan 2 15:04"))
+		}
+		return nil
+	},
+}
+
+// issueRemindersCmd represents the issue reminders command
+var issueRemindersCmd = &cobra.Command{
+	Use:   "reminders",
+	Short: "List issues whose snooze has elapsed",
+	Long: `List snoozed issues that are due for follow-up, with their current state.
+
+Reminders for issues that have since been completed or canceled are cleared
+automatically. Use --all to include reminders that are not yet due.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminders, err := config.LoadReminders(cfg.Profile)
+		if err != nil {
+			return err
+		}
+		if len(reminders) == 0 {
+			return formatter.Output([]reminderEntry{})
+		}
+
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Check every reminder so closed issues are cleared even before due,
+		// fetching all of their issues in one query
+		ids := make([]string, 0, len(reminders))
+		for _, reminder := range reminders {
+			ids = append(ids, reminder.IssueID)
+		}
+		issues, err := apiClient.ListIssues(getContext(), &client.IssueFilters{IDs: ids})
+		if err != nil {
+			return fmt.Errorf("failed to check reminders: %w", err)
+		}
+		byID := make(map[string]*model.Issue, len(issues))
+		for i := range issues {
+			byID[issues[i].ID] = &issues[i]
+		}
+
+		now := time.Now()
+		entries := []reminderEntry{}
+		closed := []string{}
+		for _, reminder := range reminders {
+			issue, ok := byID[reminder.IssueID]
+			if !ok {
+				formatter.Statusf("Warning: could not check %s: issue not found\n", reminder.Identifier)
+				continue
+			}
+			if issue.State != nil && client.IsClosedState(issue.State.Type) {
+				closed = append(closed, reminder.IssueID)
+				continue
+			}
+			if !issueAllFlag && !reminder.Due(now) {
+				continue
+			}
+
+			entry := reminderEntry{
+				Issue: issue.Identifier,
+				Title: issue.Title,
+				Until: reminder.Until,
+			}
+			if issue.State != nil {
+				entry.State = issue.State.Name
+			}
+			entries = append(entries, entry)
+		}
+
+		if len(closed) > 0 {
+			if _, err := config.DeleteReminders(cfg.Profile, closed...); err != nil {
+				return err
+			}
+			formatter.Statusf("Cleared %d reminder(s) for closed issues\n", len(closed))
+		}
+
+		return formatter.Output(entries)
+	},
+}
+
+// issueCommitCmd represents the issue commit command
+var issueCommitCmd = &cobra.Command{
+	Use:   "commit <issue-id> [-- <git commit args>]",
+	Short: "Suggest a commit message for an issue",
+	Long: `Print a commit message referencing an issue's identifier and title, e.g.
+"ENG-123: Fix login bug". --refs adds a "Refs ENG-123" trailer.
+
+With --run, git commit is run with the message instead; arguments after --
+are passed on to it.
+
+Examples:
+  lirt issue commit ENG-123
+  lirt issue commit ENG-123 --refs --run -- -a
+  git commit -m "$(lirt issue commit ENG-123)"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitArgs := args[1:]
+		if dash := cmd.ArgsLenAtDash(); dash != 1 && len(gitArgs) > 0 {
+			return fmt.Errorf("git commit arguments must follow --")
+		}
+		if len(gitArgs) > 0 && !issueRunFlag {
+			return fmt.Errorf("git commit arguments require --run")
+		}
+
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		id, err := resolveIssueRef(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+		issue, err := apiClient.GetIssue(getContext(), id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		message := commitMessage(issue, issueRefsFlag)
+		if !issueRunFlag {
+			_, err := fmt.Fprintln(formatter.Writer(), message)
+			return err
+		}
+
+		git := exec.CommandContext(getContext(), "git", append([]string{"commit", "-m", message}, gitArgs...)...)
+		git.Stdin = os.Stdin
+		git.Stdout = os.Stdout
+		git.Stderr = os.Stderr
+		if err := git.Run(); err != nil {
+			return fmt.Errorf("git commit failed: %w", err)
+		}
+		return nil
+	},
+}
+
+// commitMessage suggests a git commit message for work on an issue:
+// "ENG-123: Fix login bug", followed by a "Refs ENG-123" trailer in its own
+// paragraph when refs is set, so Linear links the commit to the issue
+func commitMessage(issue *model.Issue, refs bool) string {
+	message := issue.Identifier + ": " + strings.TrimSpace(issue.Title)
+	if refs {
+		message += "\n\nRefs " + issue.Identifier
+	}
+	return message
+}
+
+// postStateComment posts --comment on an issue after a state change. The
+// change has already succeeded, so a failed comment is reported on stderr
+// as a partial success instead of failing the command.
+func postStateComment(apiClient *client.Client, issueID, issueRef string) {
+	if issueCommentFlag == "" {
+		return
+	}
+
+	input := &client.CreateCommentInput{
+		IssueID: &issueID,
+		Body:    issueCommentFlag,
+	}
+	if _, err := apiClient.CreateComment(getContext(), input); err != nil {
+		formatter.Statusf("Warning: issue %s was updated but the comment was not posted: %v\n", issueRef, err)
+		return
+	}
+
+	if !quietFlag {
+		formatter.Statusf("✓ Added comment to %s\n", issueRef)
+	}
+}
+
+// currentIssueRef is the issue reference naming the issue of the current git
+// branch, as in lirt issue list --parent .
+const currentIssueRef = "."
+
+// branchIssueRef returns ref, or for currentIssueRef the identifier in the
+// name of the current git branch (see client.BranchIdentifier)
+func branchIssueRef(ref string) (string, error) {
+	if ref != currentIssueRef {
+		return ref, nil
+	}
+
+	out, err := exec.CommandContext(getContext(), "git", "branch", "--show-current").Output()
+	if err != nil {
+		return "", fmt.Errorf("%q names the issue of the current git branch, but git failed: %w", ref, err)
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "" {
+		return "", fmt.Errorf("%q names the issue of the current git branch, but HEAD is detached", ref)
+	}
+	identifier, ok := client.BranchIdentifier(branch)
+	if !ok {
+		return "", fmt.Errorf("git branch %s does not name an issue", branch)
+	}
+	logger.Debug("resolved current issue from git branch", "branch", branch, "issue", identifier)
+	return identifier, nil
+}
+
+// resolveIssueRef resolves an issue identifier or UUID like ResolveIssueID,
+// but when nothing matches it lists similar issues in the error so a typo
+// is easy to correct
+func resolveIssueRef(apiClient *client.Client, ref string) (string, error) {
+	id, err := apiClient.ResolveIssueID(getContext(), ref)
+	if !errors.Is(err, client.ErrIssueNotFound) {
+		return id, err
+	}
+
+	suggestions, suggestErr := apiClient.SuggestIssues(getContext(), ref)
+	if suggestErr != nil || len(suggestions) == 0 {
+		logger.Debug("no issue suggestions", "ref", ref, "error", suggestErr)
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v\nDid you mean:", err)
+	for _, issue := range suggestions {
+		fmt.Fprintf(&b, "\n  %-10s %s", issue.Identifier, issue.Title)
+	}
+	return "", errors.New(b.String())
+}
+
+// resolveTeamID resolves a team key or ID to an ID, failing with the
+// available keys when no such team exists
 func resolveTeamID(apiClient *client.Client, teamKeyOrID string) (string, error) {
-	// If it looks like a UUID, return as-is
-	if len(teamKeyOrID) > 20 {
-		return teamKeyOrID, nil
+	teams, err := getTeams(apiClient)
+	if err != nil {
+		return "", err
 	}
 
-	// Otherwise fetch teams and find by key
-	teams, err := apiClient.ListTeams(getContext())
+	team, err := client.FindTeam(teams, teamKeyOrID)
 	if err != nil {
 		return "", err
 	}
+	logger.Debug("resolved team", "key", teamKeyOrID, "id", team.ID)
+	return team.ID, nil
+}
+
+// defaultTeamRef returns the team a list command is scoped to when none is
+// given on the command itself: the global --team, else LIRT_TEAM, else the
+// profile or project team (all resolved into cfg.Team). allTeams (the
+// command's --all-teams) lifts the scope.
+func defaultTeamRef(allTeams bool) string {
+	if allTeams || cfg == nil {
+		return ""
+	}
+	return cfg.Team
+}
 
-	for _, team := range teams {
-		if team.Key == teamKeyOrID {
-			return team.ID, nil
+// refreshIssuesIncremental updates a stale cached issue list by fetching only
+// issues updated since the newest one already cached. Issues updated in a way
+// that no longer matches the filters are dropped. It falls back to a full
+// fetch when the cache is missing or its last full fetch is older than
+// incremental_max_age, which also catches deleted and archived issues.
+func refreshIssuesIncremental(apiClient *client.Client, cacheKey string, filters *client.IssueFilters) ([]model.Issue, error) {
+	maxAge := 24 * time.Hour
+	if duration, err := time.ParseDuration(cfg.IncrementalMaxAge); err == nil {
+		maxAge = duration
+	}
+
+	// Incremental refreshes rewrite the entry, so the time of the full fetch
+	// it builds on is kept alongside it
+	syncKey := cacheKey + "-synced"
+	var syncedAt time.Time
+	_, synced, _ := cacheInstance.Peek(syncKey, &syncedAt)
+
+	var cached []model.Issue
+	_, found, err := cacheInstance.Peek(cacheKey, &cached)
+
+	var issues []model.Issue
+	lastSeen := client.LatestUpdate(cached)
+	if err != nil || !found || !synced || time.Since(syncedAt) > maxAge || lastSeen.IsZero() {
+		issues, err = apiClient.ListIssues(listContext(), filters)
+		if err != nil {
+			return nil, err
+		}
+		cacheInstance.Set(syncKey, time.Now())
+	} else {
+		deltaFilters := *filters
+		deltaFilters.UpdatedAfter = &lastSeen
+		updates, err := apiClient.ListIssues(getContext(), &deltaFilters)
+		if err != nil {
+			return nil, err
+		}
+		changed, err := apiClient.ListIssueIDs(getContext(), &client.IssueFilters{UpdatedAfter: &lastSeen})
+		if err != nil {
+			return nil, err
+		}
+		issues = client.MergeIssues(cached, updates, changed)
+		if limitFlag > 0 && len(issues) > limitFlag {
+			issues = issues[:limitFlag]
 		}
 	}
 
-	return "", fmt.Errorf("team not found: %s", teamKeyOrID)
+	cacheInstance.Set(cacheKey, issues)
+	return issues, nil
+}
+
+// isNoneValue reports whether a filter value requests entities with the field unset
+func isNoneValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "none", "null":
+		return true
+	default:
+		return false
+	}
 }
 
 // Helper function to parse priority value
@@ -669,33 +1652,103 @@ func init() {
 	issueCmd.AddCommand(issueDeleteCmd)
 	issueCmd.AddCommand(issueAssignCmd)
 	issueCmd.AddCommand(issueUnassignCmd)
+	issueCmd.AddCommand(issueTriageCmd)
+	issueCmd.AddCommand(issueSetProjectCmd)
+	issueCmd.AddCommand(issueClearProjectCmd)
+	issueCmd.AddCommand(issueReactCmd)
+	issueCmd.AddCommand(issueSnoozeCmd)
+	issueCmd.AddCommand(issueRemindersCmd)
+	issueCmd.AddCommand(issueCommitCmd)
 
 	// Flags for issue list
-	issueListCmd.Flags().StringVar(&issueTeamFlag, "team", "", "Filter by team key or ID")
+	issueListCmd.Flags().StringArrayVar(&issueListTeamsFlag, "team", nil, "Filter by team key or ID (repeatable)")
+	issueListCmd.RegisterFlagCompletionFunc("team", completeFrom("teams"))
 	issueListCmd.Flags().StringVar(&issueStateFlag, "state", "", "Filter by state ID")
-	issueListCmd.Flags().StringVar(&issueAssigneeFlag, "assignee", "", "Filter by assignee ID")
+	issueListCmd.Flags().BoolVar(&issueWithDescFlag, "include-description", false, "Fetch and show each issue's description")
+	issueListCmd.Flags().BoolVar(&issueNoDescFlag, "no-description", false, "Leave descriptions out (default)")
+	issueListCmd.MarkFlagsMutuallyExclusive("include-description", "no-description")
+	issueListCmd.Flags().StringVar(&issueStateTypeFlag, "state-type", "", "Filter by state type (triage, backlog, unstarted, started, completed, canceled)")
+	issueListCmd.Flags().StringVar(&issueAssigneeFlag, "assignee", "", "Filter by assignee: me, email, name, or ID (or 'none' for unassigned)")
 	issueListCmd.Flags().StringSliceVar(&issueLabelFlag, "label", []string{}, "Filter by label IDs")
-	issueListCmd.Flags().StringVar(&issueProjectFlag, "project", "", "Filter by project ID")
+	issueListCmd.Flags().StringVar(&issueCreatedByFlag, "created-by", "", "Filter by creator (user ID, email, name, or 'me')")
+	issueListCmd.Flags().StringVar(&issueProjectFlag, "project", "", "Filter by project ID (or 'none' for no project)")
 	issueListCmd.Flags().StringVar(&issuePriorityFlag, "priority", "", "Filter by priority (0-4 or urgent/high/medium/low/none)")
 	issueListCmd.Flags().StringVar(&issueMilestoneFlag, "milestone", "", "Filter by milestone ID")
+	issueListCmd.Flags().StringVar(&issueParentFlag, "parent", "", "List sub-issues of a parent issue (ID, identifier, or . for the current git branch's issue)")
 	issueListCmd.Flags().StringVar(&issueSearchFlag, "search", "", "Search issues by text")
+	issueListCmd.Flags().BoolVar(&issueOverdueFlag, "overdue", false, "Only open issues whose due date has passed")
+	issueListCmd.Flags().BoolVar(&issueNoDueDateFlag, "no-due-date", false, "Only issues without a due date")
+	issueListCmd.MarkFlagsMutuallyExclusive("overdue", "no-due-date")
+	issueListCmd.Flags().BoolVar(&issueNoLabelFlag, "has-no-label", false, "Only issues without any labels")
+	issueListCmd.Flags().BoolVar(&issueHasLabelFlag, "has-label", false, "Only issues with at least one label")
+	issueListCmd.MarkFlagsMutuallyExclusive("has-no-label", "has-label")
+	issueListCmd.Flags().BoolVar(&issueAllTeamsFlag, "all-teams", false, "List issues in every team, ignoring the default team")
+	issueListCmd.MarkFlagsMutuallyExclusive("team", "all-teams")
+	issueListCmd.Flags().StringVar(&issueOlderThanFlag, "older-than", "", "Only open issues not updated within this age (e.g. 3d, 2w, 36h), highlighted in tables")
+	issueListCmd.Flags().StringVar(&issueSortFlag, "sort", "", "Sort by field (state, priority, project, assignee, team)")
+	issueListCmd.Flags().StringVar(&issueGroupByFlag, "group-by", "", "Group by field (state, priority, project, assignee, team, label)")
+	issueListCmd.Flags().StringVar(&issueCountByFlag, "count-by", "", "Print issue counts per value instead of issues (state, priority, assignee, label, project)")
+	issueListCmd.MarkFlagsMutuallyExclusive("group-by", "count-by")
+	issueListCmd.Flags().StringVar(&issueCreatedInFlag, "created-in-cycle", "", "Only issues created during a cycle (current, next, previous, or number)")
+	issueListCmd.Flags().StringVar(&issueCompletedInFlag, "completed-in-cycle", "", "Only issues completed during a cycle (current, next, previous, or number)")
+	issueListCmd.Flags().BoolVar(&issueIncrementalFlag, "incremental", false, "Refresh a stale cache by fetching only issues updated since last fetch")
+	issueListCmd.MarkFlagsMutuallyExclusive("incremental", "older-than")
+
+	// Flags for issue view
+	issueViewCmd.Flags().BoolVarP(&issueWebFlag, "web", "w", false, "Open the issue in the browser")
+	issueViewCmd.Flags().BoolVar(&issueRawFlag, "raw", false, "Print the description as raw markdown")
 
 	// Flags for issue create
-	issueCreateCmd.Flags().StringVar(&issueTeamFlag, "team", "", "Team key or ID (required)")
+	issueCreateCmd.Flags().StringVar(&issueTeamFlag, "team", "", "Team key or ID (required unless --parent is given)")
 	issueCreateCmd.Flags().StringVar(&issueTitleFlag, "title", "", "Issue title (required)")
 	issueCreateCmd.Flags().StringVar(&issueDescFlag, "description", "", "Issue description")
 	issueCreateCmd.Flags().StringVar(&issuePriorityFlag, "priority", "", "Priority (0-4 or urgent/high/medium/low/none)")
+	issueCreateCmd.Flags().StringSliceVar(&issueLabelFlag, "label", []string{}, "Label names or IDs (replaces the team's default labels)")
+	issueCreateCmd.RegisterFlagCompletionFunc("team", completeFrom("teams"))
+	issueCreateCmd.RegisterFlagCompletionFunc("label", completeFrom("labels"))
 	issueCreateCmd.Flags().StringVar(&issueStateFlag, "state", "", "State ID")
-	issueCreateCmd.Flags().StringVar(&issueAssigneeFlag, "assignee", "", "Assignee user ID")
+	issueCreateCmd.Flags().BoolVar(&issueTriageFlag, "triage", false, "File the issue in the team's triage state")
+	issueCreateCmd.MarkFlagsMutuallyExclusive("state", "triage")
+	issueCreateCmd.Flags().StringVar(&issueAssigneeFlag, "assignee", "", "Assignee: me, email, name, or ID")
 	issueCreateCmd.Flags().StringVar(&issueProjectFlag, "project", "", "Project ID")
 	issueCreateCmd.Flags().StringVar(&issueParentFlag, "parent", "", "Parent issue ID or identifier")
+	issueCreateCmd.Flags().BoolVar(&issueOpenFlag, "open", false, "Open the new issue in the browser")
+	issueCreateCmd.Flags().BoolVarP(&issueOpenFlag, "web", "w", false, "Open the new issue in the browser (alias for --open)")
 
 	// Flags for issue edit
 	issueEditCmd.Flags().StringVar(&issueTitleFlag, "title", "", "Issue title")
 	issueEditCmd.Flags().StringVar(&issueDescFlag, "description", "", "Issue description")
+	issueEditCmd.Flags().StringVar(&issueAppendDescFlag, "append-description", "", "Add text to the end of the current description")
+	issueEditCmd.Flags().StringVar(&issuePrependDescFlag, "prepend-description", "", "Add text to the start of the current description")
+	issueEditCmd.MarkFlagsMutuallyExclusive("description", "append-description", "prepend-description")
 	issueEditCmd.Flags().StringVar(&issuePriorityFlag, "priority", "", "Priority (0-4 or urgent/high/medium/low/none)")
 	issueEditCmd.Flags().StringVar(&issueStateFlag, "state", "", "State ID")
+	issueEditCmd.Flags().BoolVar(&issueTriageFlag, "triage", false, "Move the issue to its team's triage state")
+	issueEditCmd.MarkFlagsMutuallyExclusive("state", "triage")
 	issueEditCmd.Flags().StringVar(&issueAssigneeFlag, "assignee", "", "Assignee user ID")
 	issueEditCmd.Flags().StringVar(&issueProjectFlag, "project", "", "Project ID")
 	issueEditCmd.Flags().StringVar(&issueParentFlag, "parent", "", "Parent issue ID or identifier")
+	issueEditCmd.Flags().BoolVar(&issueClearProjectFlag, "clear-project", false, "Remove the issue from its project")
+	issueEditCmd.Flags().BoolVar(&issueClearParentFlag, "clear-parent", false, "Remove the issue's parent")
+	issueEditCmd.Flags().BoolVar(&issueClearPriorityFlag, "clear-priority", false, "Clear the issue's priority")
+	issueEditCmd.Flags().BoolVar(&issueClearDueDateFlag, "clear-due-date", false, "Clear the issue's due date")
+
+	// Flags for issue close/reopen/archive
+	issueCloseCmd.Flags().StringVar(&issueCommentFlag, "comment", "", "Post a comment after closing")
+	issueReopenCmd.Flags().StringVar(&issueCommentFlag, "comment", "", "Post a comment after reopening")
+	issueArchiveCmd.Flags().StringVar(&issueCommentFlag, "comment", "", "Post a comment after archiving")
+
+	// Flags for issue react
+	issueReactCmd.Flags().StringVar(&issueEmojiFlag, "emoji", "", "Emoji shortcode, e.g. :eyes: (required)")
+
+	// Flags for issue snooze/reminders
+	issueSnoozeCmd.Flags().StringVar(&issueUntilFlag, "until", "", "When to be reminded: 2d, 1w, 4h, or YYYY-MM-DD")
+	issueSnoozeCmd.Flags().BoolVar(&issueUnsnoozeFlag, "clear", false, "Remove the issue's reminder")
+	issueSnoozeCmd.MarkFlagsMutuallyExclusive("until", "clear")
+	issueTriageCmd.Flags().StringVar(&issueTeamFlag, "team", "", "Route the issue to this team's triage (key or ID)")
+	issueRemindersCmd.Flags().BoolVar(&issueAllFlag, "all", false, "Include reminders that are not yet due")
+
+	// Flags for issue commit
+	issueCommitCmd.Flags().BoolVar(&issueRunFlag, "run", false, "Run git commit with the message instead of printing it")
+	issueCommitCmd.Flags().BoolVar(&issueRefsFlag, "refs", false, "Add a \"Refs <identifier>\" trailer")
 }
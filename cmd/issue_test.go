@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+func TestCommitMessage(t *testing.T) {
+	issue := &model.Issue{Identifier: "ENG-123", Title: " Fix login bug\n"}
+
+	tests := []struct {
+		name string
+		refs bool
+		want string
+	}{
+		{name: "Subject only", want: "ENG-123: Fix login bug"},
+		{name: "With trailer", refs: true, want: "ENG-123: Fix login bug\n\nRefs ENG-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitMessage(issue, tt.refs); got != tt.want {
+				t.Errorf("commitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
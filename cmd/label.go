@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelNameFlag string
+)
+
+// labelConcurrency bounds parallel issue lookups for label apply/remove
+const labelConcurrency = 4
+
+// labelResult reports the outcome of a label change on one issue
+type labelResult struct {
+	Issue  string `json:"issue"`
+	Result string `json:"result"` // ok or failed
+	Error  string `json:"error,omitempty"`
+}
+
+// labelCmd represents the label command
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Manage issue labels",
+	Long:  `Apply or remove a label across many issues at once.`,
+}
+
+// labelApplyCmd represents the label apply command
+var labelApplyCmd = &cobra.Command{
+	Use:   "apply <issue-id>...",
+	Short: "Add a label to issues",
+	Long: `Add a label to each of the given issues, keeping their existing labels.
+
+The label is matched by name (case-insensitive) or ID. If several teams define
+a label with the same name, narrow it down with --team.
+
+Examples:
+  lirt label apply --label Bug ENG-1 ENG-2 ENG-3
+  lirt label apply --label Bug --team ENG ENG-1 ENG-2`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLabelChange(args, "apply", func(labelID string) *client.UpdateIssueInput {
+			return &client.UpdateIssueInput{AddedLabelIDs: []string{labelID}}
+		})
+	},
+}
+
+// labelRemoveCmd represents the label remove command
+var labelRemoveCmd = &cobra.Command{
+	Use:   "remove <issue-id>...",
+	Short: "Remove a label from issues",
+	Long: `Remove a label from each of the given issues, keeping their other labels.
+
+Examples:
+  lirt label remove --label Bug ENG-1 ENG-2 ENG-3`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLabelChange(args, "remove", func(labelID string) *client.UpdateIssueInput {
+			return &client.UpdateIssueInput{RemovedLabelIDs: []string{labelID}}
+		})
+	},
+}
+
+// runLabelChange resolves --label and the issues (with bounded
+// concurrency), then applies the update built by change to all of them with
+// batched mutations, reporting a result per issue. It fails if any issue
+// could not be updated.
+func runLabelChange(issues []string, verb string, change func(labelID string) *client.UpdateIssueInput) error {
+	apiClient, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if labelNameFlag == "" {
+		return fmt.Errorf("--label is required")
+	}
+
+	labels, err := getLabels(apiClient)
+	if err != nil {
+		return err
+	}
+	label, err := client.FindLabel(labels, labelNameFlag, cfg.Team)
+	if err != nil {
+		return err
+	}
+
+	results := make([]labelResult, len(issues))
+	ids := make([]string, len(issues))
+	sem := make(chan struct{}, labelConcurrency)
+	var wg sync.WaitGroup
+
+	for i, issue := range issues {
+		wg.Add(1)
+		go func(i int, issue string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = labelResult{Issue: issue, Result: "ok"}
+			id, err := apiClient.ResolveIssueID(getContext(), issue)
+			if err != nil {
+				results[i].Result = "failed"
+				results[i].Error = err.Error()
+				return
+			}
+			ids[i] = id
+		}(i, issue)
+	}
+	wg.Wait()
+
+	resolved := []string{}
+	for _, id := range ids {
+		if id != "" {
+			resolved = append(resolved, id)
+		}
+	}
+	failures := apiClient.UpdateIssues(getContext(), resolved, change(label.ID))
+
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+		if err, ok := failures[id]; ok {
+			results[i].Result = "failed"
+			results[i].Error = err.Error()
+			continue
+		}
+
+		// Invalidate cached issue so view shows the new labels
+		if !noCacheFlag {
+			cacheInstance.Invalidate(fmt.Sprintf("issue-%s", id))
+		}
+	}
+
+	if err := formatter.Output(results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Result != "ok" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s label %s on %d of %d issues", verb, label.Name, failed, len(issues))
+	}
+	return nil
+}
+
+// getLabels returns all issue labels, using the cache when possible
+func getLabels(apiClient *client.Client) ([]model.Label, error) {
+	cacheKey := "labels"
+	var labels []model.Label
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &labels); err == nil && found {
+			return labels, nil
+		}
+	}
+
+	labels, err := apiClient.ListLabels(getContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, labels)
+	}
+
+	return labels, nil
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+
+	// Add subcommands
+	labelCmd.AddCommand(labelApplyCmd)
+	labelCmd.AddCommand(labelRemoveCmd)
+
+	// Flags for label apply/remove
+	labelApplyCmd.Flags().StringVar(&labelNameFlag, "label", "", "Label name or ID (required)")
+	labelRemoveCmd.Flags().StringVar(&labelNameFlag, "label", "", "Label name or ID (required)")
+}
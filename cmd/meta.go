@@ -2,10 +2,34 @@ package cmd
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	metaStatesAllTeamsFlag bool
+	metaLabelsAllTeamsFlag bool
+	metaMeIDFlag           bool
+	metaMeEmailFlag        bool
+)
+
+// viewerTTL bounds how long the authenticated user's identity is cached
+const viewerTTL = 10 * time.Minute
+
+// metaStatesConcurrency bounds parallel state fetches for --all-teams
+const metaStatesConcurrency = 4
+
+// teamState is a workflow state annotated with its team key
+type teamState struct {
+	Team string `json:"team"`
+	model.State
+}
+
 // metaCmd represents the meta command
 var metaCmd = &cobra.Command{
 	Use:   "meta",
@@ -17,20 +41,38 @@ var metaCmd = &cobra.Command{
 var metaStatesCmd = &cobra.Command{
 	Use:   "states [team-id]",
 	Short: "List workflow states",
-	Long:  `List workflow states for a specific team or all teams.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `List workflow states for a specific team or all teams.
+
+Examples:
+  lirt meta states --team ENG
+  lirt meta states --all-teams --format json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
 			return err
 		}
 
+		if metaStatesAllTeamsFlag {
+			if len(args) > 0 {
+				return fmt.Errorf("--all-teams cannot be combined with a team ID")
+			}
+			states, err := listAllTeamStates(apiClient)
+			if err != nil {
+				return err
+			}
+			if formatter.Format() == output.FormatTable {
+				return formatter.OutputGrouped(states, "team")
+			}
+			return formatter.Output(states)
+		}
+
 		// Get team ID from arg or flag
 		teamID := ""
 		if len(args) > 0 {
 			teamID = args[0]
-		} else if teamFlag != "" {
-			resolvedID, err := resolveTeamID(apiClient, teamFlag)
+		} else if team := defaultTeamRef(false); team != "" {
+			resolvedID, err := resolveTeamID(apiClient, team)
 			if err != nil {
 				return err
 			}
@@ -38,33 +80,140 @@ var metaStatesCmd = &cobra.Command{
 		}
 
 		if teamID == "" {
-			return fmt.Errorf("team ID or --team flag is required")
+			return fmt.Errorf("team ID, --team, a default team, or --all-teams flag is required")
 		}
 
-		// Check cache
-		cacheKey := fmt.Sprintf("states-%s", teamID)
-		var states interface{}
-		if !noCacheFlag {
-			if found, err := cacheInstance.Get(cacheKey, &states); err == nil && found {
-				return formatter.Output(states)
-			}
+		states, err := getWorkflowStates(apiClient, teamID)
+		if err != nil {
+			return err
+		}
+
+		return formatter.Output(states)
+	},
+}
+
+// getWorkflowStates returns a team's workflow states, cached per team under
+// states-<team-id> and shared by meta states and issue close/reopen.
+// --no-cache fetches them fresh.
+func getWorkflowStates(apiClient *client.Client, teamID string) ([]model.State, error) {
+	// Check cache
+	cacheKey := fmt.Sprintf("states-%s", teamID)
+	var states []model.State
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &states); err == nil && found {
+			return states, nil
+		}
+	}
+
+	// Fetch from API
+	states, err := apiClient.ListWorkflowStates(getContext(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow states: %w", err)
+	}
+
+	// Cache results
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, states)
+	}
+
+	return states, nil
+}
+
+// listAllTeamStates fetches workflow states for every team concurrently and
+// annotates each with its team key, preserving team order
+func listAllTeamStates(apiClient *client.Client) ([]teamState, error) {
+	teams, err := apiClient.ListTeams(getContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	results := make([][]model.State, len(teams))
+	errs := make([]error, len(teams))
+	sem := make(chan struct{}, metaStatesConcurrency)
+	var wg sync.WaitGroup
+
+	for i, team := range teams {
+		wg.Add(1)
+		go func(i int, teamID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = getWorkflowStates(apiClient, teamID)
+		}(i, team.ID)
+	}
+	wg.Wait()
+
+	states := []teamState{}
+	for i, team := range teams {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("team %s: %w", team.Key, errs[i])
 		}
+		for _, state := range results[i] {
+			states = append(states, teamState{Team: team.Key, State: state})
+		}
+	}
+
+	return states, nil
+}
+
+// metaMeCmd represents the meta me command
+var metaMeCmd = &cobra.Command{
+	Use:   "me",
+	Short: "Show the authenticated user's identity",
+	Long: `Show the authenticated user's identity for scripting.
 
-		// Fetch from API
-		states, err = apiClient.ListWorkflowStates(getContext(), teamID)
+Use --id or --email to print just that field.
+
+Examples:
+  lirt meta me --id
+  lirt issue list --assignee "$(lirt meta me --id)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := getClient()
 		if err != nil {
-			return fmt.Errorf("failed to list workflow states: %w", err)
+			return err
 		}
 
-		// Cache results
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, states)
+		viewer, err := getViewer(apiClient)
+		if err != nil {
+			return err
 		}
 
-		return formatter.Output(states)
+		switch {
+		case metaMeIDFlag:
+			fmt.Println(viewer.ID)
+			return nil
+		case metaMeEmailFlag:
+			fmt.Println(viewer.Email)
+			return nil
+		}
+
+		return formatter.Output(viewer)
 	},
 }
 
+// getViewer returns the authenticated user, cached per profile for viewerTTL
+func getViewer(apiClient *client.Client) (*model.Viewer, error) {
+	cacheKey := "viewer"
+	var viewer *model.Viewer
+	if !noCacheFlag {
+		if found, err := cacheInstance.GetWithTTL(cacheKey, viewerTTL, &viewer); err == nil && found && viewer != nil {
+			return viewer, nil
+		}
+	}
+
+	viewer, err := apiClient.GetViewer(getContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, viewer)
+	}
+
+	return viewer, nil
+}
+
 // metaPrioritiesCmd represents the meta priorities command
 var metaPrioritiesCmd = &cobra.Command{
 	Use:   "priorities",
@@ -88,9 +237,37 @@ var metaPrioritiesCmd = &cobra.Command{
 var metaLabelsCmd = &cobra.Command{
 	Use:   "labels [team-id]",
 	Short: "List labels",
-	Long:  `List labels for a specific team or all teams.`,
+	Long: `List every issue label, or with a team (argument, --team, or the default
+team) the labels available to that team: its own and workspace labels.
+--all-teams lists every label even when a default team is set.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("meta labels: not yet implemented")
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		labels, err := getLabels(apiClient)
+		if err != nil {
+			return err
+		}
+
+		if metaLabelsAllTeamsFlag && len(args) > 0 {
+			return fmt.Errorf("--all-teams cannot be combined with a team ID")
+		}
+		teamRef := defaultTeamRef(metaLabelsAllTeamsFlag)
+		if len(args) > 0 {
+			teamRef = args[0]
+		}
+		if teamRef == "" {
+			return formatter.Output(labels)
+		}
+
+		teamID, err := resolveTeamID(apiClient, teamRef)
+		if err != nil {
+			return err
+		}
+		return formatter.Output(client.TeamLabels(labels, teamID))
 	},
 }
 
@@ -127,8 +304,20 @@ func init() {
 
 	// Add subcommands
 	metaCmd.AddCommand(metaStatesCmd)
+	metaCmd.AddCommand(metaMeCmd)
 	metaCmd.AddCommand(metaPrioritiesCmd)
 	metaCmd.AddCommand(metaLabelsCmd)
 	metaCmd.AddCommand(metaCyclesCmd)
 	metaCmd.AddCommand(metaIssueTypesCmd)
+
+	// meta states flags
+	metaStatesCmd.Flags().BoolVar(&metaStatesAllTeamsFlag, "all-teams", false, "List states for every team, annotated with the team key")
+
+	// meta labels flags
+	metaLabelsCmd.Flags().BoolVar(&metaLabelsAllTeamsFlag, "all-teams", false, "List every label, ignoring the default team")
+
+	// meta me flags
+	metaMeCmd.Flags().BoolVar(&metaMeIDFlag, "id", false, "Print only the user ID")
+	metaMeCmd.Flags().BoolVar(&metaMeEmailFlag, "email", false, "Print only the user email")
+	metaMeCmd.MarkFlagsMutuallyExclusive("id", "email")
 }
@@ -1,12 +1,10 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +13,9 @@ var (
 	milestoneNameFlag       string
 	milestoneDescFlag       string
 	milestoneTargetDateFlag string
+	milestoneWebFlag        bool
+	milestoneSortFlag       string
+	milestoneReverseFlag    bool
 )
 
 // milestoneCmd represents the milestone command
@@ -28,33 +29,49 @@ var milestoneCmd = &cobra.Command{
 var milestoneListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List milestones",
-	Long:  `List milestones, optionally filtered by project.`,
+	Long: `List milestones, optionally filtered by project.
+
+Milestones are sorted by target date so upcoming ones come first; those
+without a target date are listed last.
+
+Examples:
+  lirt milestone list --project <project-id>
+  lirt milestone list --sort name
+  lirt milestone list --sort created --reverse`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateField("--sort", milestoneSortFlag, client.MilestoneSortFields); err != nil {
+			return err
+		}
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
 		}
 
 		// Check cache first
-		cacheKey := fmt.Sprintf("milestones-%s", milestoneProjectFlag)
-		var milestones interface{}
+		cacheKey := listCacheKey(fmt.Sprintf("milestones-%s", milestoneProjectFlag))
+		var milestones []model.Milestone
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &milestones); err == nil && found {
-				return formatter.Output(milestones)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		milestones, err = apiClient.ListMilestones(getContext(), milestoneProjectFlag)
-		if err != nil {
-			return fmt.Errorf("failed to list milestones: %w", err)
-		}
+		if !cached {
+			milestones, err = apiClient.ListMilestones(listContext(), milestoneProjectFlag)
+			if err != nil {
+				return fmt.Errorf("failed to list milestones: %w", err)
+			}
 
-		// Cache results
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, milestones)
+			// Cache results
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, milestones)
+			}
 		}
 
+		client.SortMilestones(milestones, milestoneSortFlag, milestoneReverseFlag)
 		return formatter.Output(milestones)
 	},
 }
@@ -63,8 +80,10 @@ var milestoneListCmd = &cobra.Command{
 var milestoneViewCmd = &cobra.Command{
 	Use:   "view <milestone-id>",
 	Short: "View milestone details",
-	Long:  `View detailed information about a specific milestone.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific milestone.
+
+Use --web to open the milestone's project in your browser instead.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -75,22 +94,39 @@ var milestoneViewCmd = &cobra.Command{
 
 		// Check cache
 		cacheKey := fmt.Sprintf("milestone-%s", milestoneID)
-		var milestone interface{}
+		var milestone *model.Milestone
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &milestone); err == nil && found {
-				return formatter.Output(milestone)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		milestone, err = apiClient.GetMilestone(getContext(), milestoneID)
-		if err != nil {
-			return fmt.Errorf("failed to get milestone: %w", err)
+		if !cached {
+			milestone, err = apiClient.GetMilestone(getContext(), milestoneID)
+			if err != nil {
+				return fmt.Errorf("failed to get milestone: %w", err)
+			}
+
+			// Cache result
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, milestone)
+			}
 		}
 
-		// Cache result
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, milestone)
+		// Milestones have no page of their own; open the parent project
+		if milestoneWebFlag {
+			if milestone.Project == nil || milestone.Project.ID == "" {
+				return fmt.Errorf("milestone %s has no project", milestoneID)
+			}
+			url := milestone.Project.URL
+			if url == "" {
+				if url, err = workspaceURL(apiClient, "project", milestone.Project.ID); err != nil {
+					return err
+				}
+			}
+			return openWeb(url)
 		}
 
 		return formatter.Output(milestone)
@@ -112,7 +148,7 @@ var milestoneIssuesCmd = &cobra.Command{
 		milestoneID := args[0]
 
 		// Check cache
-		cacheKey := fmt.Sprintf("milestone-issues-%s", milestoneID)
+		cacheKey := listCacheKey(fmt.Sprintf("milestone-issues-%s", milestoneID))
 		var issues interface{}
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &issues); err == nil && found {
@@ -121,7 +157,7 @@ var milestoneIssuesCmd = &cobra.Command{
 		}
 
 		// Fetch from API
-		issues, err = apiClient.ListMilestoneIssues(getContext(), milestoneID)
+		issues, err = apiClient.ListMilestoneIssues(listContext(), milestoneID)
 		if err != nil {
 			return fmt.Errorf("failed to list milestone issues: %w", err)
 		}
@@ -179,7 +215,7 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Created milestone %s\n", milestone.Name)
+			formatter.Statusf("✓ Created milestone %s\n", milestone.Name)
 		}
 
 		return formatter.Output(milestone)
@@ -221,7 +257,7 @@ var milestoneEditCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Updated milestone %s\n", milestoneID)
+			formatter.Statusf("✓ Updated milestone %s\n", milestoneID)
 		}
 
 		return nil
@@ -243,15 +279,8 @@ var milestoneDeleteCmd = &cobra.Command{
 		milestoneID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Delete milestone %s? This cannot be undone. (y/N): ", milestoneID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Delete milestone %s? This cannot be undone.", milestoneID)); err != nil || !ok {
+			return err
 		}
 
 		// Delete milestone
@@ -260,7 +289,7 @@ var milestoneDeleteCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Deleted milestone %s\n", milestoneID)
+			formatter.Statusf("✓ Deleted milestone %s\n", milestoneID)
 		}
 
 		return nil
@@ -280,6 +309,11 @@ func init() {
 
 	// Flags for milestone list
 	milestoneListCmd.Flags().StringVar(&milestoneProjectFlag, "project", "", "Filter by project ID")
+	milestoneListCmd.Flags().StringVar(&milestoneSortFlag, "sort", "target-date", "Sort by field (target-date, name, created)")
+	milestoneListCmd.Flags().BoolVar(&milestoneReverseFlag, "reverse", false, "Reverse the sort order")
+
+	// Flags for milestone view
+	milestoneViewCmd.Flags().BoolVarP(&milestoneWebFlag, "web", "w", false, "Open the milestone's project in the browser")
 
 	// Flags for milestone create
 	milestoneCreateCmd.Flags().StringVar(&milestoneProjectFlag, "project", "", "Project ID (required)")
@@ -1,23 +1,34 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	projectNameFlag  string
-	projectDescFlag  string
-	projectStateFlag string
-	projectLeadFlag  string
-	projectPriorityFlag string
+	projectNameFlag       string
+	projectDescFlag       string
+	projectStateFlag      string
+	projectLeadFlag       string
+	projectPriorityFlag   string
+	projectWebFlag        bool
+	projectTeamsFlag      []string
+	projectMembersFlag    []string
+	projectStartDateFlag  string
+	projectTargetDateFlag string
+	projectSortFlag       string
+	projectReverseFlag    bool
 )
 
+// projectStates are the valid project states
+var projectStates = []string{"backlog", "planned", "started", "paused", "completed", "canceled"}
+
 // projectCmd represents the project command
 var projectCmd = &cobra.Command{
 	Use:   "project",
@@ -29,8 +40,25 @@ var projectCmd = &cobra.Command{
 var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all projects",
-	Long:  `List all projects with their state, priority, and lead.`,
+	Long: `List all projects with their state, priority, lead, progress, and target date.
+
+Projects are sorted by target date so the list reads as a roadmap; those
+without a target date are listed last.
+
+Examples:
+  lirt project list
+  lirt project list --state started
+  lirt project list --sort progress --reverse`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if projectStateFlag != "" {
+			if err := validateProjectState(projectStateFlag); err != nil {
+				return err
+			}
+		}
+		if err := validateField("--sort", projectSortFlag, client.ProjectSortFields); err != nil {
+			return err
+		}
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
@@ -38,34 +66,81 @@ var projectListCmd = &cobra.Command{
 
 		// Check cache first
 		cacheKey := "projects"
-		var projects interface{}
+		if projectStateFlag != "" {
+			cacheKey = fmt.Sprintf("projects-%s", projectStateFlag)
+		}
+		cacheKey = listCacheKey(cacheKey)
+		var projects []model.Project
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &projects); err == nil && found {
-				return formatter.Output(projects)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		projects, err = apiClient.ListProjects(getContext())
-		if err != nil {
-			return fmt.Errorf("failed to list projects: %w", err)
-		}
+		if !cached {
+			projects, err = apiClient.ListProjects(listContext(), projectStateFlag)
+			if err != nil {
+				return fmt.Errorf("failed to list projects: %w", err)
+			}
 
-		// Cache results
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, projects)
+			// Cache results
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, projects)
+			}
 		}
 
-		return formatter.Output(projects)
+		client.SortProjects(projects, projectSortFlag, projectReverseFlag)
+		return outputProjects(projects)
 	},
 }
 
+// projectRow is a project as listed in table, CSV, and plain output, with
+// progress as a percentage. JSON output keeps the full model.Project.
+type projectRow struct {
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Priority   int        `json:"priority"`
+	Lead       string     `json:"lead,omitempty"`
+	Progress   string     `json:"progress"`
+	TargetDate *time.Time `json:"targetDate,omitempty"`
+}
+
+// projectRows converts projects to the rows listed outside JSON
+func projectRows(projects []model.Project) []projectRow {
+	rows := make([]projectRow, len(projects))
+	for i, p := range projects {
+		rows[i] = projectRow{
+			Name:       p.Name,
+			State:      p.State,
+			Priority:   p.Priority,
+			Progress:   formatProgress(p.Progress),
+			TargetDate: p.TargetDate,
+		}
+		if p.Lead != nil {
+			rows[i].Lead = p.Lead.Name
+		}
+	}
+	return rows
+}
+
+// outputProjects writes projects, with progress as a percentage outside JSON
+func outputProjects(projects []model.Project) error {
+	if formatter.Format() == output.FormatJSON {
+		return formatter.Output(projects)
+	}
+	return formatter.Output(projectRows(projects))
+}
+
 // projectViewCmd represents the project view command
 var projectViewCmd = &cobra.Command{
 	Use:   "view <project-id>",
 	Short: "View project details",
-	Long:  `View detailed information about a specific project.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific project.
+
+Use --web to open the project in your browser instead.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -76,22 +151,35 @@ var projectViewCmd = &cobra.Command{
 
 		// Check cache
 		cacheKey := fmt.Sprintf("project-%s", projectID)
-		var project interface{}
+		var project *model.Project
+		cached := false
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &project); err == nil && found {
-				return formatter.Output(project)
+				cached = true
 			}
 		}
 
 		// Fetch from API
-		project, err = apiClient.GetProject(getContext(), projectID)
-		if err != nil {
-			return fmt.Errorf("failed to get project: %w", err)
+		if !cached {
+			project, err = apiClient.GetProject(getContext(), projectID)
+			if err != nil {
+				return fmt.Errorf("failed to get project: %w", err)
+			}
+
+			// Cache result
+			if !noCacheFlag {
+				cacheInstance.Set(cacheKey, project)
+			}
 		}
 
-		// Cache result
-		if !noCacheFlag {
-			cacheInstance.Set(cacheKey, project)
+		if projectWebFlag {
+			url := project.URL
+			if url == "" {
+				if url, err = workspaceURL(apiClient, "project", project.ID); err != nil {
+					return err
+				}
+			}
+			return openWeb(url)
 		}
 
 		return formatter.Output(project)
@@ -113,7 +201,7 @@ var projectIssuesCmd = &cobra.Command{
 		projectID := args[0]
 
 		// Check cache
-		cacheKey := fmt.Sprintf("project-issues-%s", projectID)
+		cacheKey := listCacheKey(fmt.Sprintf("project-issues-%s", projectID))
 		var issues interface{}
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &issues); err == nil && found {
@@ -122,7 +210,7 @@ var projectIssuesCmd = &cobra.Command{
 		}
 
 		// Fetch from API
-		issues, err = apiClient.ListProjectIssues(getContext(), projectID)
+		issues, err = apiClient.ListProjectIssues(listContext(), projectID)
 		if err != nil {
 			return fmt.Errorf("failed to list project issues: %w", err)
 		}
@@ -144,9 +232,13 @@ var projectCreateCmd = &cobra.Command{
 
 Project states: backlog, planned, started, paused, completed, canceled
 
+At least one team is required: pass --team (repeatable) or set a default team.
+--member (repeatable) adds members by user ID, email, name, or "me".
+
 Examples:
-  lirt project create --name "Q1 Initiative"
-  lirt project create --name "Migration" --description "Database migration" --state planned`,
+  lirt project create --name "Q1 Initiative" --team ENG
+  lirt project create --name "Migration" --team ENG --team OPS --member me --state planned
+  lirt project create --name "Launch" --team ENG --start-date 2026-01-05 --target-date 2026-03-31`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -157,10 +249,41 @@ Examples:
 		if projectNameFlag == "" {
 			return fmt.Errorf("--name is required")
 		}
+		if err := client.ValidateDateRange(projectStartDateFlag, projectTargetDateFlag); err != nil {
+			return err
+		}
+
+		// Resolve teams, falling back to the default team
+		teamRefs := projectTeamsFlag
+		if len(teamRefs) == 0 && cfg.Team != "" {
+			teamRefs = []string{cfg.Team}
+		}
+		if len(teamRefs) == 0 {
+			return fmt.Errorf("--team is required")
+		}
+		teams, err := apiClient.ListTeams(getContext())
+		if err != nil {
+			return fmt.Errorf("failed to list teams: %w", err)
+		}
+		teamIDs, err := client.ResolveTeamIDs(teams, teamRefs)
+		if err != nil {
+			return err
+		}
+
+		// Resolve members before creating so a typo doesn't leave a half-set-up project
+		memberIDs := make([]string, 0, len(projectMembersFlag))
+		for _, member := range projectMembersFlag {
+			memberID, err := resolveUserID(apiClient, member)
+			if err != nil {
+				return err
+			}
+			memberIDs = append(memberIDs, memberID)
+		}
 
 		// Build input
 		input := &client.CreateProjectInput{
-			Name: projectNameFlag,
+			Name:    projectNameFlag,
+			TeamIDs: &teamIDs,
 		}
 
 		if projectDescFlag != "" {
@@ -168,17 +291,8 @@ Examples:
 		}
 
 		if projectStateFlag != "" {
-			// Validate state
-			validStates := []string{"backlog", "planned", "started", "paused", "completed", "canceled"}
-			valid := false
-			for _, s := range validStates {
-				if projectStateFlag == s {
-					valid = true
-					break
-				}
-			}
-			if !valid {
-				return fmt.Errorf("invalid state: %s (must be one of: %s)", projectStateFlag, strings.Join(validStates, ", "))
+			if err := validateProjectState(projectStateFlag); err != nil {
+				return err
 			}
 			input.State = &projectStateFlag
 		}
@@ -195,6 +309,14 @@ Examples:
 			input.LeadID = &projectLeadFlag
 		}
 
+		if projectStartDateFlag != "" {
+			input.StartDate = &projectStartDateFlag
+		}
+
+		if projectTargetDateFlag != "" {
+			input.TargetDate = &projectTargetDateFlag
+		}
+
 		// Create project
 		project, err := apiClient.CreateProject(getContext(), input)
 		if err != nil {
@@ -202,11 +324,21 @@ Examples:
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Created project %s\n", project.Name)
-			fmt.Printf("  %s\n", project.URL)
+			formatter.Statusf("✓ Created project %s\n", project.Name)
+			formatter.Statusf("  %s\n", project.URL)
 		}
 
-		return formatter.Output(project)
+		// Seed members
+		if len(memberIDs) > 0 {
+			if err := apiClient.UpdateProject(getContext(), project.ID, &client.UpdateProjectInput{MemberIDs: &memberIDs}); err != nil {
+				return fmt.Errorf("created project %s but failed to add members: %w", project.ID, err)
+			}
+			if !quietFlag {
+				formatter.Statusf("✓ Added %d member(s)\n", len(memberIDs))
+			}
+		}
+
+		return formatter.OutputCreated(project, project.ID, createdIDOnly())
 	},
 }
 
@@ -224,6 +356,10 @@ var projectEditCmd = &cobra.Command{
 
 		projectID := args[0]
 
+		if err := client.ValidateDateRange(projectStartDateFlag, projectTargetDateFlag); err != nil {
+			return err
+		}
+
 		// Build input
 		input := &client.UpdateProjectInput{}
 
@@ -236,17 +372,8 @@ var projectEditCmd = &cobra.Command{
 		}
 
 		if projectStateFlag != "" {
-			// Validate state
-			validStates := []string{"backlog", "planned", "started", "paused", "completed", "canceled"}
-			valid := false
-			for _, s := range validStates {
-				if projectStateFlag == s {
-					valid = true
-					break
-				}
-			}
-			if !valid {
-				return fmt.Errorf("invalid state: %s (must be one of: %s)", projectStateFlag, strings.Join(validStates, ", "))
+			if err := validateProjectState(projectStateFlag); err != nil {
+				return err
 			}
 			input.State = &projectStateFlag
 		}
@@ -263,13 +390,21 @@ var projectEditCmd = &cobra.Command{
 			input.LeadID = &projectLeadFlag
 		}
 
+		if projectStartDateFlag != "" {
+			input.StartDate = &projectStartDateFlag
+		}
+
+		if projectTargetDateFlag != "" {
+			input.TargetDate = &projectTargetDateFlag
+		}
+
 		// Update project
 		if err := apiClient.UpdateProject(getContext(), projectID, input); err != nil {
 			return fmt.Errorf("failed to update project: %w", err)
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Updated project %s\n", projectID)
+			formatter.Statusf("✓ Updated project %s\n", projectID)
 		}
 
 		return nil
@@ -291,15 +426,8 @@ var projectArchiveCmd = &cobra.Command{
 		projectID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Archive project %s? (y/N): ", projectID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Archive project %s?", projectID)); err != nil || !ok {
+			return err
 		}
 
 		// Archive project
@@ -308,7 +436,7 @@ var projectArchiveCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Archived project %s\n", projectID)
+			formatter.Statusf("✓ Archived project %s\n", projectID)
 		}
 
 		return nil
@@ -330,15 +458,8 @@ var projectDeleteCmd = &cobra.Command{
 		projectID := args[0]
 
 		// Confirm
-		if !quietFlag {
-			fmt.Printf("Delete project %s? This cannot be undone. (y/N): ", projectID)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
+		if ok, err := confirm(fmt.Sprintf("Delete project %s? This cannot be undone.", projectID)); err != nil || !ok {
+			return err
 		}
 
 		// Delete project
@@ -347,13 +468,44 @@ var projectDeleteCmd = &cobra.Command{
 		}
 
 		if !quietFlag {
-			fmt.Printf("✓ Deleted project %s\n", projectID)
+			formatter.Statusf("✓ Deleted project %s\n", projectID)
 		}
 
 		return nil
 	},
 }
 
+// resolveProject resolves a project name or ID to the project
+func resolveProject(apiClient *client.Client, ref string) (*model.Project, error) {
+	if client.IsUUID(ref) {
+		project, err := apiClient.GetProject(getContext(), ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		return project, nil
+	}
+
+	projects, err := apiClient.ListProjects(getContext(), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	project, err := client.FindProject(projects, ref)
+	if err == nil {
+		logger.Debug("resolved project", "ref", ref, "id", project.ID)
+	}
+	return project, err
+}
+
+// validateProjectState checks a --state value against projectStates
+func validateProjectState(state string) error {
+	for _, s := range projectStates {
+		if state == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid state: %s (must be one of: %s)", state, strings.Join(projectStates, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(projectCmd)
 
@@ -366,12 +518,24 @@ func init() {
 	projectCmd.AddCommand(projectArchiveCmd)
 	projectCmd.AddCommand(projectDeleteCmd)
 
+	// Flags for project list
+	projectListCmd.Flags().StringVar(&projectStateFlag, "state", "", "Filter by state (backlog, planned, started, paused, completed, canceled)")
+	projectListCmd.Flags().StringVar(&projectSortFlag, "sort", "target-date", "Sort by field (target-date, name, progress, priority)")
+	projectListCmd.Flags().BoolVar(&projectReverseFlag, "reverse", false, "Reverse the sort order")
+
+	// Flags for project view
+	projectViewCmd.Flags().BoolVarP(&projectWebFlag, "web", "w", false, "Open the project in the browser")
+
 	// Flags for project create
 	projectCreateCmd.Flags().StringVar(&projectNameFlag, "name", "", "Project name (required)")
 	projectCreateCmd.Flags().StringVar(&projectDescFlag, "description", "", "Project description")
 	projectCreateCmd.Flags().StringVar(&projectStateFlag, "state", "", "Project state (backlog, planned, started, paused, completed, canceled)")
 	projectCreateCmd.Flags().StringVar(&projectPriorityFlag, "priority", "", "Priority (0-4 or urgent/high/medium/low/none)")
 	projectCreateCmd.Flags().StringVar(&projectLeadFlag, "lead", "", "Lead user ID")
+	projectCreateCmd.Flags().StringArrayVar(&projectTeamsFlag, "team", []string{}, "Team key or ID (repeatable; defaults to the configured team)")
+	projectCreateCmd.Flags().StringArrayVar(&projectMembersFlag, "member", []string{}, "Member user ID, email, name, or 'me' (repeatable)")
+	projectCreateCmd.Flags().StringVar(&projectStartDateFlag, "start-date", "", "Start date (YYYY-MM-DD)")
+	projectCreateCmd.Flags().StringVar(&projectTargetDateFlag, "target-date", "", "Target date (YYYY-MM-DD)")
 
 	// Flags for project edit
 	projectEditCmd.Flags().StringVar(&projectNameFlag, "name", "", "Project name")
@@ -379,4 +543,6 @@ func init() {
 	projectEditCmd.Flags().StringVar(&projectStateFlag, "state", "", "Project state (backlog, planned, started, paused, completed, canceled)")
 	projectEditCmd.Flags().StringVar(&projectPriorityFlag, "priority", "", "Priority (0-4 or urgent/high/medium/low/none)")
 	projectEditCmd.Flags().StringVar(&projectLeadFlag, "lead", "", "Lead user ID")
+	projectEditCmd.Flags().StringVar(&projectStartDateFlag, "start-date", "", "Start date (YYYY-MM-DD)")
+	projectEditCmd.Flags().StringVar(&projectTargetDateFlag, "target-date", "", "Target date (YYYY-MM-DD)")
 }
@@ -1,41 +1,79 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/dixson3/lirt/internal/cache"
 	"github.com/dixson3/lirt/internal/client"
 	"github.com/dixson3/lirt/internal/config"
+	"github.com/dixson3/lirt/internal/logging"
+	"github.com/dixson3/lirt/internal/model"
 	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 var (
 	// Global flags
-	profileFlag  string
-	apiKeyFlag   string
-	teamFlag     string
-	formatFlag   string
-	jsonFlag     string
-	jqFlag       string
-	noCacheFlag  bool
-	quietFlag    bool
-	verboseFlag  bool
+	profileFlag       string
+	apiKeyFlag        string
+	teamFlag          string
+	formatFlag        string
+	jsonFlag          string
+	jqFlag            string
+	noCacheFlag       bool
+	cacheOnlyFlag     bool
+	cacheTTLFlag      string
+	quietFlag         bool
+	verboseFlag       bool
+	limitFlag         int
+	noPagerFlag       bool
+	partialOKFlag     bool
+	yesFlag           bool
+	maxColWidthFlag   int
+	wrapFlag          bool
+	truncateFlag      bool
+	schemaFlag        bool
+	csvDelimiterFlag  string
+	csvBOMFlag        bool
+	csvSingleLineFlag bool
+	logFormatFlag     string
+	repeatFlag        time.Duration
+	jitterFlag        time.Duration
+	compactFlag       bool
+	prettyFlag        bool
 
 	// Version is injected at build time
 	Version = "dev"
 
 	// Shared context
-	cfg    *config.Config
-	apiClient *client.Client
+	cfg           *config.Config
+	apiClient     *client.Client
+	retryPolicy   = client.DefaultRetryPolicy
 	cacheInstance *cache.Cache
-	formatter *output.Formatter
+	formatter     *output.Formatter
+	pager         *output.Pager
+	logger        = logging.Discard()
+
+	// rootCtx is canceled on SIGINT/SIGTERM so in-flight requests abort
+	rootCtx = context.Background()
 )
 
+// ErrCancelled is returned when the command is interrupted (e.g. Ctrl-C)
+var ErrCancelled = errors.New("cancelled")
+
 // rootCmd represents the base command
 var rootCmd = &cobra.Command{
 	Use:   "lirt",
@@ -46,10 +84,25 @@ It provides fast, scriptable access to Linear's API with support for multiple wo
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Logs go to stderr: debug with --verbose, otherwise warnings and up
+		logFormat, err := logging.ParseFormat(logFormatFlag)
+		if err != nil {
+			return err
+		}
+		logger = logging.New(os.Stderr, verboseFlag, logFormat)
+
+		// --profile all wraps the command before --repeat does, so each
+		// repeat fans out again
+		if err := setupFanOut(cmd); err != nil {
+			return err
+		}
+		if err := setupRepeat(cmd); err != nil {
+			return err
+		}
+
 		// Initialize configuration
 		profile := config.GetProfile(profileFlag)
 
-		var err error
 		cfg, err = config.LoadConfig(profile)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
@@ -62,35 +115,119 @@ It provides fast, scriptable access to Linear's API with support for multiple wo
 		if teamFlag != "" {
 			cfg.Team = teamFlag
 		}
-		if formatFlag != "" {
-			cfg.Format = formatFlag
-		}
 
 		// Override with environment variables
 		if team := os.Getenv("LIRT_TEAM"); team != "" && teamFlag == "" {
 			cfg.Team = team
 		}
-		if format := os.Getenv("LIRT_FORMAT"); format != "" && formatFlag == "" {
-			cfg.Format = format
+		if noAutoJSON, err := strconv.ParseBool(os.Getenv("LIRT_NO_AUTO_JSON")); err == nil && noAutoJSON {
+			cfg.AutoJSON = false
+		}
+		if tz := os.Getenv("LIRT_TZ"); tz != "" {
+			cfg.Timezone = tz
+		}
+
+		// Retry settings apply to every API client made by this command.
+		// Invalid ones fall back to the defaults with a warning rather than
+		// failing every command; lirt doctor reports them.
+		if attempts, baseDelay, maxDelay, err := cfg.Retry(); err != nil {
+			logger.Warn("ignoring invalid retry settings", "err", err)
+		} else {
+			retryPolicy = client.RetryPolicy{Attempts: attempts, BaseDelay: baseDelay, MaxDelay: maxDelay}
 		}
 
-		// Parse cache TTL
-		cacheTTL := 5 * time.Minute
-		if cfg.CacheTTL != "" {
-			if duration, err := time.ParseDuration(cfg.CacheTTL); err == nil {
+		// Parse cache TTL: --cache-ttl overrides cache_ttl for this
+		// invocation, and --cache-only serves cached data of any age
+		cacheTTL := cache.DefaultTTL
+		if cacheOnlyFlag {
+			if noCacheFlag {
+				return fmt.Errorf("--cache-only and --no-cache cannot be used together")
+			}
+			cacheTTL = cache.NoExpiry
+		} else if cacheTTLFlag != "" {
+			duration, err := cache.ParseTTL(cacheTTLFlag)
+			if err != nil {
+				return fmt.Errorf("--cache-ttl: %w", err)
+			}
+			cacheTTL = duration
+		} else if cfg.CacheTTL != "" {
+			if duration, err := cache.ParseTTL(cfg.CacheTTL); err == nil {
 				cacheTTL = duration
 			}
 		}
 
 		// Initialize cache
 		cacheInstance = cache.New(profile, cacheTTL)
+		cacheInstance.SetLogger(logger)
 
-		// Initialize formatter (auto-detect if piped)
-		format := output.Format(cfg.Format)
-		if !isTerminal() && formatFlag == "" {
+		// Initialize formatter: --format, LIRT_FORMAT, then the configured
+		// format (per-command first, e.g. issue.list.format) are honored even
+		// when piped; only with none of them does piping switch to JSON, and
+		// not at all with auto_json = false. --jq implies JSON.
+		format := output.ResolveFormat(!isTerminal() && cfg.AutoJSON, formatFlag, os.Getenv("LIRT_FORMAT"), cfg.ConfiguredFormatFor(commandPath(cmd)))
+		var query *output.Query
+		if maxColWidthFlag < 0 {
+			return fmt.Errorf("--max-col-width must not be negative")
+		}
+		delimiter, err := output.ParseCSVDelimiter(csvDelimiterFlag)
+		if err != nil {
+			return err
+		}
+		if jsonFlag != "" {
+			format = output.FormatJSON
+		}
+		if jqFlag != "" {
+			if query, err = output.ParseQuery(jqFlag); err != nil {
+				return err
+			}
 			format = output.FormatJSON
 		}
-		formatter = output.New(format, os.Stdout)
+		formatter = output.New(format, outputWriter(format))
+		formatter.SetQuery(query)
+		formatter.SetFields(output.ParseFields(jsonFlag))
+		if compactFlag || prettyFlag {
+			formatter.SetPrettyJSON(prettyFlag)
+		}
+		formatter.SetTableLayout(output.TableLayout{
+			MaxColWidth: maxColWidthFlag,
+			Wrap:        wrapFlag,
+			Width:       terminalWidth(),
+		})
+		formatter.SetCSVOptions(output.CSVOptions{
+			Delimiter:  delimiter,
+			BOM:        csvBOMFlag,
+			SingleLine: csvSingleLineFlag,
+		})
+
+		// Timestamps display in LIRT_TZ or the timezone setting, else in TZ.
+		// A TZ Go cannot load (e.g. ":/etc/localtime") is ignored.
+		loc, err := output.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return err
+		}
+		if loc == nil {
+			loc, _ = output.LoadLocation(os.Getenv("TZ"))
+		}
+		formatter.SetLocation(loc)
+		// Status and data are formatted independently: --format only shapes
+		// stdout, while --log-format json turns status lines on stderr into
+		// JSON records
+		if quietFlag {
+			// --quiet leaves only errors on stderr
+			formatter.SetStatusWriter(io.Discard)
+		} else if logFormat == logging.FormatJSON {
+			formatter.SetStatusLogger(logging.NewStatus(os.Stderr))
+		}
+
+		if schemaFlag {
+			if formatter.Format() != output.FormatJSON {
+				return fmt.Errorf("--schema requires --format json")
+			}
+			// Describe the command's output instead of running it
+			cmd.RunE = func(cmd *cobra.Command, args []string) error {
+				return formatter.Output(schemaFor(cmd))
+			}
+		}
 
 		return nil
 	},
@@ -100,20 +237,68 @@ It provides fast, scriptable access to Linear's API with support for multiple wo
 func Execute(version string) error {
 	Version = version
 	rootCmd.Version = version
-	return rootCmd.Execute()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// Restore default signal handling so a second Ctrl-C exits at once
+		<-ctx.Done()
+		stop()
+	}()
+	rootCtx = ctx
+
+	// Expand a user alias before cobra parses the command line
+	expanded, err := expandAliasArgs(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	if expanded != nil {
+		rootCmd.SetArgs(expanded)
+	}
+	allowSchemaArgs(rootCmd)
+
+	err = rootCmd.Execute()
+	if pager != nil {
+		pager.Close()
+	}
+	if err != nil && ctx.Err() != nil {
+		return ErrCancelled
+	}
+	return err
 }
 
 func init() {
 	// Global persistent flags
-	rootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "P", "", "Named profile to use")
+	rootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "P", "", "Named profile to use (all: run a list command against every profile)")
 	rootCmd.PersistentFlags().StringVar(&apiKeyFlag, "api-key", "", "Override API key for this invocation")
 	rootCmd.PersistentFlags().StringVarP(&teamFlag, "team", "t", "", "Team key context (overrides config)")
+	rootCmd.RegisterFlagCompletionFunc("team", completeFrom("teams"))
 	rootCmd.PersistentFlags().StringVarP(&formatFlag, "format", "f", "", "Output format: table, json, csv, plain")
-	rootCmd.PersistentFlags().StringVar(&jsonFlag, "json", "", "Output specific fields as JSON (comma-separated)")
+	rootCmd.PersistentFlags().StringVar(&jsonFlag, "json", "", "Output specific fields as JSON (comma-separated; implies --format json)")
 	rootCmd.PersistentFlags().StringVar(&jqFlag, "jq", "", "Apply jq expression to JSON output")
 	rootCmd.PersistentFlags().BoolVar(&noCacheFlag, "no-cache", false, "Bypass cached data")
-	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output")
-	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Debug output")
+	rootCmd.PersistentFlags().BoolVar(&cacheOnlyFlag, "cache-only", false, "Serve only cached data and fail instead of calling the API")
+	rootCmd.PersistentFlags().StringVar(&cacheTTLFlag, "cache-ttl", "", "Override cache_ttl for this invocation (e.g. 30s, 1m; 0 disables caching)")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress all non-error output on stderr and success messages")
+	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Answer yes to confirmation prompts")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Log requests, cache hits and misses, and lookups to stderr")
+	rootCmd.PersistentFlags().StringVar(&logFormatFlag, "log-format", "text", "Format of logs and status messages on stderr: text or json (independent of --format)")
+	rootCmd.PersistentFlags().IntVar(&limitFlag, "limit", 0, "Maximum number of results for list commands (0 = all)")
+	rootCmd.PersistentFlags().BoolVar(&noPagerFlag, "no-pager", false, "Do not pipe output through a pager")
+	rootCmd.PersistentFlags().DurationVar(&repeatFlag, "repeat", 0, "Re-run the command at this interval (e.g. 30s, 5m) until interrupted")
+	rootCmd.PersistentFlags().DurationVar(&jitterFlag, "jitter", 0, "Add a random delay of up to this duration to each --repeat interval")
+	rootCmd.PersistentFlags().BoolVar(&partialOKFlag, "partial-ok", false, "Show partial results when some fields fail instead of erroring")
+	rootCmd.PersistentFlags().IntVar(&maxColWidthFlag, "max-col-width", 0, "Maximum width of each table column (0 = fit TITLE to the terminal)")
+	rootCmd.PersistentFlags().BoolVar(&wrapFlag, "wrap", false, "Wrap long table cells onto several lines")
+	rootCmd.PersistentFlags().BoolVar(&truncateFlag, "truncate", false, "Truncate long table cells with an ellipsis (default)")
+	rootCmd.PersistentFlags().StringVar(&csvDelimiterFlag, "csv-delimiter", ",", "Field separator for CSV output (e.g. ';', or '\\t' for tab)")
+	rootCmd.PersistentFlags().BoolVar(&csvBOMFlag, "csv-bom", false, "Start CSV output with a UTF-8 byte order mark (for Excel)")
+	rootCmd.PersistentFlags().BoolVar(&csvSingleLineFlag, "csv-single-line", false, "Collapse line breaks in CSV cells to spaces so each record is one line")
+	rootCmd.PersistentFlags().BoolVar(&schemaFlag, "schema", false, "Print the JSON field schema of the command's output instead of running it")
+	rootCmd.PersistentFlags().BoolVar(&compactFlag, "compact", false, "Print JSON on one line (default when stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&prettyFlag, "pretty", false, "Indent JSON output (default on a terminal)")
+	rootCmd.MarkFlagsMutuallyExclusive("wrap", "truncate")
+	rootCmd.MarkFlagsMutuallyExclusive("compact", "pretty")
 
 	// Bind flags to viper
 	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
@@ -128,6 +313,52 @@ func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = false
 }
 
+// commandPath returns the command's path below the root, e.g. ["issue", "list"]
+func commandPath(cmd *cobra.Command) []string {
+	path := []string{}
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+	return path
+}
+
+// schemaFor returns the JSON schema of the entity a command outputs, found
+// from the nearest command name or alias naming a model entity (issue list
+// -> issue, meta states -> state). Commands with no entity get every entity.
+func schemaFor(cmd *cobra.Command) model.Schema {
+	known := map[string]bool{}
+	for _, name := range model.EntityNames() {
+		known[name] = true
+	}
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		for _, name := range append([]string{c.Name()}, c.Aliases...) {
+			name = strings.TrimSuffix(name, "s")
+			if known[name] {
+				return model.SchemaFor(name)
+			}
+		}
+	}
+	return model.SchemaFor()
+}
+
+// allowSchemaArgs wraps the argument validation of cmd and its subcommands so
+// --schema works without the arguments the command needs to run (lirt issue
+// view --schema). Cobra validates arguments before PersistentPreRunE, where
+// --schema replaces the command.
+func allowSchemaArgs(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if schemaFlag {
+				return nil
+			}
+			return validate(cmd, args)
+		}
+	}
+	for _, c := range cmd.Commands() {
+		allowSchemaArgs(c)
+	}
+}
+
 // isTerminal checks if stdout is a terminal
 func isTerminal() bool {
 	fileInfo, err := os.Stdout.Stat()
@@ -137,6 +368,97 @@ func isTerminal() bool {
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
 
+// outputWriter returns where command output is written: a pager for table
+// and plain output on a terminal ($LIRT_PAGER, $PAGER, or less -FRX), or
+// stdout directly
+func outputWriter(format output.Format) io.Writer {
+	if noPagerFlag || !isTerminal() {
+		return os.Stdout
+	}
+	if format != output.FormatTable && format != output.FormatPlain {
+		return os.Stdout
+	}
+
+	command := output.PagerCommand()
+	if command == "" {
+		return os.Stdout
+	}
+
+	pager = output.NewPager(command, os.Stdout)
+	return pager
+}
+
+// minRepeatInterval keeps --repeat from hammering the API
+const minRepeatInterval = time.Second
+
+// setupRepeat validates --repeat and --jitter and, with --repeat, wraps the
+// command so it runs on that interval until interrupted
+func setupRepeat(cmd *cobra.Command) error {
+	if jitterFlag < 0 {
+		return fmt.Errorf("--jitter must not be negative")
+	}
+	if repeatFlag == 0 {
+		if jitterFlag > 0 {
+			return fmt.Errorf("--jitter requires --repeat")
+		}
+		return nil
+	}
+	if repeatFlag < minRepeatInterval {
+		return fmt.Errorf("--repeat must be at least %s", minRepeatInterval)
+	}
+	if cmd.RunE == nil {
+		return nil
+	}
+
+	// A pager would wait for the user after the first run
+	noPagerFlag = true
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return repeatRun(run, cmd, args)
+	}
+	return nil
+}
+
+// repeatRun runs a command every --repeat interval, plus a random delay of
+// up to --jitter, until interrupted. Runs are separated by a timestamp on
+// stderr. A failed run is logged and the next one goes ahead, so a
+// transient error does not end monitoring.
+func repeatRun(run func(*cobra.Command, []string) error, cmd *cobra.Command, args []string) error {
+	for i := 0; ; i++ {
+		if i > 0 {
+			formatter.Statusf("--- %s ---\n", time.Now().Format("2006-01-02 15:04:05 MST"))
+		}
+		if err := run(cmd, args); err != nil {
+			if rootCtx.Err() != nil {
+				return nil
+			}
+			logger.Error("run failed", "err", err)
+		}
+
+		delay := repeatFlag
+		if jitterFlag > 0 {
+			delay += rand.N(jitterFlag)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-rootCtx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// terminalWidth returns the width of stdout's terminal, or 0 if unknown
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 // getClient returns an authenticated Linear API client
 func getClient() (*client.Client, error) {
 	if apiClient != nil {
@@ -147,8 +469,16 @@ func getClient() (*client.Client, error) {
 		return nil, fmt.Errorf("not authenticated - run 'lirt auth login' to set up credentials")
 	}
 
+	opts := append(clientOptions(cfg.Profile), client.WithPageSize(cfg.PageSize))
+	if partialOKFlag {
+		opts = append(opts, client.WithPartialOK(warnPartial))
+	}
+	if cacheOnlyFlag {
+		opts = append(opts, client.WithOffline())
+	}
+
 	var err error
-	apiClient, err = client.New(cfg.APIKey)
+	apiClient, err = client.New(cfg.APIKey, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
@@ -156,16 +486,130 @@ func getClient() (*client.Client, error) {
 	return apiClient, nil
 }
 
-// getContext returns a context for API calls
+// clientOptions returns the options shared by every API client: the
+// profile for auth errors, the User-Agent (version plus LIRT_USER_AGENT),
+// and the configured retry policy
+func clientOptions(profile string) []client.Option {
+	return []client.Option{
+		client.WithProfile(profile),
+		client.WithVersion(Version),
+		client.WithUserAgent(os.Getenv("LIRT_USER_AGENT")),
+		client.WithLogger(logger),
+		client.WithRetry(retryPolicy),
+	}
+}
+
+// warnPartial reports a partial response or truncated list accepted under
+// --partial-ok. Caching is disabled for the rest of the command so the
+// incomplete data is not served later as a full result.
+func warnPartial(err error) {
+	noCacheFlag = true
+
+	if queryErr, ok := err.(*client.QueryErrors); ok {
+		formatter.Statusf("Warning: partial response, some fields are missing\n%s", queryErr.Details())
+		return
+	}
+	formatter.Statusf("Warning: %v\n", err)
+}
+
+// outputList writes list data honoring --sort and --group-by fields
+func outputList(data interface{}, sortBy, groupBy string) error {
+	if sortBy != "" {
+		data = formatter.SortBy(data, sortBy)
+	}
+	if groupBy != "" {
+		return formatter.OutputGrouped(data, groupBy)
+	}
+	return formatter.Output(data)
+}
+
+// formatProgress shows a 0-1 completion fraction (project, initiative, or
+// cycle progress) as a whole percentage
+func formatProgress(progress float64) string {
+	return fmt.Sprintf("%.0f%%", progress*100)
+}
+
+// confirm asks the user to confirm a destructive action, returning false if
+// they decline. --yes answers for them. --quiet never prompts, so without
+// --yes the action is refused rather than silently confirmed.
+func confirm(prompt string) (bool, error) {
+	if yesFlag {
+		return true, nil
+	}
+	if quietFlag {
+		return false, fmt.Errorf("confirmation required: %s (pass --yes to proceed with --quiet)", strings.TrimSuffix(prompt, "?"))
+	}
+
+	fmt.Fprintf(os.Stderr, "%s (y/N): ", prompt)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Fprintln(os.Stderr, "Cancelled.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// createdIDOnly reports whether create commands should print only the new
+// identifier: in --quiet mode unless JSON output was explicitly requested
+func createdIDOnly() bool {
+	return quietFlag && formatFlag != string(output.FormatJSON)
+}
+
+// validateField checks that a --sort or --group-by value is supported
+func validateField(flag, value string, valid []string) error {
+	if value == "" {
+		return nil
+	}
+	for _, v := range valid {
+		if value == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s: %s (must be one of: %s)", flag, value, strings.Join(valid, ", "))
+}
+
+// getContext returns a context for API calls, canceled on SIGINT/SIGTERM
 func getContext() context.Context {
-	return context.Background()
+	return rootCtx
+}
+
+// listContext returns a context for a command's primary list query, capped
+// by --limit. Lookups (e.g. resolving a team key) should use getContext so
+// they always see every result.
+func listContext() context.Context {
+	return client.WithLimit(getContext(), limitFlag)
+}
+
+// listCacheKey scopes a list cache key to --limit so capped results are
+// never served for an uncapped request
+func listCacheKey(key string) string {
+	return cache.ListKey(key, limitFlag)
 }
 
 // ExitCode constants
 const (
-	ExitSuccess         = 0
-	ExitError           = 1
-	ExitUsageError      = 2
-	ExitAuthError       = 3
-	ExitNotFound        = 4
+	ExitSuccess    = 0
+	ExitError      = 1
+	ExitUsageError = 2
+	ExitAuthError  = 3
+	ExitNotFound   = 4
+	ExitCancelled  = 130 // 128 + SIGINT, as shells report Ctrl-C
 )
+
+// ExitCodeFor maps a command error to the process exit code
+func ExitCodeFor(err error) int {
+	var authErr *client.AuthError
+	if errors.As(err, &authErr) {
+		return ExitAuthError
+	}
+	if errors.Is(err, ErrCancelled) {
+		return ExitCancelled
+	}
+	if errors.Is(err, client.ErrOffline) {
+		// A --cache-only cache miss
+		return ExitNotFound
+	}
+	return ExitError
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// TestFlagShorthands verifies every command's flags merge with the global
+// ones. A local flag reusing a global shorthand (e.g. -f for --format)
+// makes cobra panic as soon as the command runs.
+func TestFlagShorthands(t *testing.T) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		t.Run(strings.Join(append([]string{"lirt"}, commandPath(cmd)...), " "), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("merging flags panicked: %v", r)
+				}
+			}()
+			cmd.InheritedFlags()
+			cmd.LocalFlags()
+		})
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+}
+
+// TestSchemaSkipsArgs verifies --schema prints the schema of a command that
+// takes arguments without them, while the command itself still requires them.
+func TestSchemaSkipsArgs(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	t.Setenv("LIRT_CONFIG_FILE", "")
+	runE := issueViewCmd.RunE
+	defer func() {
+		issueViewCmd.RunE = runE
+		schemaFlag, formatFlag = false, ""
+		rootCmd.SetArgs(nil)
+	}()
+	allowSchemaArgs(rootCmd)
+
+	rootCmd.SetArgs([]string{"issue", "view"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "accepts 1 arg") {
+		t.Fatalf("issue view without an ID: error = %v, want an argument error", err)
+	}
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"issue", "view", "--schema", "--format", "json"})
+	err = rootCmd.Execute()
+	w.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("issue view --schema: error = %v", err)
+	}
+	out, _ := io.ReadAll(r)
+	if !strings.Contains(string(out), `"identifier"`) {
+		t.Errorf("issue view --schema printed %s, want the issue schema", out)
+	}
+}
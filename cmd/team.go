@@ -2,10 +2,30 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	teamMineFlag     bool
+	teamCurrentFlag  bool
+	teamNextFlag     bool
+	teamPreviousFlag bool
+)
+
+// cycleRow is a cycle as shown in table, CSV, and plain output. JSON output
+// keeps the full model.Cycle.
+type cycleRow struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Dates      string `json:"dates"`
+	Completion string `json:"completion"`
+}
+
 // teamCmd represents the team command
 var teamCmd = &cobra.Command{
 	Use:   "team",
@@ -17,16 +37,28 @@ var teamCmd = &cobra.Command{
 var teamListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all teams",
-	Long:  `List all teams with their keys, names, and descriptions.`,
+	Long: `List all teams with their keys, names, descriptions, icons, and colors.
+On a color terminal each team key is shown in the team's color.
+
+The MEMBERSHIP column shows your role in each team (owner or member). Use
+--mine to list only the teams you belong to.
+
+Examples:
+  lirt team list
+  lirt team list --mine`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := getClient()
+		apiClient, err := getClient()
 		if err != nil {
 			return err
 		}
 
 		// Check cache first
 		cacheKey := "teams"
-		var teams interface{}
+		if teamMineFlag {
+			cacheKey = "teams-mine"
+		}
+		cacheKey = listCacheKey(cacheKey)
+		var teams []model.Team
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &teams); err == nil && found {
 				return formatter.Output(teams)
@@ -34,11 +66,18 @@ var teamListCmd = &cobra.Command{
 		}
 
 		// Fetch from API
-		teams, err = client.ListTeams(getContext())
+		teams, err = apiClient.ListTeams(listContext())
 		if err != nil {
 			return fmt.Errorf("failed to list teams: %w", err)
 		}
 
+		// Resolve the viewer's memberships once for all teams
+		roles, err := apiClient.ListTeamMemberships(getContext())
+		if err != nil {
+			return fmt.Errorf("failed to list team memberships: %w", err)
+		}
+		teams = client.ApplyMemberships(teams, roles, teamMineFlag)
+
 		// Cache the results
 		if !noCacheFlag {
 			cacheInstance.Set(cacheKey, teams)
@@ -74,10 +113,26 @@ var teamMembersCmd = &cobra.Command{
 var teamStatesCmd = &cobra.Command{
 	Use:   "states <key-or-id>",
 	Short: "List team workflow states",
-	Long:  `List workflow states for a specific team.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List workflow states for a specific team. Same output as
+lirt meta states --team <key>.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("team states: not yet implemented")
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		teamID, err := resolveTeamID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		states, err := getWorkflowStates(apiClient, teamID)
+		if err != nil {
+			return err
+		}
+
+		return formatter.Output(states)
 	},
 }
 
@@ -85,10 +140,27 @@ var teamStatesCmd = &cobra.Command{
 var teamLabelsCmd = &cobra.Command{
 	Use:   "labels <key-or-id>",
 	Short: "List team labels",
-	Long:  `List labels for a specific team.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List the labels available to a specific team: its own labels and
+workspace labels (shown without a team). Same output as
+lirt meta labels <key>.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("team labels: not yet implemented")
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		teamID, err := resolveTeamID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		labels, err := getLabels(apiClient)
+		if err != nil {
+			return err
+		}
+
+		return formatter.Output(client.TeamLabels(labels, teamID))
 	},
 }
 
@@ -96,13 +168,125 @@ var teamLabelsCmd = &cobra.Command{
 var teamCyclesCmd = &cobra.Command{
 	Use:   "cycles <key-or-id>",
 	Short: "List team cycles",
-	Long:  `List cycles for a specific team.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List cycles for a specific team, oldest first, with their date range and
+completion.
+
+--current, --next, and --previous show only the cycle running now, the next
+one to start, or the last one to end.
+
+Examples:
+  lirt team cycles ENG
+  lirt team cycles ENG --current`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("team cycles: not yet implemented")
+		apiClient, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		teamID, err := resolveTeamID(apiClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		cycles, err := getCycles(apiClient, teamID)
+		if err != nil {
+			return err
+		}
+
+		selector := ""
+		switch {
+		case teamCurrentFlag:
+			selector = client.CycleCurrent
+		case teamNextFlag:
+			selector = client.CycleNext
+		case teamPreviousFlag:
+			selector = client.CyclePrevious
+		}
+		if selector != "" {
+			cycle, err := client.SelectCycle(cycles, selector, time.Now())
+			if err != nil {
+				return fmt.Errorf("%w for team %s", err, args[0])
+			}
+			cycles = []model.Cycle{*cycle}
+		}
+
+		return outputCycles(cycles)
 	},
 }
 
+// getTeams returns all teams for resolving and validating --team values.
+// They are cached under their own key since, unlike team list, they carry
+// no membership roles.
+func getTeams(apiClient *client.Client) ([]model.Team, error) {
+	cacheKey := "teams-lookup"
+	var teams []model.Team
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &teams); err == nil && found {
+			return teams, nil
+		}
+	}
+
+	teams, err := apiClient.ListTeams(getContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, teams)
+	}
+
+	return teams, nil
+}
+
+// getCycles returns a team's cycles, cached under cycles-<team-id>
+func getCycles(apiClient *client.Client, teamID string) ([]model.Cycle, error) {
+	// Check cache
+	cacheKey := fmt.Sprintf("cycles-%s", teamID)
+	var cycles []model.Cycle
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &cycles); err == nil && found {
+			return cycles, nil
+		}
+	}
+
+	// Fetch from API
+	cycles, err := apiClient.ListCycles(getContext(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cycles: %w", err)
+	}
+
+	// Cache results
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, cycles)
+	}
+
+	return cycles, nil
+}
+
+// outputCycles writes cycles, with their dates as a range and completion as
+// "done" or a percentage outside JSON
+func outputCycles(cycles []model.Cycle) error {
+	if formatter.Format() == output.FormatJSON {
+		return formatter.Output(cycles)
+	}
+
+	rows := make([]cycleRow, len(cycles))
+	for i, cycle := range cycles {
+		completion := formatProgress(cycle.Progress)
+		if cycle.Completed {
+			completion = "done"
+		}
+		rows[i] = cycleRow{
+			Number:     cycle.Number,
+			Name:       cycle.Name,
+			Dates:      cycle.StartsAt.Format("2006-01-02") + " – " + cycle.EndsAt.Format("2006-01-02"),
+			Completion: completion,
+		}
+	}
+	return formatter.Output(rows)
+}
+
 func init() {
 	rootCmd.AddCommand(teamCmd)
 
@@ -113,4 +297,13 @@ func init() {
 	teamCmd.AddCommand(teamStatesCmd)
 	teamCmd.AddCommand(teamLabelsCmd)
 	teamCmd.AddCommand(teamCyclesCmd)
+
+	// Flags for team list
+	teamListCmd.Flags().BoolVar(&teamMineFlag, "mine", false, "Only list teams you are a member of")
+
+	// Flags for team cycles
+	teamCyclesCmd.Flags().BoolVar(&teamCurrentFlag, "current", false, "Show only the cycle running now")
+	teamCyclesCmd.Flags().BoolVar(&teamNextFlag, "next", false, "Show only the next cycle to start")
+	teamCyclesCmd.Flags().BoolVar(&teamPreviousFlag, "previous", false, "Show only the most recently ended cycle")
+	teamCyclesCmd.MarkFlagsMutuallyExclusive("current", "next", "previous")
 }
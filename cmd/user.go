@@ -2,10 +2,58 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/dixson3/lirt/internal/client"
+	"github.com/dixson3/lirt/internal/model"
+	"github.com/dixson3/lirt/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	userSortFlag      string
+	userGroupByFlag   string
+	userStateTypeFlag string
+	userTeamFlag      string
+	userAllTeamsFlag  bool
+	userPriorityFlag  string
+	userRelationFlag  string
+	userCountsFlag    bool
+	userShowEmailFlag bool
+)
+
+// userRow is a user as listed in table, CSV, and plain output. JSON output
+// keeps the full model.User.
+type userRow struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email,omitempty"`
+	Active bool   `json:"active"`
+}
+
+// userDetail is a single user as shown by user view in table, CSV, and
+// plain output
+type userDetail struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	FullName       string `json:"fullName"`
+	Email          string `json:"email"`
+	Active         bool   `json:"active"`
+	Admin          bool   `json:"admin"`
+	Timezone       string `json:"timezone,omitempty"`
+	AssignedIssues string `json:"assignedIssues,omitempty"`
+	CreatedIssues  string `json:"createdIssues,omitempty"`
+}
+
+// userIssueFields are the fields accepted by user issues --sort and --group-by
+var userIssueFields = []string{"state", "priority", "project"}
+
+// userIssueRelations are the values accepted by user issues --issues
+var userIssueRelations = []string{string(client.UserIssuesAssigned), string(client.UserIssuesCreated)}
+
+// stateTypes are the workflow state types accepted by --state-type
+var stateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
 // userCmd represents the user command
 var userCmd = &cobra.Command{
 	Use:   "user",
@@ -17,7 +65,11 @@ var userCmd = &cobra.Command{
 var userListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all users",
-	Long:  `List all users in the workspace.`,
+	Long: `List all users in the workspace.
+
+The NAME column shows each user's display name, falling back to their full
+name. Emails are hidden in table, CSV, and plain output unless --show-email
+is given; JSON output always includes every field.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -25,16 +77,16 @@ var userListCmd = &cobra.Command{
 		}
 
 		// Check cache first
-		cacheKey := "users"
-		var users interface{}
+		cacheKey := listCacheKey("users")
+		var users []model.User
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &users); err == nil && found {
-				return formatter.Output(users)
+				return outputUsers(users)
 			}
 		}
 
 		// Fetch from API
-		users, err = apiClient.ListUsers(getContext())
+		users, err = apiClient.ListUsers(listContext())
 		if err != nil {
 			return fmt.Errorf("failed to list users: %w", err)
 		}
@@ -44,7 +96,7 @@ var userListCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, users)
 		}
 
-		return formatter.Output(users)
+		return outputUsers(users)
 	},
 }
 
@@ -52,8 +104,11 @@ var userListCmd = &cobra.Command{
 var userViewCmd = &cobra.Command{
 	Use:   "view <user-id>",
 	Short: "View user details",
-	Long:  `View detailed information about a specific user.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific user.
+
+Use --counts to include how many issues the user is assigned and has created.
+Counting stops past 500 issues, shown as >500.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiClient, err := getClient()
 		if err != nil {
@@ -64,15 +119,18 @@ var userViewCmd = &cobra.Command{
 
 		// Check cache
 		cacheKey := fmt.Sprintf("user-%s", userID)
-		var user interface{}
+		if userCountsFlag {
+			cacheKey += "-counts"
+		}
+		var user *model.User
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &user); err == nil && found {
-				return formatter.Output(user)
+				return outputUser(user)
 			}
 		}
 
 		// Fetch from API
-		user, err = apiClient.GetUser(getContext(), userID)
+		user, err = apiClient.GetUser(getContext(), userID, userCountsFlag)
 		if err != nil {
 			return fmt.Errorf("failed to get user: %w", err)
 		}
@@ -82,7 +140,7 @@ var userViewCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, user)
 		}
 
-		return formatter.Output(user)
+		return outputUser(user)
 	},
 }
 
@@ -109,11 +167,36 @@ var userMeCmd = &cobra.Command{
 
 // userIssuesCmd represents the user issues command
 var userIssuesCmd = &cobra.Command{
-	Use:   "issues <user-id>",
-	Short: "List user's assigned issues",
-	Long:  `List all issues assigned to a specific user.`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "issues <user-id|me>",
+	Short: "List user's assigned or created issues",
+	Long: `List all issues assigned to a specific user.
+
+Pass "me" to list issues assigned to the authenticated user. Use
+--issues created to list the issues the user filed instead.
+
+Examples:
+  lirt user issues me
+  lirt user issues <user-id> --team ENG --state-type started
+  lirt user issues <user-id> --priority urgent
+  lirt user issues me --issues created
+  lirt user issues <user-id> --group-by state
+  lirt user issues <user-id> --sort priority --group-by project`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateField("--sort", userSortFlag, userIssueFields); err != nil {
+			return err
+		}
+		if err := validateField("--group-by", userGroupByFlag, userIssueFields); err != nil {
+			return err
+		}
+		if err := validateField("--state-type", userStateTypeFlag, stateTypes); err != nil {
+			return err
+		}
+		if err := validateField("--issues", userRelationFlag, userIssueRelations); err != nil {
+			return err
+		}
+		relation := client.UserIssueRelation(userRelationFlag)
+
 		apiClient, err := getClient()
 		if err != nil {
 			return err
@@ -121,17 +204,55 @@ var userIssuesCmd = &cobra.Command{
 
 		userID := args[0]
 
+		// Build filters layered onto the user's issues query
+		filters := &client.IssueFilters{}
+
+		teamRef := userTeamFlag
+		if teamRef == "" {
+			teamRef = defaultTeamRef(userAllTeamsFlag)
+		}
+		if teamRef != "" {
+			teamID, err := resolveTeamID(apiClient, teamRef)
+			if err != nil {
+				return err
+			}
+			filters.TeamID = &teamID
+		}
+
+		if userStateTypeFlag != "" {
+			filters.StateType = &userStateTypeFlag
+		}
+
+		if userPriorityFlag != "" {
+			priority, err := parsePriority(userPriorityFlag)
+			if err != nil {
+				return err
+			}
+			filters.Priority = &priority
+		}
+
 		// Check cache
-		cacheKey := fmt.Sprintf("user-issues-%s", userID)
+		cacheKey := listCacheKey(fmt.Sprintf("user-issues-%s-%s-%s-%s-%s", userID, relation, teamRef, userStateTypeFlag, userPriorityFlag))
 		var issues interface{}
 		if !noCacheFlag {
 			if found, err := cacheInstance.Get(cacheKey, &issues); err == nil && found {
-				return formatter.Output(issues)
+				return outputList(issues, userSortFlag, userGroupByFlag)
 			}
 		}
 
-		// Fetch from API
-		issues, err = apiClient.ListUserIssues(getContext(), userID)
+		// Fetch from API (the viewer connection avoids resolving our own ID)
+		switch {
+		case userID == "me" && relation == client.UserIssuesAssigned:
+			issues, err = apiClient.ListMyIssues(listContext(), filters)
+		case userID == "me":
+			viewer, viewerErr := getViewer(apiClient)
+			if viewerErr != nil {
+				return viewerErr
+			}
+			issues, err = apiClient.ListUserIssues(listContext(), viewer.ID, relation, filters)
+		default:
+			issues, err = apiClient.ListUserIssues(listContext(), userID, relation, filters)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to list user issues: %w", err)
 		}
@@ -141,10 +262,120 @@ var userIssuesCmd = &cobra.Command{
 			cacheInstance.Set(cacheKey, issues)
 		}
 
-		return formatter.Output(issues)
+		return outputList(issues, userSortFlag, userGroupByFlag)
 	},
 }
 
+// userName returns the name a user goes by: their display name if set,
+// otherwise their full name
+func userName(user *model.User) string {
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Name
+}
+
+// userRows converts users to the rows listed outside JSON
+func userRows(users []model.User) []userRow {
+	rows := make([]userRow, len(users))
+	for i := range users {
+		rows[i] = userRow{
+			ID:     users[i].ID,
+			Name:   userName(&users[i]),
+			Active: users[i].Active,
+		}
+		if userShowEmailFlag {
+			rows[i].Email = users[i].Email
+		}
+	}
+	return rows
+}
+
+// outputUsers writes a user list, trimmed to the useful columns outside JSON
+func outputUsers(users []model.User) error {
+	if formatter.Format() == output.FormatJSON {
+		return formatter.Output(users)
+	}
+	return formatter.Output(userRows(users))
+}
+
+// outputUser writes a single user with their account details
+func outputUser(user *model.User) error {
+	if formatter.Format() == output.FormatJSON {
+		return formatter.Output(user)
+	}
+
+	return formatter.Output(userDetail{
+		ID:             user.ID,
+		Name:           userName(user),
+		FullName:       user.Name,
+		Email:          user.Email,
+		Active:         user.Active,
+		Admin:          user.Admin,
+		Timezone:       user.Timezone,
+		AssignedIssues: issueCount(user.AssignedIssueCount, user.AssignedIssuesCapped),
+		CreatedIssues:  issueCount(user.CreatedIssueCount, user.CreatedIssuesCapped),
+	})
+}
+
+// issueCount formats an issue count for user view, ">N" when counting
+// stopped at the cap; empty when counts were not requested
+func issueCount(count *int, capped bool) string {
+	switch {
+	case count == nil:
+		return ""
+	case capped:
+		return fmt.Sprintf(">%d", *count)
+	default:
+		return strconv.Itoa(*count)
+	}
+}
+
+// resolveUserID resolves "me", a UUID, an email, or a (display) name to the
+// ID of an existing user
+func resolveUserID(apiClient *client.Client, userRef string) (string, error) {
+	if userRef == "me" {
+		viewer, err := getViewer(apiClient)
+		if err != nil {
+			return "", err
+		}
+		return viewer.ID, nil
+	}
+	users, err := getUsers(apiClient)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := client.FindUser(users, userRef)
+	if err != nil {
+		return "", err
+	}
+	logger.Debug("resolved user", "ref", userRef, "id", user.ID)
+	return user.ID, nil
+}
+
+// getUsers returns all users, sharing the uncapped user list cache entry
+func getUsers(apiClient *client.Client) ([]model.User, error) {
+	cacheKey := "users"
+	var users []model.User
+	if !noCacheFlag {
+		if found, err := cacheInstance.Get(cacheKey, &users); err == nil && found {
+			return users, nil
+		}
+	}
+
+	users, err := apiClient.ListUsers(getContext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	if !noCacheFlag {
+		cacheInstance.Set(cacheKey, users)
+	}
+
+	return users, nil
+}
+
 func init() {
 	rootCmd.AddCommand(userCmd)
 
@@ -153,4 +384,20 @@ func init() {
 	userCmd.AddCommand(userViewCmd)
 	userCmd.AddCommand(userMeCmd)
 	userCmd.AddCommand(userIssuesCmd)
+
+	// Flags for user issues
+	userIssuesCmd.Flags().StringVar(&userSortFlag, "sort", "", "Sort by field (state, priority, project)")
+	userIssuesCmd.Flags().StringVar(&userGroupByFlag, "group-by", "", "Group by field (state, priority, project)")
+	userIssuesCmd.Flags().StringVar(&userStateTypeFlag, "state-type", "", "Filter by state type (triage, backlog, unstarted, started, completed, canceled)")
+	userIssuesCmd.Flags().StringVar(&userTeamFlag, "team", "", "Filter by team key or ID (defaults to the configured team)")
+	userIssuesCmd.Flags().BoolVar(&userAllTeamsFlag, "all-teams", false, "List issues in every team, ignoring the default team")
+	userIssuesCmd.MarkFlagsMutuallyExclusive("team", "all-teams")
+	userIssuesCmd.Flags().StringVar(&userPriorityFlag, "priority", "", "Filter by priority (0-4 or urgent/high/medium/low/none)")
+	userIssuesCmd.Flags().StringVar(&userRelationFlag, "issues", string(client.UserIssuesAssigned), "Which issues to list (assigned, created)")
+
+	// Flags for user list
+	userListCmd.Flags().BoolVar(&userShowEmailFlag, "show-email", false, "Include an EMAIL column in table, CSV, and plain output")
+
+	// Flags for user view
+	userViewCmd.Flags().BoolVar(&userCountsFlag, "counts", false, "Include assigned and created issue counts")
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/dixson3/lirt/internal/client"
+)
+
+// browserCommand returns the OS-appropriate command to open a URL
+func browserCommand(url string) (string, []string) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "cmd", []string{"/c", "start", "", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// openWeb opens url in the default browser. If no opener is available
+// (e.g. on a headless machine) the URL is written to the output instead.
+func openWeb(url string) error {
+	if url == "" {
+		return fmt.Errorf("no URL available")
+	}
+
+	name, args := browserCommand(url)
+	if path, err := exec.LookPath(name); err == nil {
+		opener := exec.Command(path, args...)
+		if err := opener.Start(); err == nil {
+			// The opener hands off to the browser; lirt does not wait for it
+			opener.Process.Release()
+			formatter.Statusf("Opening %s in your browser.\n", url)
+			return nil
+		}
+	}
+
+	_, err := fmt.Fprintln(formatter.Writer(), url)
+	return err
+}
+
+// workspaceURL builds a Linear web URL for a path within the authenticated
+// workspace, e.g. workspaceURL(c, "issue", "ENG-123")
+func workspaceURL(apiClient *client.Client, kind, id string) (string, error) {
+	viewer, err := apiClient.GetViewer(getContext())
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if viewer.Organization == nil || viewer.Organization.URLKey == "" {
+		return "", fmt.Errorf("workspace URL key not available")
+	}
+	return fmt.Sprintf("https://linear.app/%s/%s/%s", viewer.Organization.URLKey, kind, id), nil
+}
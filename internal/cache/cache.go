@@ -1,23 +1,61 @@
 package cache
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dixson3/lirt/internal/config"
+	"github.com/dixson3/lirt/internal/logging"
 )
 
+// SchemaVersion identifies the layout of cached model data. Bump it whenever
+// a cached model struct changes so entries written by older releases are
+// discarded instead of decoding into partially-populated structs.
+const SchemaVersion = 13
+
+// DefaultTTL is the cache lifetime used when neither cache_ttl nor
+// --cache-ttl is set.
+const DefaultTTL = 5 * time.Minute
+
+// NoExpiry is a TTL under which cached data never expires, for serving
+// whatever is cached when the API must not be used (--cache-only)
+const NoExpiry = time.Duration(math.MaxInt64)
+
+// ParseTTL parses a cache lifetime such as "30s" or "1m". Zero disables
+// caching; negative durations are rejected.
+func ParseTTL(s string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache TTL %q: %w", s, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid cache TTL %q: must not be negative", s)
+	}
+	return ttl, nil
+}
+
 // Cache represents a file-based cache
 type Cache struct {
 	profile string
 	ttl     time.Duration
+	logger  *slog.Logger
 }
 
-// CachedData represents cached data with metadata
+// CachedData represents cached data with metadata. Key holds the original
+// cache key, since the file itself is named after its hash.
 type CachedData struct {
+	Version   int         `json:"version"`
+	Key       string      `json:"key"`
 	FetchedAt time.Time   `json:"fetchedAt"`
 	Data      interface{} `json:"data"`
 }
@@ -27,14 +65,41 @@ func New(profile string, ttl time.Duration) *Cache {
 	return &Cache{
 		profile: profile,
 		ttl:     ttl,
+		logger:  logging.Discard(),
 	}
 }
 
+// SetLogger sets where cache hits and misses are logged (at debug level)
+func (c *Cache) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
 // GetCacheDir returns the cache directory for this profile
 func (c *Cache) GetCacheDir() string {
 	return filepath.Join(config.GetConfigDir(), "cache", c.profile)
 }
 
+// path returns the file an entry is stored in. Keys embed filter values
+// such as search terms, so the file is named after the key's SHA-256 rather
+// than the key itself to keep slashes and other unsafe characters out of
+// the path. A list capped by ListKey is named after its base key's hash
+// plus the limit (<hash>-limit-N.json), so the capped lists of a key can be
+// found from file names alone.
+func (c *Cache) path(key string) string {
+	base, limit := splitListKey(key)
+	name := hashKey(base)
+	if limit > 0 {
+		name += limitSuffix + strconv.Itoa(limit)
+	}
+	return filepath.Join(c.GetCacheDir(), name+".json")
+}
+
+// hashKey returns the hex SHA-256 of a cache key
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
 // ensureCacheDir creates the cache directory if it doesn't exist
 func (c *Cache) ensureCacheDir() error {
 	dir := c.GetCacheDir()
@@ -43,37 +108,219 @@ func (c *Cache) ensureCacheDir() error {
 
 // Get retrieves cached data if it exists and is not expired
 func (c *Cache) Get(key string, target interface{}) (bool, error) {
-	cachePath := filepath.Join(c.GetCacheDir(), key+".json")
+	return c.GetWithTTL(key, c.ttl, target)
+}
+
+// GetWithTTL retrieves cached data if it is younger than ttl. The effective
+// TTL never exceeds the cache's configured TTL, so expensive entries can be
+// given a shorter lifetime without outliving a user's cache_ttl setting.
+// Under NoExpiry ttl is ignored, so --cache-only serves every entry.
+func (c *Cache) GetWithTTL(key string, ttl time.Duration, target interface{}) (bool, error) {
+	if ttl > c.ttl || c.ttl == NoExpiry {
+		ttl = c.ttl
+	}
 
-	data, err := os.ReadFile(cachePath)
+	cached, ok, err := c.read(key)
+	if err != nil {
+		c.logger.Debug("cache entry unreadable", "key", key, "error", err)
+		return false, err
+	}
+	if !ok {
+		c.logger.Debug("cache miss", "key", key)
+		return false, nil
+	}
+
+	// Check if expired
+	age := time.Since(cached.FetchedAt)
+	if age > ttl {
+		c.logger.Debug("cache expired", "key", key, "age", age.Round(time.Second), "ttl", ttl)
+		return false, nil
+	}
+
+	// Unmarshal the actual data into target
+	if err := decode(cached, target); err != nil {
+		c.logger.Debug("cache entry unreadable", "key", key, "error", err)
+		return false, err
+	}
+
+	c.logger.Debug("cache hit", "key", key, "age", age.Round(time.Second))
+	return true, nil
+}
+
+// limitSuffix separates a list's cache key from the --limit it was fetched
+// with, e.g. "issues-ENG-limit-10"
+const limitSuffix = "-limit-"
+
+// ListKey returns the cache key for a list fetched with limit (0 = all), so
+// capped results are never served for an uncapped request
+func ListKey(key string, limit int) string {
+	if limit > 0 {
+		return fmt.Sprintf("%s%s%d", key, limitSuffix, limit)
+	}
+	return key
+}
+
+// splitListKey splits a key made by ListKey into the base key and limit.
+// Any other key is its own base, with limit 0.
+func splitListKey(key string) (string, int) {
+	i := strings.LastIndex(key, limitSuffix)
+	if i < 0 {
+		return key, 0
+	}
+	n, err := strconv.Atoi(key[i+len(limitSuffix):])
+	if err != nil || ListKey(key[:i], n) != key {
+		return key, 0
+	}
+	return key[:i], n
+}
+
+// GetList retrieves a list cached under key for a request capped at limit
+// (0 = all). Besides the entry for exactly that limit, an unexpired entry for
+// the same key fetched without a limit or with a larger one satisfies the
+// request: pages arrive in the same order, so its first limit items are what
+// a fresh capped fetch would return.
+func GetList[T any](c *Cache, key string, limit int) ([]T, bool, error) {
+	var items []T
+	found, err := c.Get(ListKey(key, limit), &items)
+	if err != nil || found || limit <= 0 {
+		return items, found, err
+	}
+
+	for _, candidate := range c.largerLists(key, limit) {
+		var larger []T
+		if found, err := c.Get(candidate, &larger); err != nil || !found {
+			continue
+		}
+		if len(larger) > limit {
+			larger = larger[:limit]
+		}
+		return larger, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// largerLists returns the cache keys of key's uncapped list and of lists
+// capped above limit, uncapped first and then smallest limit first. The
+// limits are read from file names (see path) rather than from the entries.
+func (c *Cache) largerLists(key string, limit int) []string {
+	entries, _ := os.ReadDir(c.GetCacheDir())
+	prefix := hashKey(key) + limitSuffix
+
+	limits := []int{}
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSuffix(rest, ".json")); err == nil && n > limit {
+			limits = append(limits, n)
+		}
+	}
+	sort.Ints(limits)
+
+	keys := []string{key}
+	for _, n := range limits {
+		keys = append(keys, ListKey(key, n))
+	}
+	return keys
+}
+
+// Entry describes one cached entry, for display
+type Entry struct {
+	Key       string    `json:"key"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Bytes     int64     `json:"bytes"`
+}
+
+// Entries returns the entries cached for this profile, sorted by key. Keys
+// are read from the entries themselves since file names are hashed.
+func (c *Cache) Entries() ([]Entry, error) {
+	files, err := os.ReadDir(c.GetCacheDir())
 	if err != nil {
 		if os.IsNotExist(err) {
-			return false, nil
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	entries := []Entry{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(c.GetCacheDir(), file.Name()))
+		if err != nil {
+			continue
+		}
+		var header struct {
+			Key       string    `json:"key"`
+			FetchedAt time.Time `json:"fetchedAt"`
+		}
+		if err := json.Unmarshal(data, &header); err != nil || header.Key == "" {
+			continue
 		}
-		return false, fmt.Errorf("failed to read cache file: %w", err)
+		entries = append(entries, Entry{Key: header.Key, FetchedAt: header.FetchedAt, Bytes: int64(len(data))})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// Peek retrieves cached data regardless of expiry, returning when it was
+// fetched. Used for incremental refreshes that build on stale entries.
+func (c *Cache) Peek(key string, target interface{}) (time.Time, bool, error) {
+	cached, ok, err := c.read(key)
+	if err != nil || !ok {
+		return time.Time{}, false, err
+	}
+
+	if err := decode(cached, target); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return cached.FetchedAt, true, nil
+}
+
+// read loads a cache entry. Entries written with a different SchemaVersion
+// are treated as a miss and removed, as is an entry stored for another key.
+func (c *Cache) read(key string) (*CachedData, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read cache file: %w", err)
 	}
 
 	var cached CachedData
 	if err := json.Unmarshal(data, &cached); err != nil {
-		return false, fmt.Errorf("failed to unmarshal cache data: %w", err)
+		return nil, false, fmt.Errorf("failed to unmarshal cache data: %w", err)
 	}
 
-	// Check if expired
-	if time.Since(cached.FetchedAt) > c.ttl {
-		return false, nil
+	if cached.Version != SchemaVersion {
+		_ = c.Invalidate(key)
+		return nil, false, nil
+	}
+	if cached.Key != key {
+		return nil, false, nil
 	}
 
-	// Unmarshal the actual data into target
+	return &cached, true, nil
+}
+
+// decode unmarshals the data of a cache entry into target
+func decode(cached *CachedData, target interface{}) error {
 	dataBytes, err := json.Marshal(cached.Data)
 	if err != nil {
-		return false, fmt.Errorf("failed to marshal cached data: %w", err)
+		return fmt.Errorf("failed to marshal cached data: %w", err)
 	}
 
 	if err := json.Unmarshal(dataBytes, target); err != nil {
-		return false, fmt.Errorf("failed to unmarshal target data: %w", err)
+		return fmt.Errorf("failed to unmarshal target data: %w", err)
 	}
 
-	return true, nil
+	return nil
 }
 
 // Set stores data in the cache
@@ -83,6 +330,8 @@ func (c *Cache) Set(key string, data interface{}) error {
 	}
 
 	cached := CachedData{
+		Version:   SchemaVersion,
+		Key:       key,
 		FetchedAt: time.Now(),
 		Data:      data,
 	}
@@ -92,23 +341,80 @@ func (c *Cache) Set(key string, data interface{}) error {
 		return fmt.Errorf("failed to marshal cache data: %w", err)
 	}
 
-	cachePath := filepath.Join(c.GetCacheDir(), key+".json")
-	if err := os.WriteFile(cachePath, jsonData, 0600); err != nil {
+	if err := os.WriteFile(c.path(key), jsonData, 0600); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
 	return nil
 }
 
-// Invalidate removes a cache entry
+// Invalidate removes a cache entry. Invalidating a list's base key also
+// removes the lists capped from it by ListKey (e.g. "favorites-limit-10"),
+// found by file name prefix (see path).
 func (c *Cache) Invalidate(key string) error {
-	cachePath := filepath.Join(c.GetCacheDir(), key+".json")
-	if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove cache file: %w", err)
+	paths := []string{c.path(key)}
+	if _, limit := splitListKey(key); limit == 0 {
+		entries, _ := os.ReadDir(c.GetCacheDir())
+		prefix := hashKey(key) + limitSuffix
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), prefix) {
+				paths = append(paths, filepath.Join(c.GetCacheDir(), entry.Name()))
+			}
+		}
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cache file: %w", err)
+		}
 	}
 	return nil
 }
 
+// Size returns the total bytes and number of entries cached for this profile
+func (c *Cache) Size() (int64, int, error) {
+	var bytes int64
+	entries := 0
+
+	err := filepath.WalkDir(c.GetCacheDir(), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		bytes += info.Size()
+		entries++
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to measure cache: %w", err)
+	}
+
+	return bytes, entries, nil
+}
+
+// CheckWritable verifies the cache directory can be created and written to
+func (c *Cache) CheckWritable() error {
+	if err := c.ensureCacheDir(); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	probe, err := os.CreateTemp(c.GetCacheDir(), ".probe-*")
+	if err != nil {
+		return fmt.Errorf("cache directory is not writable: %w", err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
 // Clear removes all cache entries for this profile
 func (c *Cache) Clear() error {
 	dir := c.GetCacheDir()
@@ -0,0 +1,403 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestSchemaVersionMismatch verifies that entries written with a different
+// schema version are treated as a miss and removed from disk.
+func TestSchemaVersionMismatch(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		name    string
+		version int
+		wantHit bool
+	}{
+		{name: "current version", version: SchemaVersion, wantHit: true},
+		{name: "old version", version: SchemaVersion - 1, wantHit: false},
+		{name: "missing version", version: 0, wantHit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("test", time.Hour)
+			if err := c.ensureCacheDir(); err != nil {
+				t.Fatalf("ensureCacheDir() error = %v", err)
+			}
+
+			entry := map[string]interface{}{
+				"key":       "items",
+				"fetchedAt": time.Now(),
+				"data":      []string{"a", "b"},
+			}
+			if tt.version != 0 {
+				entry["version"] = tt.version
+			}
+			raw, err := json.Marshal(entry)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			path := c.path("items")
+			if err := os.WriteFile(path, raw, 0600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			var got []string
+			hit, err := c.Get("items", &got)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if hit != tt.wantHit {
+				t.Errorf("Get() hit = %v, want %v", hit, tt.wantHit)
+			}
+
+			_, statErr := os.Stat(path)
+			if exists := statErr == nil; exists != tt.wantHit {
+				t.Errorf("cache file exists = %v, want %v", exists, tt.wantHit)
+			}
+		})
+	}
+}
+
+// TestSetWritesSchemaVersion verifies that Set stamps entries with the
+// current schema version so they round-trip through Get and Peek.
+func TestSetWritesSchemaVersion(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+
+	c := New("test", time.Hour)
+	if err := c.Set("items", []string{"a"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	var got []string
+	if hit, err := c.Get("items", &got); err != nil || !hit {
+		t.Fatalf("Get() = %v, %v; want hit", hit, err)
+	}
+	if _, hit, err := c.Peek("items", &got); err != nil || !hit {
+		t.Fatalf("Peek() = %v, %v; want hit", hit, err)
+	}
+}
+
+// TestSize verifies that Size counts cache entries and bytes, and reports an
+// empty cache when the directory does not exist yet.
+func TestSize(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	c := New("test", time.Hour)
+
+	bytes, entries, err := c.Size()
+	if err != nil || bytes != 0 || entries != 0 {
+		t.Fatalf("Size() on missing dir = %d, %d, %v; want 0, 0, nil", bytes, entries, err)
+	}
+
+	if err := c.CheckWritable(); err != nil {
+		t.Fatalf("CheckWritable() error = %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if err := c.Set(key, []string{key}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	bytes, entries, err = c.Size()
+	if err != nil {
+		t.Fatalf("Size() error = %v", err)
+	}
+	if entries != 2 {
+		t.Errorf("Size() entries = %d, want 2", entries)
+	}
+	if bytes <= 0 {
+		t.Errorf("Size() bytes = %d, want > 0", bytes)
+	}
+}
+
+// TestGetList verifies that a capped list request is served from an
+// uncapped or larger cached list with the same key, cut to the limit, but
+// never from a smaller list or one for different filters.
+func TestGetList(t *testing.T) {
+	items := func(n int) []int {
+		list := make([]int, n)
+		for i := range list {
+			list[i] = i + 1
+		}
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		entries map[string][]int
+		limit   int
+		want    []int
+		wantHit bool
+	}{
+		{name: "exact limit", entries: map[string][]int{"issues-ENG-limit-10": items(10)}, limit: 10, want: items(10), wantHit: true},
+		{name: "from uncapped", entries: map[string][]int{"issues-ENG": items(500)}, limit: 10, want: items(10), wantHit: true},
+		{name: "from larger limit", entries: map[string][]int{"issues-ENG-limit-50": items(50)}, limit: 10, want: items(10), wantHit: true},
+		{name: "larger limit with fewer results", entries: map[string][]int{"issues-ENG-limit-50": items(3)}, limit: 10, want: items(3), wantHit: true},
+		{name: "smaller limit misses", entries: map[string][]int{"issues-ENG-limit-5": items(5)}, limit: 10, wantHit: false},
+		{name: "other filters miss", entries: map[string][]int{"issues-ENG-started": items(500), "issues-ENG-started-limit-50": items(50)}, limit: 10, wantHit: false},
+		{name: "uncapped request needs uncapped entry", entries: map[string][]int{"issues-ENG-limit-50": items(50)}, limit: 0, wantHit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+			c := New("test", time.Hour)
+			for key, list := range tt.entries {
+				if err := c.Set(key, list); err != nil {
+					t.Fatalf("Set(%s) error = %v", key, err)
+				}
+			}
+
+			got, hit, err := GetList[int](c, "issues-ENG", tt.limit)
+			if err != nil {
+				t.Fatalf("GetList() error = %v", err)
+			}
+			if hit != tt.wantHit {
+				t.Fatalf("GetList() hit = %v, want %v", hit, tt.wantHit)
+			}
+			if hit && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestListKeyPath verifies capped lists are stored under their base key's
+// hash and limit, and that keys merely resembling ListKey keys are not.
+func TestListKeyPath(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	c := New("test", time.Hour)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "issues-ENG", want: hashKey("issues-ENG") + ".json"},
+		{key: "issues-ENG-limit-10", want: hashKey("issues-ENG") + "-limit-10.json"},
+		{key: "issues-ENG-limit-010", want: hashKey("issues-ENG-limit-010") + ".json"},
+		{key: "issues-ENG-limit-0", want: hashKey("issues-ENG-limit-0") + ".json"},
+		{key: "issues-ENG-limit-x", want: hashKey("issues-ENG-limit-x") + ".json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := filepath.Base(c.path(tt.key)); got != tt.want {
+				t.Errorf("path(%q) = %s, want %s", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestInvalidateList verifies invalidating a list's base key removes its
+// capped lists too, while a capped key only removes itself.
+func TestInvalidateList(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	c := New("test", time.Hour)
+
+	keys := []string{"favorites", ListKey("favorites", 10), ListKey("favorites", 50), "favorites-extra"}
+	for _, key := range keys {
+		if err := c.Set(key, []string{key}); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+	found := func(key string) bool {
+		var items []string
+		ok, err := c.Get(key, &items)
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", key, err)
+		}
+		return ok
+	}
+
+	if err := c.Invalidate(ListKey("favorites", 10)); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if found(ListKey("favorites", 10)) || !found("favorites") || !found(ListKey("favorites", 50)) {
+		t.Error("invalidating a capped list touched other entries")
+	}
+
+	if err := c.Invalidate("favorites"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	for _, key := range keys[:3] {
+		if found(key) {
+			t.Errorf("%s still cached after invalidating favorites", key)
+		}
+	}
+	if !found("favorites-extra") {
+		t.Error("favorites-extra was removed with favorites")
+	}
+}
+
+// TestUnsafeKeys verifies that keys containing path separators and spaces
+// are stored inside the cache directory under a hashed name, round-trip
+// through Get, and do not collide with similar keys.
+func TestUnsafeKeys(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	c := New("test", time.Hour)
+
+	keys := []string{
+		"issues-ENG-started--search:foo/bar baz",
+		"issues-ENG-started--search:foo_bar baz",
+		"../../escape",
+	}
+	for _, key := range keys {
+		if err := c.Set(key, []string{key}); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+
+	for _, key := range keys {
+		var got []string
+		hit, err := c.Get(key, &got)
+		if err != nil || !hit {
+			t.Fatalf("Get(%q) = %v, %v; want hit", key, hit, err)
+		}
+		if !reflect.DeepEqual(got, []string{key}) {
+			t.Errorf("Get(%q) = %v, want [%s]", key, got, key)
+		}
+	}
+
+	entries, err := os.ReadDir(c.GetCacheDir())
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != len(keys) {
+		t.Fatalf("cache dir has %d entries, want %d", len(entries), len(keys))
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			t.Errorf("unexpected subdirectory %s in cache dir", entry.Name())
+		}
+	}
+
+	cached, err := c.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	got := []string{}
+	for _, entry := range cached {
+		got = append(got, entry.Key)
+	}
+	want := append([]string(nil), keys...)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() keys = %v, want %v", got, want)
+	}
+}
+
+// TestParseTTL verifies that a --cache-ttl override is parsed strictly and
+// that the parsed lifetime is the one Get enforces.
+func TestParseTTL(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+		wantHit bool
+	}{
+		{name: "longer than entry age", value: "1h", want: time.Hour, wantHit: true},
+		{name: "shorter than entry age", value: "1m", want: time.Minute, wantHit: false},
+		{name: "zero disables", value: "0", want: 0, wantHit: false},
+		{name: "negative", value: "-1m", wantErr: true},
+		{name: "not a duration", value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := ParseTTL(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTTL(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if ttl != tt.want {
+				t.Fatalf("ParseTTL(%q) = %v, want %v", tt.value, ttl, tt.want)
+			}
+
+			c := New("test", ttl)
+			if err := c.ensureCacheDir(); err != nil {
+				t.Fatalf("ensureCacheDir() error = %v", err)
+			}
+			raw, err := json.Marshal(CachedData{
+				Version:   SchemaVersion,
+				Key:       "items",
+				FetchedAt: time.Now().Add(-5 * time.Minute),
+				Data:      []string{"a"},
+			})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if err := os.WriteFile(c.path("items"), raw, 0600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			var got []string
+			hit, err := c.Get("items", &got)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if hit != tt.wantHit {
+				t.Errorf("Get() hit = %v, want %v", hit, tt.wantHit)
+			}
+		})
+	}
+}
+
+// TestGetWithTTL verifies a per-entry TTL shortens the cache TTL but is
+// ignored under NoExpiry, so --cache-only still serves the entry.
+func TestGetWithTTL(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		name     string
+		cacheTTL time.Duration
+		entryTTL time.Duration
+		wantHit  bool
+	}{
+		{name: "entry TTL shortens cache TTL", cacheTTL: time.Hour, entryTTL: time.Minute, wantHit: false},
+		{name: "entry TTL within cache TTL", cacheTTL: time.Hour, entryTTL: 10 * time.Minute, wantHit: true},
+		{name: "cache TTL caps entry TTL", cacheTTL: time.Minute, entryTTL: time.Hour, wantHit: false},
+		{name: "no expiry ignores entry TTL", cacheTTL: NoExpiry, entryTTL: time.Minute, wantHit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("test", tt.cacheTTL)
+			if err := c.ensureCacheDir(); err != nil {
+				t.Fatalf("ensureCacheDir() error = %v", err)
+			}
+			raw, err := json.Marshal(CachedData{
+				Version:   SchemaVersion,
+				Key:       "viewer",
+				FetchedAt: time.Now().Add(-5 * time.Minute),
+				Data:      "u1",
+			})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if err := os.WriteFile(c.path("viewer"), raw, 0600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			var got string
+			hit, err := c.GetWithTTL("viewer", tt.entryTTL, &got)
+			if err != nil {
+				t.Fatalf("GetWithTTL() error = %v", err)
+			}
+			if hit != tt.wantHit {
+				t.Errorf("GetWithTTL() hit = %v, want %v", hit, tt.wantHit)
+			}
+		})
+	}
+}
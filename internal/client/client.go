@@ -2,11 +2,20 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	graphql "github.com/hasura/go-graphql-client"
+	"github.com/hasura/go-graphql-client/pkg/jsonutil"
+
+	"github.com/dixson3/lirt/internal/logging"
 )
 
 const (
@@ -14,11 +23,50 @@ const (
 	LinearAPIEndpoint = "https://api.linear.app/graphql"
 )
 
+// uuidPattern matches the canonical 8-4-4-4-12 hex UUID format used by Linear
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // Client wraps the Linear GraphQL client
 type Client struct {
-	graphql *graphql.Client
-	apiKey  string
-	http    *http.Client
+	graphql   *graphql.Client
+	apiKey    string
+	profile   string
+	pageSize  int
+	retry     RetryPolicy
+	http      *http.Client
+	version   string
+	userAgent string // suffix appended to lirt/<version>
+	logger    *slog.Logger
+
+	// partialWarn, when set, accepts partial responses (see WithPartialOK)
+	partialWarn func(error)
+
+	// offline refuses every request with ErrOffline (see WithOffline)
+	offline bool
+}
+
+// ErrOffline is returned for every request made by a client created
+// WithOffline, before anything is sent
+var ErrOffline = errors.New("not in cache, and --cache-only allows no API requests")
+
+// AuthError is returned when the API rejects the token (HTTP 401/403),
+// typically because it has expired or been revoked
+type AuthError struct {
+	Profile    string
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	login := "lirt auth login"
+	if e.Profile != "" && e.Profile != "default" {
+		login += " --profile " + e.Profile
+	}
+	profile := e.Profile
+	if profile == "" {
+		profile = "default"
+	}
+	return fmt.Sprintf("authentication failed (%d %s) - the API key for profile '%s' may be expired or revoked; run '%s' to re-authenticate",
+		e.StatusCode, http.StatusText(e.StatusCode), profile, login)
 }
 
 // New creates a new Linear API client
@@ -28,7 +76,11 @@ func New(apiKey string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		apiKey: apiKey,
+		apiKey:   apiKey,
+		pageSize: DefaultPageSize,
+		retry:    DefaultRetryPolicy,
+		version:  "dev",
+		logger:   logging.Discard(),
 		http: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -40,10 +92,11 @@ func New(apiKey string, opts ...Option) (*Client, error) {
 	}
 
 	// Create GraphQL client with auth
+	userAgent := c.UserAgent()
 	c.graphql = graphql.NewClient(LinearAPIEndpoint, c.http).
 		WithRequestModifier(func(req *http.Request) {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
-			req.Header.Set("User-Agent", "lirt/0.1.0")
+			req.Header.Set("User-Agent", userAgent)
 		})
 
 	return c, nil
@@ -59,6 +112,47 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithProfile sets the profile name reported in authentication errors
+func WithProfile(profile string) Option {
+	return func(c *Client) {
+		c.profile = profile
+	}
+}
+
+// WithVersion sets the lirt version reported in the User-Agent header
+func WithVersion(version string) Option {
+	return func(c *Client) {
+		if version != "" {
+			c.version = version
+		}
+	}
+}
+
+// WithUserAgent appends a suffix to the User-Agent header so tools
+// embedding lirt can identify their traffic (e.g. "my-bot/1.2")
+func WithUserAgent(suffix string) Option {
+	return func(c *Client) {
+		c.userAgent = strings.TrimSpace(suffix)
+	}
+}
+
+// WithPageSize sets how many nodes list queries request per page
+func WithPageSize(size int) Option {
+	return func(c *Client) {
+		if size > 0 {
+			c.pageSize = size
+		}
+	}
+}
+
+// WithRetry sets how list queries retry a page that fails transiently
+// (network errors, rate limiting, 5xx responses)
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
 // WithTimeout sets the HTTP client timeout
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
@@ -66,14 +160,309 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithPartialOK makes Query and Mutate accept partial responses: when the
+// API returns data alongside field-level errors, the data is decoded and
+// warn is called with the *QueryErrors instead of failing the request. List
+// queries likewise return the pages fetched before a page failed for good,
+// calling warn with an *IncompleteError.
+func WithPartialOK(warn func(error)) Option {
+	return func(c *Client) {
+		c.partialWarn = warn
+	}
+}
+
+// WithOffline makes the client fail every request with ErrOffline instead
+// of sending it, so a command can only use data it has cached
+func WithOffline() Option {
+	return func(c *Client) {
+		c.offline = true
+	}
+}
+
+// WithLogger sets where requests are logged (at debug level)
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// UserAgent returns the User-Agent header sent with every request
+func (c *Client) UserAgent() string {
+	userAgent := "lirt/" + c.version
+	if c.userAgent != "" {
+		userAgent += " " + c.userAgent
+	}
+	return userAgent
+}
+
 // Query executes a GraphQL query
 func (c *Client) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
-	return c.graphql.Query(ctx, q, variables)
+	if c.offline {
+		return ErrOffline
+	}
+	start := time.Now()
+	err := c.acceptPartial(q, c.wrapError(c.graphql.Query(ctx, q, variables)))
+	c.logRequest("query", q, start, err)
+	return err
 }
 
 // Mutate executes a GraphQL mutation
 func (c *Client) Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error {
-	return c.graphql.Mutate(ctx, m, variables)
+	if c.offline {
+		return ErrOffline
+	}
+	start := time.Now()
+	err := c.acceptPartial(m, c.wrapError(c.graphql.Mutate(ctx, m, variables)))
+	c.logRequest("mutation", m, start, err)
+	return err
+}
+
+// logRequest records a finished request, named by its Go type (e.g.
+// IssuesQuery), with its duration and any error
+func (c *Client) logRequest(kind string, v interface{}, start time.Time, err error) {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", v), "*client.")
+	attrs := []any{"kind", kind, "operation", name, "duration", time.Since(start).Round(time.Millisecond)}
+	if err != nil {
+		c.logger.Debug("graphql request failed", append(attrs, "error", err)...)
+		return
+	}
+	c.logger.Debug("graphql request", attrs...)
+}
+
+// acceptPartial turns a partial response into success when WithPartialOK is
+// set. The GraphQL client has already decoded any data in a 200 response
+// into v; data carried by an error response body is decoded here.
+func (c *Client) acceptPartial(v interface{}, err error) error {
+	if err == nil || c.partialWarn == nil {
+		return err
+	}
+
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return err
+	}
+
+	data, queryErr := parseQueryErrors(err)
+	if queryErr == nil || !queryErr.Partial() {
+		return err
+	}
+	if len(data) > 0 {
+		if jsonutil.UnmarshalGraphQL(data, v) != nil {
+			return err
+		}
+	}
+
+	c.partialWarn(queryErr)
+	return nil
+}
+
+// Exec runs a raw GraphQL document and returns the response data as JSON.
+// operationName selects which operation to run when the document defines
+// several; it may be empty for single-operation documents.
+func (c *Client) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) (json.RawMessage, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+	var options []graphql.Option
+	if operationName != "" {
+		options = append(options, graphql.OperationName(operationName))
+	}
+
+	data, err := c.graphql.ExecRaw(ctx, query, variables, options...)
+	if err = c.wrapError(err); err == nil {
+		return data, nil
+	}
+
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return nil, err
+	}
+	if partial, queryErr := parseQueryErrors(err); queryErr != nil {
+		if len(data) == 0 {
+			data = partial
+		}
+		return data, queryErr
+	}
+	return data, err
+}
+
+// GraphQLError is one entry of the errors array in a GraphQL response
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []ErrorLocation        `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// ErrorLocation is a position in the query document
+type ErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// QueryErrors is returned by Exec when the API answers with GraphQL errors
+// (e.g. query validation failures). Any partial data is still returned.
+type QueryErrors struct {
+	Errors []GraphQLError
+}
+
+func (e *QueryErrors) Error() string {
+	if len(e.Errors) == 1 {
+		return "query returned an error: " + e.Errors[0].Message
+	}
+	return fmt.Sprintf("query returned %d errors", len(e.Errors))
+}
+
+// Partial reports whether the errors are all field-level execution errors.
+// Such errors carry the path of the failed field, and the rest of the
+// response data is still usable; request-level errors (e.g. validation
+// failures) have no path and no data.
+func (e *QueryErrors) Partial() bool {
+	if len(e.Errors) == 0 {
+		return false
+	}
+	for _, gqlErr := range e.Errors {
+		if len(gqlErr.Path) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Details renders every error with its location, path, and extensions
+func (e *QueryErrors) Details() string {
+	var b strings.Builder
+	for i, gqlErr := range e.Errors {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "GraphQL error: %s\n", gqlErr.Message)
+		for _, loc := range gqlErr.Locations {
+			fmt.Fprintf(&b, "  at line %d, column %d\n", loc.Line, loc.Column)
+		}
+		if len(gqlErr.Path) > 0 {
+			parts := make([]string, len(gqlErr.Path))
+			for j, p := range gqlErr.Path {
+				parts[j] = fmt.Sprint(p)
+			}
+			fmt.Fprintf(&b, "  path: %s\n", strings.Join(parts, "."))
+		}
+		if len(gqlErr.Extensions) > 0 {
+			if ext, err := json.Marshal(gqlErr.Extensions); err == nil {
+				fmt.Fprintf(&b, "  extensions: %s\n", ext)
+			}
+		}
+	}
+	return b.String()
+}
+
+// parseQueryErrors extracts GraphQL errors from a client error. Errors in a
+// 200 response are decoded by the GraphQL client; validation failures arrive
+// as an HTTP 400 whose body carries the errors array (and possibly data).
+// Returns nil if err is a transport failure rather than GraphQL errors.
+func parseQueryErrors(err error) (json.RawMessage, *QueryErrors) {
+	var gqlErrs graphql.Errors
+	if !errors.As(err, &gqlErrs) {
+		return nil, nil
+	}
+
+	var netErr graphql.NetworkError
+	if errors.As(err, &netErr) {
+		var body struct {
+			Data   json.RawMessage `json:"data"`
+			Errors []GraphQLError  `json:"errors"`
+		}
+		if json.Unmarshal([]byte(netErr.Body()), &body) != nil || len(body.Errors) == 0 {
+			return nil, nil
+		}
+		return body.Data, &QueryErrors{Errors: body.Errors}
+	}
+
+	queryErr := &QueryErrors{}
+	for _, e := range gqlErrs {
+		if e.Unwrap() != nil {
+			// Internal client error (request, encode, decode), not from the API
+			return nil, nil
+		}
+		gqlErr := GraphQLError{
+			Message:    e.Message,
+			Path:       e.Path,
+			Extensions: e.Extensions,
+		}
+		for _, loc := range e.Locations {
+			gqlErr.Locations = append(gqlErr.Locations, ErrorLocation{Line: loc.Line, Column: loc.Column})
+		}
+		queryErr.Errors = append(queryErr.Errors, gqlErr)
+	}
+	return nil, queryErr
+}
+
+// wrapError converts HTTP 401/403 transport errors into an AuthError naming
+// the active profile; other errors are returned unchanged
+func (c *Client) wrapError(err error) error {
+	var netErr graphql.NetworkError
+	if errors.As(err, &netErr) {
+		switch netErr.StatusCode() {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return &AuthError{Profile: c.profile, StatusCode: netErr.StatusCode()}
+		}
+	}
+	return err
+}
+
+// PingResult describes a round trip to the API
+type PingResult struct {
+	Latency            time.Duration
+	RateLimitLimit     int // -1 if the API did not report it
+	RateLimitRemaining int // -1 if the API did not report it
+}
+
+// Ping sends a minimal authenticated query and reports latency and the
+// request rate limit headers. HTTP 401/403 is returned as an AuthError.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, LinearAPIEndpoint,
+		strings.NewReader(`{"query":"{ viewer { id } }"}`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("User-Agent", c.UserAgent())
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	latency := time.Since(start)
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, &AuthError{Profile: c.profile, StatusCode: resp.StatusCode}
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return &PingResult{
+		Latency:            latency,
+		RateLimitLimit:     headerInt(resp.Header, "X-RateLimit-Requests-Limit"),
+		RateLimitRemaining: headerInt(resp.Header, "X-RateLimit-Requests-Remaining"),
+	}, nil
+}
+
+// headerInt parses an integer response header, returning -1 if absent
+func headerInt(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return value
 }
 
 // GetAPIKey returns the configured API key
@@ -88,3 +477,9 @@ func MaskAPIKey(key string) string {
 	}
 	return key[:12] + "..."
 }
+
+// IsUUID reports whether s is a canonical UUID (e.g. an entity ID rather than
+// an identifier like ENG-123 or a team key)
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
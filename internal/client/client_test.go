@@ -1,6 +1,10 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -130,3 +134,494 @@ func TestMaskAPIKeyNoFullKeyExposure(t *testing.T) {
 		})
 	}
 }
+
+// TestIsUUID verifies that entity IDs are distinguished from issue
+// identifiers and team keys.
+func TestIsUUID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{
+			name:     "Lowercase UUID",
+			input:    "a1b2c3d4-e5f6-7890-abcd-ef1234567890",
+			expected: true,
+		},
+		{
+			name:     "Uppercase UUID",
+			input:    "A1B2C3D4-E5F6-7890-ABCD-EF1234567890",
+			expected: true,
+		},
+		{
+			name:     "Issue identifier",
+			input:    "ENG-123",
+			expected: false,
+		},
+		{
+			name:     "Short team key",
+			input:    "ENG",
+			expected: false,
+		},
+		{
+			name:     "Team key longer than 20 chars",
+			input:    "PLATFORMINFRASTRUCTURE",
+			expected: false,
+		},
+		{
+			name:     "UUID without dashes",
+			input:    "a1b2c3d4e5f67890abcdef1234567890",
+			expected: false,
+		},
+		{
+			name:     "UUID with non-hex characters",
+			input:    "g1b2c3d4-e5f6-7890-abcd-ef1234567890",
+			expected: false,
+		},
+		{
+			name:     "UUID with surrounding text",
+			input:    "xa1b2c3d4-e5f6-7890-abcd-ef1234567890",
+			expected: false,
+		},
+		{
+			name:     "Empty string",
+			input:    "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUUID(tt.input); got != tt.expected {
+				t.Errorf("IsUUID(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// statusTransport is an http.RoundTripper that answers every request with a
+// fixed status code
+type statusTransport int
+
+func (s statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: int(s),
+		Status:     http.StatusText(int(s)),
+		Body:       io.NopCloser(strings.NewReader(`{"errors":[{"message":"denied"}]}`)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+// TestAuthErrorWrapping verifies that 401/403 responses are converted into an
+// AuthError naming the active profile, and other failures are not.
+func TestAuthErrorWrapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		profile   string
+		wantAuth  bool
+		wantInMsg string
+	}{
+		{
+			name:      "Unauthorized",
+			status:    http.StatusUnauthorized,
+			profile:   "work",
+			wantAuth:  true,
+			wantInMsg: "lirt auth login --profile work",
+		},
+		{
+			name:      "Forbidden on default profile",
+			status:    http.StatusForbidden,
+			profile:   "default",
+			wantAuth:  true,
+			wantInMsg: "run 'lirt auth login'",
+		},
+		{
+			name:     "Server error",
+			status:   http.StatusInternalServerError,
+			profile:  "work",
+			wantAuth: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New("lin_api_test", WithProfile(tt.profile),
+				WithHTTPClient(&http.Client{Transport: statusTransport(tt.status)}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var q struct {
+				Viewer struct {
+					ID string
+				}
+			}
+			err = c.Query(context.Background(), &q, nil)
+			if err == nil {
+				t.Fatal("Query() expected error, got nil")
+			}
+
+			var authErr *AuthError
+			if got := errors.As(err, &authErr); got != tt.wantAuth {
+				t.Fatalf("errors.As(AuthError) = %v, want %v (err: %v)", got, tt.wantAuth, err)
+			}
+			if tt.wantAuth {
+				if authErr.Profile != tt.profile {
+					t.Errorf("Profile = %q, want %q", authErr.Profile, tt.profile)
+				}
+				if !strings.Contains(err.Error(), tt.wantInMsg) {
+					t.Errorf("error %q does not contain %q", err.Error(), tt.wantInMsg)
+				}
+			}
+		})
+	}
+}
+
+// headerTransport answers every request with 200 and the given headers
+type headerTransport map[string]string
+
+func (h headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header := make(http.Header)
+	for k, v := range h {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(strings.NewReader(`{"data":{"viewer":{"id":"u1"}}}`)),
+		Header:     header,
+		Request:    req,
+	}, nil
+}
+
+// TestPing verifies that Ping reports rate limit headers, using -1 when the
+// API omits them, and maps auth failures to AuthError.
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name          string
+		transport     http.RoundTripper
+		wantLimit     int
+		wantRemaining int
+		wantAuthErr   bool
+	}{
+		{
+			name: "Rate limit headers",
+			transport: headerTransport{
+				"X-RateLimit-Requests-Limit":     "1500",
+				"X-RateLimit-Requests-Remaining": "1499",
+			},
+			wantLimit:     1500,
+			wantRemaining: 1499,
+		},
+		{
+			name:          "Headers missing",
+			transport:     headerTransport{},
+			wantLimit:     -1,
+			wantRemaining: -1,
+		},
+		{
+			name:        "Unauthorized",
+			transport:   statusTransport(http.StatusUnauthorized),
+			wantAuthErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: tt.transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := c.Ping(context.Background())
+			if tt.wantAuthErr {
+				var authErr *AuthError
+				if !errors.As(err, &authErr) {
+					t.Fatalf("Ping() error = %v, want AuthError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Ping() error = %v", err)
+			}
+			if result.RateLimitLimit != tt.wantLimit || result.RateLimitRemaining != tt.wantRemaining {
+				t.Errorf("Ping() rate limit = %d/%d, want %d/%d",
+					result.RateLimitRemaining, result.RateLimitLimit, tt.wantRemaining, tt.wantLimit)
+			}
+		})
+	}
+}
+
+// bodyTransport answers every request with a fixed status and JSON body
+type bodyTransport struct {
+	status int
+	body   string
+}
+
+func (b bodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: b.status,
+		Status:     http.StatusText(b.status),
+		Body:       io.NopCloser(strings.NewReader(b.body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+// TestExecQueryErrors verifies that GraphQL errors from both 200 and 400
+// responses surface as QueryErrors with location and path details, that
+// partial data is kept, and that transport failures are not misreported.
+func TestExecQueryErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		transport   bodyTransport
+		wantData    string
+		wantErrs    int
+		wantDetails []string
+	}{
+		{
+			name: "Partial data with errors",
+			transport: bodyTransport{http.StatusOK,
+				`{"data":{"viewer":{"id":"u1"},"issue":null},"errors":[{"message":"Entity not found","path":["issue"],"extensions":{"code":"NOT_FOUND"}}]}`},
+			wantData:    `{"viewer":{"id":"u1"},"issue":null}`,
+			wantErrs:    1,
+			wantDetails: []string{"GraphQL error: Entity not found", "path: issue", `extensions: {"code":"NOT_FOUND"}`},
+		},
+		{
+			name: "Validation failure on 400",
+			transport: bodyTransport{http.StatusBadRequest,
+				`{"errors":[{"message":"Cannot query field \"foo\" on type \"Issue\".","locations":[{"line":1,"column":17}]},{"message":"Unknown argument"}]}`},
+			wantErrs:    2,
+			wantDetails: []string{`Cannot query field "foo"`, "at line 1, column 17", "GraphQL error: Unknown argument"},
+		},
+		{
+			name:      "Server error without errors array",
+			transport: bodyTransport{http.StatusBadGateway, `<html>bad gateway</html>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: tt.transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			data, err := c.Exec(context.Background(), "{ viewer { id } }", "", nil)
+			if err == nil {
+				t.Fatal("Exec() expected error, got nil")
+			}
+
+			var queryErr *QueryErrors
+			if !errors.As(err, &queryErr) {
+				if tt.wantErrs > 0 {
+					t.Fatalf("Exec() error = %v, want QueryErrors", err)
+				}
+				return
+			}
+			if tt.wantErrs == 0 {
+				t.Fatalf("Exec() error = %v, want transport error", err)
+			}
+
+			if len(queryErr.Errors) != tt.wantErrs {
+				t.Errorf("len(Errors) = %d, want %d", len(queryErr.Errors), tt.wantErrs)
+			}
+			if tt.wantData != "" && string(data) != tt.wantData {
+				t.Errorf("Exec() data = %s, want %s", data, tt.wantData)
+			}
+			details := queryErr.Details()
+			for _, want := range tt.wantDetails {
+				if !strings.Contains(details, want) {
+					t.Errorf("Details() = %q, missing %q", details, want)
+				}
+			}
+		})
+	}
+}
+
+// TestQueryPartialOK verifies that WithPartialOK returns decoded data and a
+// warning for field-level errors, while request-level errors, transport
+// failures, and clients without the option still fail.
+func TestQueryPartialOK(t *testing.T) {
+	partialBody := `{"data":{"viewer":{"id":"u1","name":null}},"errors":[{"message":"Forbidden","path":["viewer","name"]}]}`
+
+	tests := []struct {
+		name      string
+		transport bodyTransport
+		partialOK bool
+		wantErr   bool
+		wantID    string
+	}{
+		{
+			name:      "Partial response accepted",
+			transport: bodyTransport{http.StatusOK, partialBody},
+			partialOK: true,
+			wantID:    "u1",
+		},
+		{
+			name: "Partial response on 400 accepted",
+			transport: bodyTransport{http.StatusBadRequest,
+				`{"data":{"viewer":{"id":"u1"}},"errors":[{"message":"Forbidden","path":["viewer","name"]}]}`},
+			partialOK: true,
+			wantID:    "u1",
+		},
+		{
+			name:      "Partial response fails without option",
+			transport: bodyTransport{http.StatusOK, partialBody},
+			wantErr:   true,
+		},
+		{
+			name: "Validation failure still fails",
+			transport: bodyTransport{http.StatusBadRequest,
+				`{"errors":[{"message":"Cannot query field \"foo\" on type \"User\"."}]}`},
+			partialOK: true,
+			wantErr:   true,
+		},
+		{
+			name:      "Transport failure still fails",
+			transport: bodyTransport{http.StatusBadGateway, `<html>bad gateway</html>`},
+			partialOK: true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warned *QueryErrors
+			opts := []Option{WithHTTPClient(&http.Client{Transport: tt.transport})}
+			if tt.partialOK {
+				opts = append(opts, WithPartialOK(func(e error) { warned, _ = e.(*QueryErrors) }))
+			}
+			c, err := New("lin_api_test", opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var query struct {
+				Viewer struct {
+					ID   string `graphql:"id"`
+					Name string `graphql:"name"`
+				} `graphql:"viewer"`
+			}
+			err = c.Query(context.Background(), &query, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Query() expected error, got nil")
+				}
+				if warned != nil {
+					t.Error("Query() warned about a response it rejected")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if query.Viewer.ID != tt.wantID {
+				t.Errorf("viewer.id = %q, want %q", query.Viewer.ID, tt.wantID)
+			}
+			if warned == nil || len(warned.Errors) != 1 {
+				t.Errorf("warning = %v, want the field error", warned)
+			}
+		})
+	}
+}
+
+// userAgentTransport records the User-Agent of each request it answers
+type userAgentTransport struct {
+	seen *[]string
+}
+
+func (u userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*u.seen = append(*u.seen, req.Header.Get("User-Agent"))
+	return bodyTransport{http.StatusOK, `{"data":{"viewer":{"id":"u1"}}}`}.RoundTrip(req)
+}
+
+// TestUserAgent verifies the User-Agent carries the lirt version and any
+// suffix, on both GraphQL requests and Ping.
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{name: "Default", expected: "lirt/dev"},
+		{name: "Version", opts: []Option{WithVersion("1.4.0")}, expected: "lirt/1.4.0"},
+		{name: "Version and suffix", opts: []Option{WithVersion("1.4.0"), WithUserAgent("my-bot/2.0")}, expected: "lirt/1.4.0 my-bot/2.0"},
+		{name: "Blank suffix ignored", opts: []Option{WithVersion("1.4.0"), WithUserAgent("  ")}, expected: "lirt/1.4.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			opts := append([]Option{WithHTTPClient(&http.Client{Transport: userAgentTransport{&seen}})}, tt.opts...)
+			c, err := New("lin_api_test", opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var query struct {
+				Viewer struct {
+					ID string `graphql:"id"`
+				} `graphql:"viewer"`
+			}
+			if err := c.Query(context.Background(), &query, nil); err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if _, err := c.Ping(context.Background()); err != nil {
+				t.Fatalf("Ping() error = %v", err)
+			}
+
+			for _, got := range seen {
+				if got != tt.expected {
+					t.Errorf("User-Agent = %q, want %q", got, tt.expected)
+				}
+			}
+			if len(seen) != 2 {
+				t.Errorf("saw %d requests, want 2", len(seen))
+			}
+		})
+	}
+}
+
+// TestOffline verifies a client created WithOffline fails every kind of
+// request with ErrOffline without sending anything, and that list queries
+// do not retry it.
+func TestOffline(t *testing.T) {
+	var seen []string
+	c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: requestTransport{&seen, `{"data":{}}`}}), WithOffline())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var query struct {
+		Viewer struct {
+			ID string `graphql:"id"`
+		} `graphql:"viewer"`
+	}
+	if err := c.Query(ctx, &query, nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Query() error = %v, want ErrOffline", err)
+	}
+	if err := c.Mutate(ctx, &query, nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Mutate() error = %v, want ErrOffline", err)
+	}
+	if _, err := c.Exec(ctx, "{ viewer { id } }", "", nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Exec() error = %v, want ErrOffline", err)
+	}
+	if _, err := c.Ping(ctx); !errors.Is(err, ErrOffline) {
+		t.Errorf("Ping() error = %v, want ErrOffline", err)
+	}
+	if _, err := c.ListTeams(ctx); !errors.Is(err, ErrOffline) {
+		t.Errorf("ListTeams() error = %v, want ErrOffline", err)
+	}
+	if isRetryable(ErrOffline) {
+		t.Error("isRetryable(ErrOffline) = true, want false")
+	}
+
+	if len(seen) != 0 {
+		t.Errorf("sent %d requests, want none", len(seen))
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+// Filter variables are maps shaped like Linear's filter input objects. The
+// GraphQL client cannot infer a type from a map, so each filter is a named
+// type declaring its Linear type. Queries always pass the filter as a
+// pointer, nil for no filter, so the variable is declared (as nullable) even
+// when it is null.
+
+// ProjectFilter is the filter variable of the projects query
+type ProjectFilter map[string]interface{}
+
+// GetGraphQLType declares the variable as Linear's ProjectFilter
+func (*ProjectFilter) GetGraphQLType() string { return "ProjectFilter" }
+
+// ProjectMilestoneFilter is the filter variable of the milestones query
+type ProjectMilestoneFilter map[string]interface{}
+
+// GetGraphQLType declares the variable as Linear's ProjectMilestoneFilter
+func (*ProjectMilestoneFilter) GetGraphQLType() string { return "ProjectMilestoneFilter" }
+
+// IssueFilter is the filter variable of the issue connections: issues and a
+// user's assignedIssues and createdIssues
+type IssueFilter map[string]interface{}
+
+// GetGraphQLType declares the variable as Linear's IssueFilter
+func (*IssueFilter) GetGraphQLType() string { return "IssueFilter" }
+
+// issueFilter returns the $filter variable for filters, nil when they
+// filter nothing
+func issueFilter(filters *IssueFilters) *IssueFilter {
+	filter := IssueFilter(buildIssueFilter(filters))
+	if len(filter) == 0 {
+		return nil
+	}
+	return &filter
+}
+
+// CommentFilter is the filter variable of the comments query
+type CommentFilter map[string]interface{}
+
+// GetGraphQLType declares the variable as Linear's CommentFilter
+func (*CommentFilter) GetGraphQLType() string { return "CommentFilter" }
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// MentionMarkup returns the markup Linear renders as a mention of user,
+// @[name](user-id), which notifies the user like a mention made in the web
+// app. The name is the user's display name, else their full name.
+func MentionMarkup(user *model.User) string {
+	name := user.DisplayName
+	if name == "" {
+		name = user.Name
+	}
+	return fmt.Sprintf("@[%s](%s)", name, user.ID)
+}
+
+// InsertMention replaces each plain-text @ref in body (case-insensitive,
+// as a whole name) with the mention markup for user. If body has no @ref
+// the mention is put in front of it, so comment add --mention alice
+// --body "can you look?" still mentions alice.
+func InsertMention(body, ref string, user *model.User) string {
+	markup := MentionMarkup(user)
+	ref = strings.TrimPrefix(ref, "@")
+
+	// An @ preceded by a word character is part of an email address, and one
+	// after [ is already inside mention markup
+	pattern := regexp.MustCompile(`(?i)(^|[^\w\[])@` + regexp.QuoteMeta(ref))
+
+	var b strings.Builder
+	last, found := 0, false
+	for _, m := range pattern.FindAllStringSubmatchIndex(body, -1) {
+		if !endsName(body[m[1]:]) {
+			continue
+		}
+		b.WriteString(body[last:m[3]])
+		b.WriteString(markup)
+		last, found = m[1], true
+	}
+	if !found {
+		return markup + " " + body
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
+
+// endsName reports whether rest, the text following an @name, ends the
+// name. Names run on through word characters and through a . or - between
+// them, so @alice does not match @alice.smith or @alice-bob, while a
+// sentence may still end right after a mention ("thanks @alice.").
+func endsName(rest string) bool {
+	isWord := func(c byte) bool {
+		return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+	switch {
+	case rest == "":
+		return true
+	case isWord(rest[0]):
+		return false
+	case rest[0] == '.' || rest[0] == '-':
+		return len(rest) == 1 || !isWord(rest[1])
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+func TestInsertMention(t *testing.T) {
+	alice := &model.User{ID: "u-1", Name: "Alice Smith", DisplayName: "alice"}
+	bob := &model.User{ID: "u-2", Name: "Bob Jones"}
+
+	tests := []struct {
+		name string
+		body string
+		ref  string
+		user *model.User
+		want string
+	}{
+		{name: "Replaces @ref", body: "@alice can you look?", ref: "alice", user: alice, want: "@[alice](u-1) can you look?"},
+		{name: "Case-insensitive, every occurrence", body: "cc @Alice and again @alice.", ref: "alice", user: alice, want: "cc @[alice](u-1) and again @[alice](u-1)."},
+		{name: "Leading @ in ref", body: "thanks @bob", ref: "@bob", user: bob, want: "thanks @[Bob Jones](u-2)"},
+		{name: "Prepended when absent", body: "can you look?", ref: "bob", user: bob, want: "@[Bob Jones](u-2) can you look?"},
+		{name: "Whole word only", body: "@alicesmith here", ref: "alice", user: alice, want: "@[alice](u-1) @alicesmith here"},
+		{name: "Dotted name untouched", body: "ask @alice.smith or @alice-bob", ref: "alice", user: alice, want: "@[alice](u-1) ask @alice.smith or @alice-bob"},
+		{name: "Adjacent mentions", body: "@alice @alice, @alice- done", ref: "alice", user: alice, want: "@[alice](u-1) @[alice](u-1), @[alice](u-1)- done"},
+		{name: "Email untouched", body: "mail bob@alice.com", ref: "alice", user: alice, want: "@[alice](u-1) mail bob@alice.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InsertMention(tt.body, tt.ref, tt.user); got != tt.want {
+				t.Errorf("InsertMention() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Several mentions are inserted one after another without disturbing
+	// markup already inserted
+	body := InsertMention("@alice @bob please review", "alice", alice)
+	body = InsertMention(body, "bob", bob)
+	if want := "@[alice](u-1) @[Bob Jones](u-2) please review"; body != want {
+		t.Errorf("two mentions = %q, want %q", body, want)
+	}
+}
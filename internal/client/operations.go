@@ -0,0 +1,62 @@
+package client
+
+import "regexp"
+
+// operationPattern matches the start of a named operation definition
+var operationPattern = regexp.MustCompile(`^(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// OperationNames returns the names of the operations defined in a GraphQL
+// document, in order. Anonymous operations and fragments are skipped; only
+// top-level definitions are considered, so a field named "query" inside a
+// selection set is not mistaken for an operation.
+func OperationNames(document string) []string {
+	names := []string{}
+	depth := 0
+	inString := false
+
+	for i := 0; i < len(document); i++ {
+		ch := document[i]
+
+		switch {
+		case inString:
+			if ch == '\\' {
+				i++
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '#':
+			// Skip comment to end of line
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+		case depth == 0 && (i == 0 || !isNameChar(document[i-1])):
+			if m := operationPattern.FindStringSubmatch(document[i:]); m != nil {
+				names = append(names, m[2])
+				i += len(m[0]) - 1
+			}
+		}
+	}
+
+	return names
+}
+
+// isNameChar reports whether ch can appear in a GraphQL name
+func isNameChar(ch byte) bool {
+	return ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// HasOperation reports whether the document defines the named operation
+func HasOperation(document, name string) bool {
+	for _, op := range OperationNames(document) {
+		if op == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestOperationNames verifies that only top-level named operations are
+// reported, ignoring anonymous operations, fragments, comments, strings,
+// and fields that happen to be called "query".
+func TestOperationNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{
+			name:     "Anonymous query",
+			document: `{ viewer { id } }`,
+			expected: []string{},
+		},
+		{
+			name: "Multiple operations",
+			document: `query GetIssue($id: String!) { issue(id: $id) { title } }
+
+mutation CloseIssue($id: String!) { issueUpdate(id: $id, input: {}) { success } }`,
+			expected: []string{"GetIssue", "CloseIssue"},
+		},
+		{
+			name: "Comments and fragments skipped",
+			document: `# query Commented { viewer { id } }
+fragment IssueFields on Issue { id title }
+query Viewer { viewer { id } }`,
+			expected: []string{"Viewer"},
+		},
+		{
+			name:     "Nested field named query",
+			document: `query Search { search(query: "query Fake") { query { id } } }`,
+			expected: []string{"Search"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OperationNames(tt.document); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("OperationNames() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
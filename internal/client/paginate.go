@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	graphql "github.com/hasura/go-graphql-client"
+)
+
+// DefaultPageSize is the number of nodes requested per page
+const DefaultPageSize = 50
+
+// RetryPolicy controls how a page that fails transiently is retried
+type RetryPolicy struct {
+	Attempts  int           // Retries after the first failure; 0 disables retrying
+	BaseDelay time.Duration // Wait before the first retry; each further retry waits one more multiple of it
+	MaxDelay  time.Duration // Longest single wait; 0 means no cap
+}
+
+// DefaultRetryPolicy retries a page three times, waiting 0.5s, 1s, then 1.5s
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// delay returns the wait before retry number attempt, counting from 0
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := time.Duration(attempt+1) * p.BaseDelay
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// IncompleteError is returned when pagination stopped early because a page
+// kept failing after retries. Fetched results were collected before the
+// failure and are returned with the error under WithPartialOK.
+type IncompleteError struct {
+	Fetched int
+	Err     error
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("results incomplete, stopped after %d: %v", e.Fetched, e.Err)
+}
+
+func (e *IncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// isRetryable reports whether a page request failed for a transient reason
+// worth retrying: a network error, a truncated response, rate limiting, or
+// a 5xx from the API. Authentication and query errors are not retried.
+func isRetryable(err error) bool {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return false
+	}
+
+	if _, queryErr := parseQueryErrors(err); queryErr != nil {
+		for _, gqlErr := range queryErr.Errors {
+			if gqlErr.Extensions["code"] == "RATELIMITED" {
+				return true
+			}
+		}
+	}
+
+	var netErr graphql.NetworkError
+	if errors.As(err, &netErr) {
+		return netErr.StatusCode() == http.StatusTooManyRequests || netErr.StatusCode() >= 500
+	}
+
+	var transportErr net.Error
+	if errors.As(err, &transportErr) {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	return false
+}
+
+// limitKey is the context key carrying a result limit
+type limitKey struct{}
+
+// WithLimit returns a context that caps list queries made with it at limit
+// results. A limit of 0 means no cap (fetch every page).
+func WithLimit(ctx context.Context, limit int) context.Context {
+	return context.WithValue(ctx, limitKey{}, limit)
+}
+
+// limitFrom returns the result limit carried by ctx, or 0 if none
+func limitFrom(ctx context.Context) int {
+	if limit, ok := ctx.Value(limitKey{}).(int); ok && limit > 0 {
+		return limit
+	}
+	return 0
+}
+
+// collectPages gathers nodes across cursor pages until the connection is
+// exhausted or limit nodes have been collected (0 = all). The page size is
+// shrunk on the last request so no more than limit nodes are fetched. It
+// stops with ctx's error as soon as ctx is canceled. A page that fails
+// transiently is retried from the same cursor under retry; if it still
+// fails, the nodes collected so far are returned with an IncompleteError.
+func collectPages[T any](ctx context.Context, pageSize, limit int, retry RetryPolicy, fetch func(first int, after *string) ([]T, pageInfo, error)) ([]T, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	var after *string
+	items := []T{}
+
+	for {
+		first := pageSize
+		if limit > 0 && limit-len(items) < first {
+			first = limit - len(items)
+		}
+
+		nodes, page, err := fetchPage(ctx, first, after, retry, fetch)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err != nil {
+			if isRetryable(err) && len(items) > 0 {
+				return items, &IncompleteError{Fetched: len(items), Err: err}
+			}
+			return nil, err
+		}
+		items = append(items, nodes...)
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		if !page.HasNextPage || page.EndCursor == "" {
+			return items, nil
+		}
+		cursor := page.EndCursor
+		after = &cursor
+	}
+}
+
+// fetchPage requests one page, retrying transient failures with a growing
+// delay. The cursor is unchanged between attempts, so a retry resumes where
+// the failed request left off.
+func fetchPage[T any](ctx context.Context, first int, after *string, retry RetryPolicy, fetch func(first int, after *string) ([]T, pageInfo, error)) ([]T, pageInfo, error) {
+	for attempt := 0; ; attempt++ {
+		nodes, page, err := fetch(first, after)
+		if err == nil || attempt >= retry.Attempts || !isRetryable(err) || ctx.Err() != nil {
+			return nodes, page, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, pageInfo{}, ctx.Err()
+		case <-time.After(retry.delay(attempt)):
+		}
+	}
+}
+
+// pages runs collectPages with the client's page size and retry policy and
+// the limit from ctx. Under WithPartialOK, results cut short by a failing page are
+// returned after a warning instead of failing.
+func pages[T any](ctx context.Context, c *Client, fetch func(first int, after *string) ([]T, pageInfo, error)) ([]T, error) {
+	items, err := collectPages(ctx, c.pageSize, limitFrom(ctx), c.retry, fetch)
+
+	var incomplete *IncompleteError
+	if errors.As(err, &incomplete) {
+		if c.partialWarn == nil {
+			return nil, incomplete.Err
+		}
+		c.partialWarn(incomplete)
+		return items, nil
+	}
+	return items, err
+}
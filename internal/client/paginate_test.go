@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeConnection serves total sequential nodes in cursor pages, recording
+// the page size requested by each call
+type fakeConnection struct {
+	total    int
+	requests []int
+}
+
+func (f *fakeConnection) fetch(first int, after *string) ([]int, pageInfo, error) {
+	f.requests = append(f.requests, first)
+
+	start := 0
+	if after != nil {
+		if _, err := fmt.Sscanf(*after, "%d", &start); err != nil {
+			return nil, pageInfo{}, err
+		}
+	}
+
+	end := start + first
+	if end > f.total {
+		end = f.total
+	}
+
+	nodes := []int{}
+	for i := start; i < end; i++ {
+		nodes = append(nodes, i)
+	}
+
+	return nodes, pageInfo{HasNextPage: end < f.total, EndCursor: fmt.Sprint(end)}, nil
+}
+
+// TestCollectPages verifies that a limit stops collection after exactly that
+// many nodes regardless of page boundaries, and that no limit fetches all.
+func TestCollectPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		pageSize     int
+		limit        int
+		wantCount    int
+		wantRequests []int
+	}{
+		{
+			name:         "Limit spans pages",
+			total:        100,
+			pageSize:     4,
+			limit:        10,
+			wantCount:    10,
+			wantRequests: []int{4, 4, 2},
+		},
+		{
+			name:         "Limit on page boundary",
+			total:        100,
+			pageSize:     5,
+			limit:        10,
+			wantCount:    10,
+			wantRequests: []int{5, 5},
+		},
+		{
+			name:         "Limit smaller than page",
+			total:        100,
+			pageSize:     50,
+			limit:        10,
+			wantCount:    10,
+			wantRequests: []int{10},
+		},
+		{
+			name:         "Limit beyond total",
+			total:        7,
+			pageSize:     5,
+			limit:        10,
+			wantCount:    7,
+			wantRequests: []int{5, 5},
+		},
+		{
+			name:         "No limit fetches all",
+			total:        12,
+			pageSize:     5,
+			limit:        0,
+			wantCount:    12,
+			wantRequests: []int{5, 5, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeConnection{total: tt.total}
+
+			got, err := collectPages(context.Background(), tt.pageSize, tt.limit, DefaultRetryPolicy, conn.fetch)
+			if err != nil {
+				t.Fatalf("collectPages() error = %v", err)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("collectPages() returned %d nodes, want %d", len(got), tt.wantCount)
+			}
+			for i, node := range got {
+				if node != i {
+					t.Fatalf("collectPages() node %d = %d, want %d", i, node, i)
+				}
+			}
+			if fmt.Sprint(conn.requests) != fmt.Sprint(tt.wantRequests) {
+				t.Errorf("page sizes requested = %v, want %v", conn.requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+// TestCollectPagesCanceled verifies that canceling the context mid-pagination
+// stops collection with context.Canceled instead of fetching further pages
+func TestCollectPagesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn := &fakeConnection{total: 100}
+	fetch := func(first int, after *string) ([]int, pageInfo, error) {
+		if len(conn.requests) == 1 {
+			// Simulate Ctrl-C while the second page is in flight
+			cancel()
+		}
+		return conn.fetch(first, after)
+	}
+
+	got, err := collectPages(ctx, 10, 0, DefaultRetryPolicy, fetch)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("collectPages() error = %v, want context.Canceled", err)
+	}
+	if got != nil {
+		t.Errorf("collectPages() returned %d nodes after cancel, want none", len(got))
+	}
+	if len(conn.requests) != 2 {
+		t.Errorf("collectPages() made %d requests, want 2", len(conn.requests))
+	}
+}
+
+// TestLimitFrom verifies that the limit is carried through the context and
+// that non-positive limits mean no cap.
+func TestLimitFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected int
+	}{
+		{name: "No limit set", ctx: context.Background(), expected: 0},
+		{name: "Positive limit", ctx: WithLimit(context.Background(), 10), expected: 10},
+		{name: "Negative limit", ctx: WithLimit(context.Background(), -1), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitFrom(tt.ctx); got != tt.expected {
+				t.Errorf("limitFrom() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+// flakyTeamsServer serves five teams one per page, answering the request
+// for page 3 with 502 Bad Gateway the first failures times
+type flakyTeamsServer struct {
+	failures int
+	afters   []string
+}
+
+func (f *flakyTeamsServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body struct {
+		Variables struct {
+			After *string `json:"after"`
+		} `json:"variables"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	page := 1
+	after := ""
+	if body.Variables.After != nil {
+		after = *body.Variables.After
+		fmt.Sscanf(after, "c%d", &page)
+		page++
+	}
+	f.afters = append(f.afters, after)
+
+	if page == 3 && f.failures > 0 {
+		f.failures--
+		return bodyTransport{http.StatusBadGateway, `<html>bad gateway</html>`}.RoundTrip(req)
+	}
+	return bodyTransport{http.StatusOK, fmt.Sprintf(
+		`{"data":{"teams":{"nodes":[{"id":"t%d","key":"T%d","name":"Team %d","description":""}],"pageInfo":{"hasNextPage":%t,"endCursor":"c%d"}}}}`,
+		page, page, page, page < 5, page)}.RoundTrip(req)
+}
+
+// TestPagesRetry verifies that a page failing transiently is retried from
+// its cursor without refetching earlier pages, and that when retries run
+// out the pages already fetched are returned only under WithPartialOK.
+func TestPagesRetry(t *testing.T) {
+	maxRetries := DefaultRetryPolicy.Attempts
+
+	tests := []struct {
+		name       string
+		failures   int
+		retries    int // 0 = DefaultRetryPolicy's attempts
+		partialOK  bool
+		wantTeams  int
+		wantErr    bool
+		wantWarned bool
+	}{
+		{name: "Recovers after a failure", failures: 1, wantTeams: 5},
+		{name: "Recovers on the last retry", failures: maxRetries, wantTeams: 5},
+		{name: "Retries exhausted", failures: maxRetries + 1, wantErr: true},
+		{name: "Retries exhausted with partial OK", failures: maxRetries + 1, partialOK: true, wantTeams: 2, wantWarned: true},
+		{name: "More configured retries", failures: maxRetries + 2, retries: maxRetries + 2, wantTeams: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retries := tt.retries
+			if retries == 0 {
+				retries = maxRetries
+			}
+			server := &flakyTeamsServer{failures: tt.failures}
+			var warned error
+			opts := []Option{WithHTTPClient(&http.Client{Transport: server}), WithPageSize(1), WithRetry(RetryPolicy{Attempts: retries})}
+			if tt.partialOK {
+				opts = append(opts, WithPartialOK(func(e error) { warned = e }))
+			}
+			c, err := New("lin_api_test", opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			teams, err := c.ListTeams(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListTeams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(teams) != tt.wantTeams {
+				t.Errorf("ListTeams() returned %d teams, want %d", len(teams), tt.wantTeams)
+			}
+			for i, team := range teams {
+				if want := fmt.Sprintf("t%d", i+1); team.ID != want {
+					t.Errorf("team %d = %s, want %s", i, team.ID, want)
+				}
+			}
+
+			var incomplete *IncompleteError
+			if got := errors.As(warned, &incomplete); got != tt.wantWarned {
+				t.Errorf("warned = %v, want IncompleteError %v", warned, tt.wantWarned)
+			}
+
+			// Pages 1 and 2 are fetched once; only page 3 is repeated
+			for i, after := range server.afters[:2] {
+				if want := []string{"", "c1"}[i]; after != want {
+					t.Errorf("request %d after = %q, want %q", i, after, want)
+				}
+			}
+			for _, after := range server.afters[2 : 2+min(tt.failures, retries)+1] {
+				if after != "c2" {
+					t.Errorf("retry after = %q, want c2", after)
+				}
+			}
+		})
+	}
+}
+
+// TestRetryPolicyDelay verifies that each retry waits one more multiple of
+// the base delay, capped at the maximum delay.
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{name: "Default", policy: DefaultRetryPolicy, want: []time.Duration{500 * time.Millisecond, time.Second, 1500 * time.Millisecond}},
+		{name: "Capped", policy: RetryPolicy{BaseDelay: 2 * time.Second, MaxDelay: 5 * time.Second}, want: []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{name: "No cap", policy: RetryPolicy{BaseDelay: time.Second}, want: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for attempt, want := range tt.want {
+				if got := tt.policy.delay(attempt); got != want {
+					t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestIsRetryable verifies that transport failures, rate limiting, and 5xx
+// responses are retried while auth and query errors are not.
+func TestIsRetryable(t *testing.T) {
+	query := func(status int, body string) error {
+		c, _ := New("lin_api_test", WithHTTPClient(&http.Client{Transport: bodyTransport{status, body}}))
+		var q struct {
+			Viewer struct {
+				ID string `graphql:"id"`
+			} `graphql:"viewer"`
+		}
+		return c.Query(context.Background(), &q, nil)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Bad gateway", err: query(http.StatusBadGateway, "bad gateway"), want: true},
+		{name: "Too many requests", err: query(http.StatusTooManyRequests, "slow down"), want: true},
+		{name: "Rate limited GraphQL error", err: query(http.StatusBadRequest, `{"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`), want: true},
+		{name: "Unauthorized", err: query(http.StatusUnauthorized, "unauthorized"), want: false},
+		{name: "Validation error", err: query(http.StatusBadRequest, `{"errors":[{"message":"Cannot query field"}]}`), want: false},
+		{name: "Connection reset", err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package client
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dixson3/lirt/internal/model"
 )
@@ -11,9 +17,9 @@ import (
 // ViewerQuery represents the GraphQL viewer query
 type ViewerQuery struct {
 	Viewer struct {
-		ID    string `graphql:"id"`
-		Name  string `graphql:"name"`
-		Email string `graphql:"email"`
+		ID           string `graphql:"id"`
+		Name         string `graphql:"name"`
+		Email        string `graphql:"email"`
 		Organization struct {
 			ID     string `graphql:"id"`
 			Name   string `graphql:"name"`
@@ -51,160 +57,649 @@ type TeamsQuery struct {
 			Key         string `graphql:"key"`
 			Name        string `graphql:"name"`
 			Description string `graphql:"description"`
+			Icon        string `graphql:"icon"`
+			Color       string `graphql:"color"`
 		} `graphql:"nodes"`
-	} `graphql:"teams"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"teams(first: $first, after: $after)"`
 }
 
-// ListTeams fetches all teams
+// ListTeams fetches all teams, following pagination up to the ctx limit
 func (c *Client) ListTeams(ctx context.Context) ([]model.Team, error) {
-	var query TeamsQuery
-	if err := c.Query(ctx, &query, nil); err != nil {
+	return pages(ctx, c, func(first int, after *string) ([]model.Team, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
+
+		var query TeamsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		teams := make([]model.Team, 0, len(query.Teams.Nodes))
+		for _, node := range query.Teams.Nodes {
+			teams = append(teams, model.Team{
+				ID:          node.ID,
+				Key:         node.Key,
+				Name:        node.Name,
+				Description: node.Description,
+				Icon:        node.Icon,
+				Color:       node.Color,
+			})
+		}
+
+		return teams, query.Teams.PageInfo, nil
+	})
+}
+
+// Viewer team membership roles
+const (
+	TeamRoleOwner  = "owner"
+	TeamRoleMember = "member"
+)
+
+// TeamMembershipsQuery represents the GraphQL query for the viewer's teams
+type TeamMembershipsQuery struct {
+	Viewer struct {
+		TeamMemberships struct {
+			Nodes []struct {
+				Owner bool `graphql:"owner"`
+				Team  struct {
+					ID string `graphql:"id"`
+				} `graphql:"team"`
+			} `graphql:"nodes"`
+			PageInfo pageInfo `graphql:"pageInfo"`
+		} `graphql:"teamMemberships(first: $first, after: $after)"`
+	} `graphql:"viewer"`
+}
+
+// ListTeamMemberships fetches the viewer's role in each team they belong to,
+// keyed by team ID
+func (c *Client) ListTeamMemberships(ctx context.Context) (map[string]string, error) {
+	memberships, err := pages(ctx, c, func(first int, after *string) ([]teamMembership, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
+
+		var query TeamMembershipsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		memberships := make([]teamMembership, 0, len(query.Viewer.TeamMemberships.Nodes))
+		for _, node := range query.Viewer.TeamMemberships.Nodes {
+			role := TeamRoleMember
+			if node.Owner {
+				role = TeamRoleOwner
+			}
+			memberships = append(memberships, teamMembership{teamID: node.Team.ID, role: role})
+		}
+
+		return memberships, query.Viewer.TeamMemberships.PageInfo, nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	teams := make([]model.Team, 0, len(query.Teams.Nodes))
-	for _, node := range query.Teams.Nodes {
-		teams = append(teams, model.Team{
-			ID:          node.ID,
-			Key:         node.Key,
-			Name:        node.Name,
-			Description: node.Description,
-		})
+	roles := make(map[string]string, len(memberships))
+	for _, m := range memberships {
+		roles[m.teamID] = m.role
+	}
+	return roles, nil
+}
+
+// teamMembership is one page entry of ListTeamMemberships
+type teamMembership struct {
+	teamID string
+	role   string
+}
+
+// ApplyMemberships sets each team's Membership from roles (team ID to role).
+// With mineOnly, teams the viewer does not belong to are dropped.
+func ApplyMemberships(teams []model.Team, roles map[string]string, mineOnly bool) []model.Team {
+	result := make([]model.Team, 0, len(teams))
+	for _, team := range teams {
+		team.Membership = roles[team.ID]
+		if mineOnly && team.Membership == "" {
+			continue
+		}
+		result = append(result, team)
+	}
+	return result
+}
+
+// ResolveTeamIDs maps team keys (case-insensitive) or IDs to team IDs,
+// dropping duplicates and keeping the given order
+func ResolveTeamIDs(teams []model.Team, keysOrIDs []string) ([]string, error) {
+	ids := []string{}
+	seen := make(map[string]bool)
+
+	for _, ref := range keysOrIDs {
+		team, err := FindTeam(teams, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[team.ID] {
+			seen[team.ID] = true
+			ids = append(ids, team.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// maxAvailable caps how many valid values a not-found error lists
+const maxAvailable = 10
+
+// FindTeam returns the team matching an ID or key (case-insensitive). The
+// error for an unknown team lists the available keys.
+func FindTeam(teams []model.Team, ref string) (*model.Team, error) {
+	keys := make([]string, 0, len(teams))
+	for i, team := range teams {
+		if team.ID == ref || strings.EqualFold(team.Key, ref) {
+			return &teams[i], nil
+		}
+		keys = append(keys, team.Key)
+	}
+	return nil, notFound("team", ref, keys)
+}
+
+// FindUser returns the user matching an ID, or an email, name or display
+// name (case-insensitive). The error for an unknown user lists some of the
+// available display names.
+func FindUser(users []model.User, ref string) (*model.User, error) {
+	names := make([]string, 0, len(users))
+	for i, user := range users {
+		if user.ID == ref || strings.EqualFold(user.Email, ref) || strings.EqualFold(user.Name, ref) || strings.EqualFold(user.DisplayName, ref) {
+			return &users[i], nil
+		}
+		name := user.DisplayName
+		if name == "" {
+			name = user.Name
+		}
+		names = append(names, name)
+	}
+	return nil, notFound("user", ref, names)
+}
+
+// notFound builds a "<kind> 'ref' not found (available: ...)" error listing
+// up to maxAvailable of the given values in order
+func notFound(kind, ref string, available []string) error {
+	if len(available) == 0 {
+		return fmt.Errorf("%s '%s' not found", kind, ref)
+	}
+	sorted := append([]string{}, available...)
+	sort.Strings(sorted)
+	list := strings.Join(sorted, ", ")
+	if len(sorted) > maxAvailable {
+		list = strings.Join(sorted[:maxAvailable], ", ") + fmt.Sprintf(", ... (%d more)", len(sorted)-maxAvailable)
+	}
+	return fmt.Errorf("%s '%s' not found (available: %s)", kind, ref, list)
+}
+
+// issueNode is the issue shape shared by list queries. The description is
+// only requested when the query's $withDescription variable is true, since
+// descriptions can dwarf the rest of a list
+type issueNode struct {
+	ID          string `graphql:"id"`
+	Identifier  string `graphql:"identifier"`
+	Title       string `graphql:"title"`
+	Description string `graphql:"description @include(if: $withDescription)"`
+	Priority    int    `graphql:"priority"`
+	State       struct {
+		ID    string `graphql:"id"`
+		Name  string `graphql:"name"`
+		Type  string `graphql:"type"`
+		Color string `graphql:"color"`
+	} `graphql:"state"`
+	Assignee *struct {
+		ID   string `graphql:"id"`
+		Name string `graphql:"name"`
+	} `graphql:"assignee"`
+	Team struct {
+		ID   string `graphql:"id"`
+		Key  string `graphql:"key"`
+		Name string `graphql:"name"`
+	} `graphql:"team"`
+	Project *struct {
+		ID   string `graphql:"id"`
+		Name string `graphql:"name"`
+	} `graphql:"project"`
+	Labels struct {
+		Nodes []struct {
+			ID    string `graphql:"id"`
+			Name  string `graphql:"name"`
+			Color string `graphql:"color"`
+		} `graphql:"nodes"`
+	} `graphql:"labels"`
+	CreatedAt string `graphql:"createdAt"`
+	UpdatedAt string `graphql:"updatedAt"`
+	URL       string `graphql:"url"`
+}
+
+// pageInfo is the cursor pagination block shared by connection queries
+type pageInfo struct {
+	HasNextPage bool   `graphql:"hasNextPage"`
+	EndCursor   string `graphql:"endCursor"`
+}
+
+// toModel converts an issue node into a model.Issue
+func (node issueNode) toModel() model.Issue {
+	issue := model.Issue{
+		ID:          node.ID,
+		Identifier:  node.Identifier,
+		Title:       node.Title,
+		Description: node.Description,
+		Priority:    node.Priority,
+		State: &model.State{
+			ID:    node.State.ID,
+			Name:  node.State.Name,
+			Type:  node.State.Type,
+			Color: node.State.Color,
+		},
+		Team: &model.Team{
+			ID:   node.Team.ID,
+			Key:  node.Team.Key,
+			Name: node.Team.Name,
+		},
+		CreatedAt: parseTime(node.CreatedAt),
+		UpdatedAt: parseTime(node.UpdatedAt),
+		URL:       node.URL,
+	}
+
+	if node.Assignee != nil {
+		issue.Assignee = &model.User{
+			ID:   node.Assignee.ID,
+			Name: node.Assignee.Name,
+		}
+	}
+
+	if node.Project != nil {
+		issue.Project = &model.Project{
+			ID:   node.Project.ID,
+			Name: node.Project.Name,
+		}
+	}
+
+	if len(node.Labels.Nodes) > 0 {
+		issue.Labels = make([]model.Label, len(node.Labels.Nodes))
+		for i, label := range node.Labels.Nodes {
+			issue.Labels[i] = model.Label{
+				ID:    label.ID,
+				Name:  label.Name,
+				Color: label.Color,
+			}
+		}
 	}
 
-	return teams, nil
+	return issue
 }
 
 // IssuesQuery represents the GraphQL issues query with filters
 type IssuesQuery struct {
 	Issues struct {
-		Nodes []struct {
-			ID          string `graphql:"id"`
-			Identifier  string `graphql:"identifier"`
-			Title       string `graphql:"title"`
-			Description string `graphql:"description"`
-			Priority    int    `graphql:"priority"`
-			State       struct {
-				ID    string `graphql:"id"`
-				Name  string `graphql:"name"`
-				Type  string `graphql:"type"`
-				Color string `graphql:"color"`
-			} `graphql:"state"`
-			Assignee *struct {
-				ID   string `graphql:"id"`
-				Name string `graphql:"name"`
-			} `graphql:"assignee"`
-			Team struct {
-				ID   string `graphql:"id"`
-				Key  string `graphql:"key"`
-				Name string `graphql:"name"`
-			} `graphql:"team"`
-			Labels struct {
-				Nodes []struct {
-					ID    string `graphql:"id"`
-					Name  string `graphql:"name"`
-					Color string `graphql:"color"`
-				} `graphql:"nodes"`
-			} `graphql:"labels"`
-			CreatedAt string `graphql:"createdAt"`
-			UpdatedAt string `graphql:"updatedAt"`
-			URL       string `graphql:"url"`
-		} `graphql:"nodes"`
-		PageInfo struct {
-			HasNextPage bool   `graphql:"hasNextPage"`
-			EndCursor   string `graphql:"endCursor"`
-		} `graphql:"pageInfo"`
+		Nodes    []issueNode `graphql:"nodes"`
+		PageInfo pageInfo    `graphql:"pageInfo"`
 	} `graphql:"issues(filter: $filter, first: $first, after: $after)"`
 }
 
 // IssueFilters represents filters for issue queries
 type IssueFilters struct {
-	TeamID     *string   `json:"team,omitempty"`
-	StateID    *string   `json:"state,omitempty"`
-	AssigneeID *string   `json:"assignee,omitempty"`
-	LabelIDs   *[]string `json:"labels,omitempty"`
-	ProjectID  *string   `json:"project,omitempty"`
-	Priority   *int      `json:"priority,omitempty"`
-	Search     *string   `json:"searchableContent,omitempty"`
+	IDs          []string   `json:"-"` // Match issues with any of these IDs
+	TeamID       *string    `json:"team,omitempty"`
+	TeamIDs      []string   `json:"-"` // Match issues in any of these teams
+	TeamKey      *string    `json:"-"` // Match issues in the team with this key
+	Numbers      []int      `json:"-"` // Match issues with any of these numbers
+	StateID      *string    `json:"state,omitempty"`
+	StateType    *string    `json:"-"` // Match issues whose state has this type (e.g. started)
+	AssigneeID   *string    `json:"assignee,omitempty"`
+	CreatorID    *string    `json:"creator,omitempty"`
+	LabelIDs     *[]string  `json:"labels,omitempty"`
+	ProjectID    *string    `json:"project,omitempty"`
+	MilestoneID  *string    `json:"projectMilestone,omitempty"`
+	ParentID     *string    `json:"parent,omitempty"`
+	Priority     *int       `json:"priority,omitempty"`
+	Search       *string    `json:"searchableContent,omitempty"`
+	Unassigned   bool       `json:"-"` // Match issues with no assignee
+	NoProject    bool       `json:"-"` // Match issues with no project
+	UpdatedAfter *time.Time `json:"-"` // Match issues updated strictly after this time
+	StaleSince   *time.Time `json:"-"` // Match open issues not updated since this time
+	Overdue      *time.Time `json:"-"` // Match open issues due before this day
+	NoDueDate    bool       `json:"-"` // Match issues with no due date
+	NoLabels     bool       `json:"-"` // Match issues with no labels
+	HasLabels    bool       `json:"-"` // Match issues with at least one label
+	CreatedIn    *TimeRange `json:"-"` // Match issues created in this range
+	CompletedIn  *TimeRange `json:"-"` // Match completed issues completed in this range
+
+	// IncludeDescription requests each issue's description, which list
+	// queries otherwise leave out
+	IncludeDescription bool `json:"-"`
+}
+
+// TimeRange is the half-open interval [Start, End), e.g. a cycle's dates
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// comparator converts the range into a Linear DateComparator
+func (r TimeRange) comparator() map[string]interface{} {
+	return map[string]interface{}{
+		"gte": r.Start.UTC().Format(time.RFC3339Nano),
+		"lt":  r.End.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// agePattern matches ages in days or weeks, e.g. 3d or 2w
+var agePattern = regexp.MustCompile(`^([0-9]+)([dw])$`)
+
+// ParseAge converts an age such as 3d, 2w, or a Go duration (36h) into the
+// time that long before now, e.g. for --older-than
+func ParseAge(value string, now time.Time) (time.Time, error) {
+	if m := agePattern.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		if n > 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	} else if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid age: %s (use a positive duration, e.g. 3d, 2w, or 36h)", value)
 }
 
-// ListIssues fetches issues with optional filters
-func (c *Client) ListIssues(ctx context.Context, filters *IssueFilters) ([]model.Issue, error) {
-	variables := map[string]interface{}{
-		"first": 50,
-	}
+// closedStateTypes are the state types an overdue or stale issue cannot be in
+var closedStateTypes = []string{"completed", "canceled"}
 
-	if filters != nil {
-		filterMap := make(map[string]interface{})
-		if filters.TeamID != nil {
-			filterMap["team"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.TeamID}}
+// IsClosedState reports whether a workflow state type (completed or
+// canceled) means no more work is expected on the issue
+func IsClosedState(stateType string) bool {
+	for _, t := range closedStateTypes {
+		if stateType == t {
+			return true
 		}
+	}
+	return false
+}
+
+// buildIssueFilter converts IssueFilters into a Linear IssueFilter map
+func buildIssueFilter(filters *IssueFilters) map[string]interface{} {
+	filterMap := make(map[string]interface{})
+	if filters == nil {
+		return filterMap
+	}
+
+	if len(filters.IDs) > 0 {
+		filterMap["id"] = map[string]interface{}{"in": filters.IDs}
+	}
+	if len(filters.TeamIDs) > 0 {
+		filterMap["team"] = map[string]interface{}{"id": map[string]interface{}{"in": filters.TeamIDs}}
+	} else if filters.TeamID != nil {
+		filterMap["team"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.TeamID}}
+	} else if filters.TeamKey != nil {
+		filterMap["team"] = map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": *filters.TeamKey}}
+	}
+	if len(filters.Numbers) > 0 {
+		filterMap["number"] = map[string]interface{}{"in": filters.Numbers}
+	}
+	if filters.StateID != nil || filters.StateType != nil || filters.Overdue != nil || filters.StaleSince != nil || filters.CompletedIn != nil {
+		state := map[string]interface{}{}
 		if filters.StateID != nil {
-			filterMap["state"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.StateID}}
+			state["id"] = map[string]interface{}{"eq": *filters.StateID}
+		}
+		if filters.StateType != nil {
+			state["type"] = map[string]interface{}{"eq": *filters.StateType}
+		} else if filters.Overdue != nil || filters.StaleSince != nil {
+			state["type"] = map[string]interface{}{"nin": closedStateTypes}
+		} else if filters.CompletedIn != nil {
+			state["type"] = map[string]interface{}{"eq": "completed"}
+		}
+		filterMap["state"] = state
+	}
+	if filters.CreatedIn != nil {
+		filterMap["createdAt"] = filters.CreatedIn.comparator()
+	}
+	if filters.CompletedIn != nil {
+		filterMap["completedAt"] = filters.CompletedIn.comparator()
+	}
+	if filters.NoDueDate {
+		filterMap["dueDate"] = map[string]interface{}{"null": true}
+	} else if filters.Overdue != nil {
+		filterMap["dueDate"] = map[string]interface{}{"lt": filters.Overdue.Format("2006-01-02")}
+	}
+	if filters.NoLabels {
+		filterMap["labels"] = map[string]interface{}{"length": map[string]interface{}{"eq": 0}}
+	} else if filters.HasLabels {
+		filterMap["labels"] = map[string]interface{}{"length": map[string]interface{}{"gt": 0}}
+	}
+	if filters.Unassigned {
+		filterMap["assignee"] = map[string]interface{}{"null": true}
+	} else if filters.AssigneeID != nil {
+		filterMap["assignee"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.AssigneeID}}
+	}
+	if filters.CreatorID != nil {
+		filterMap["creator"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.CreatorID}}
+	}
+	if filters.NoProject {
+		filterMap["project"] = map[string]interface{}{"null": true}
+	} else if filters.ProjectID != nil {
+		filterMap["project"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.ProjectID}}
+	}
+	if filters.MilestoneID != nil {
+		filterMap["projectMilestone"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.MilestoneID}}
+	}
+	if filters.ParentID != nil {
+		filterMap["parent"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.ParentID}}
+	}
+	if filters.Priority != nil {
+		filterMap["priority"] = map[string]interface{}{"eq": *filters.Priority}
+	}
+	if filters.Search != nil && *filters.Search != "" {
+		filterMap["searchableContent"] = map[string]interface{}{"containsIgnoreCase": *filters.Search}
+	}
+	if filters.UpdatedAfter != nil || filters.StaleSince != nil {
+		updated := map[string]interface{}{}
+		if filters.UpdatedAfter != nil {
+			updated["gt"] = filters.UpdatedAfter.UTC().Format(time.RFC3339Nano)
+		}
+		if filters.StaleSince != nil {
+			updated["lt"] = filters.StaleSince.UTC().Format(time.RFC3339Nano)
+		}
+		filterMap["updatedAt"] = updated
+	}
+
+	return filterMap
+}
+
+// ListIssues fetches issues with optional filters, following pagination up
+// to the ctx limit
+func (c *Client) ListIssues(ctx context.Context, filters *IssueFilters) ([]model.Issue, error) {
+	return pages(ctx, c, func(first int, after *string) ([]model.Issue, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first":           first,
+			"after":           after,
+			"filter":          issueFilter(filters),
+			"withDescription": filters != nil && filters.IncludeDescription,
 		}
-		if filters.AssigneeID != nil {
-			filterMap["assignee"] = map[string]interface{}{"id": map[string]interface{}{"eq": *filters.AssigneeID}}
+
+		var query IssuesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
-		if filters.Priority != nil {
-			filterMap["priority"] = map[string]interface{}{"eq": *filters.Priority}
+
+		issues := make([]model.Issue, 0, len(query.Issues.Nodes))
+		for _, node := range query.Issues.Nodes {
+			issues = append(issues, node.toModel())
+		}
+
+		return issues, query.Issues.PageInfo, nil
+	})
+}
+
+// MyIssuesQuery represents the viewer's assigned issues connection
+type MyIssuesQuery struct {
+	Viewer struct {
+		AssignedIssues struct {
+			Nodes    []issueNode `graphql:"nodes"`
+			PageInfo pageInfo    `graphql:"pageInfo"`
+		} `graphql:"assignedIssues(filter: $filter, first: $first, after: $after)"`
+	} `graphql:"viewer"`
+}
+
+// ListMyIssues fetches issues assigned to the authenticated user in a single
+// connection query per page, without resolving the viewer ID first
+func (c *Client) ListMyIssues(ctx context.Context, filters *IssueFilters) ([]model.Issue, error) {
+	return pages(ctx, c, func(first int, after *string) ([]model.Issue, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first":           first,
+			"after":           after,
+			"filter":          issueFilter(filters),
+			"withDescription": filters != nil && filters.IncludeDescription,
 		}
-		if filters.Search != nil && *filters.Search != "" {
-			filterMap["searchableContent"] = map[string]interface{}{"containsIgnoreCase": *filters.Search}
+
+		var query MyIssuesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
-		if len(filterMap) > 0 {
-			variables["filter"] = filterMap
+
+		issues := make([]model.Issue, 0, len(query.Viewer.AssignedIssues.Nodes))
+		for _, node := range query.Viewer.AssignedIssues.Nodes {
+			issues = append(issues, node.toModel())
 		}
+
+		return issues, query.Viewer.AssignedIssues.PageInfo, nil
+	})
+}
+
+// parseTime parses an API timestamp, returning the zero time if it is
+// empty or malformed
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
 	}
+	return t
+}
 
-	var query IssuesQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
+// parseDate parses an optional Linear date (YYYY-MM-DD), returning nil if
+// unset or malformed
+func parseDate(value *string) *time.Time {
+	if value == nil || *value == "" {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil
 	}
+	return &t
+}
 
-	issues := make([]model.Issue, 0, len(query.Issues.Nodes))
-	for _, node := range query.Issues.Nodes {
-		issue := model.Issue{
-			ID:          node.ID,
-			Identifier:  node.Identifier,
-			Title:       node.Title,
-			Description: node.Description,
-			Priority:    node.Priority,
-			State: &model.State{
-				ID:    node.State.ID,
-				Name:  node.State.Name,
-				Type:  node.State.Type,
-				Color: node.State.Color,
-			},
-			Team: &model.Team{
-				ID:   node.Team.ID,
-				Key:  node.Team.Key,
-				Name: node.Team.Name,
-			},
-			URL: node.URL,
+// ValidateDateRange checks that start and target, when set, are dates in
+// Linear's YYYY-MM-DD form and that start is not after target
+func ValidateDateRange(start, target string) error {
+	dates := []struct{ flag, value string }{{"--start-date", start}, {"--target-date", target}}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date.value); err != nil {
+			return fmt.Errorf("invalid %s: %s (expected YYYY-MM-DD)", date.flag, date.value)
 		}
+	}
+	if start != "" && target != "" && start > target {
+		return fmt.Errorf("--start-date %s is after --target-date %s", start, target)
+	}
+	return nil
+}
 
-		if node.Assignee != nil {
-			issue.Assignee = &model.User{
-				ID:   node.Assignee.ID,
-				Name: node.Assignee.Name,
-			}
+// LatestUpdate returns the most recent UpdatedAt across issues
+func LatestUpdate(issues []model.Issue) time.Time {
+	var latest time.Time
+	for _, issue := range issues {
+		if issue.UpdatedAt.After(latest) {
+			latest = issue.UpdatedAt
 		}
+	}
+	return latest
+}
 
-		if len(node.Labels.Nodes) > 0 {
-			issue.Labels = make([]model.Label, len(node.Labels.Nodes))
-			for i, label := range node.Labels.Nodes {
-				issue.Labels[i] = model.Label{
-					ID:    label.ID,
-					Name:  label.Name,
-					Color: label.Color,
-				}
-			}
+// MergeIssues applies updated issues onto a previously fetched set. Issues
+// already present are replaced in place; new issues are prepended. changed
+// holds the IDs of every issue updated since existing was fetched, whether
+// or not it still matches; existing issues among them that are missing from
+// updates no longer match and are dropped.
+func MergeIssues(existing, updates []model.Issue, changed []string) []model.Issue {
+	updated := make(map[string]model.Issue, len(updates))
+	for _, issue := range updates {
+		updated[issue.ID] = issue
+	}
+	moved := make(map[string]bool, len(changed))
+	for _, id := range changed {
+		if _, ok := updated[id]; !ok {
+			moved[id] = true
+		}
+	}
+
+	merged := make([]model.Issue, 0, len(existing)+len(updates))
+	kept := make(map[string]bool, len(existing))
+	for _, issue := range existing {
+		if moved[issue.ID] {
+			continue
 		}
+		if update, ok := updated[issue.ID]; ok {
+			issue = update
+		}
+		kept[issue.ID] = true
+		merged = append(merged, issue)
+	}
 
-		issues = append(issues, issue)
+	added := []model.Issue{}
+	for _, issue := range updates {
+		if !kept[issue.ID] {
+			added = append(added, issue)
+		}
 	}
 
-	return issues, nil
+	return append(added, merged...)
+}
+
+// IssueIDsQuery represents the issues query fetching only IDs
+type IssueIDsQuery struct {
+	Issues struct {
+		Nodes []struct {
+			ID string `graphql:"id"`
+		} `graphql:"nodes"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"issues(filter: $filter, first: $first, after: $after)"`
+}
+
+// ListIssueIDs fetches the IDs of issues matching filters, for finding which
+// issues changed without fetching them, following pagination up to the ctx
+// limit
+func (c *Client) ListIssueIDs(ctx context.Context, filters *IssueFilters) ([]string, error) {
+	return pages(ctx, c, func(first int, after *string) ([]string, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": issueFilter(filters),
+		}
+
+		var query IssueIDsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		ids := make([]string, 0, len(query.Issues.Nodes))
+		for _, node := range query.Issues.Nodes {
+			ids = append(ids, node.ID)
+		}
+
+		return ids, query.Issues.PageInfo, nil
+	})
 }
 
 // IssueQuery represents a single issue query
@@ -249,6 +744,17 @@ type IssueQuery struct {
 			Identifier string `graphql:"identifier"`
 			Title      string `graphql:"title"`
 		} `graphql:"parent"`
+		Reactions []struct {
+			Emoji string `graphql:"emoji"`
+		} `graphql:"reactions"`
+		Attachments struct {
+			Nodes []struct {
+				ID         string `graphql:"id"`
+				Title      string `graphql:"title"`
+				URL        string `graphql:"url"`
+				SourceType string `graphql:"sourceType"`
+			} `graphql:"nodes"`
+		} `graphql:"attachments"`
 		CreatedAt string `graphql:"createdAt"`
 		UpdatedAt string `graphql:"updatedAt"`
 		URL       string `graphql:"url"`
@@ -283,7 +789,9 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*model.Issue, error)
 			Key:  query.Issue.Team.Key,
 			Name: query.Issue.Team.Name,
 		},
-		URL: query.Issue.URL,
+		CreatedAt: parseTime(query.Issue.CreatedAt),
+		UpdatedAt: parseTime(query.Issue.UpdatedAt),
+		URL:       query.Issue.URL,
 	}
 
 	if query.Issue.Assignee != nil {
@@ -314,13 +822,99 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*model.Issue, error)
 		}
 	}
 
+	emojis := make([]string, len(query.Issue.Reactions))
+	for i, reaction := range query.Issue.Reactions {
+		emojis[i] = reaction.Emoji
+	}
+	issue.Reactions = summarizeReactions(emojis)
+
+	for _, attachment := range query.Issue.Attachments.Nodes {
+		issue.Attachments = append(issue.Attachments, model.Attachment{
+			ID:         attachment.ID,
+			Title:      attachment.Title,
+			URL:        attachment.URL,
+			SourceType: attachment.SourceType,
+		})
+	}
+
 	return issue, nil
 }
 
+// summarizeReactions counts reactions per emoji, keeping the order in which
+// each emoji first appears
+func summarizeReactions(emojis []string) []model.Reaction {
+	if len(emojis) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int)
+	summary := []model.Reaction{}
+	for _, emoji := range emojis {
+		emoji = NormalizeEmoji(emoji)
+		if i, ok := index[emoji]; ok {
+			summary[i].Count++
+			continue
+		}
+		index[emoji] = len(summary)
+		summary = append(summary, model.Reaction{Emoji: emoji, Count: 1})
+	}
+	return summary
+}
+
+// NormalizeEmoji strips surrounding colons from an emoji shortcode, so that
+// ":eyes:" and "eyes" name the same reaction
+func NormalizeEmoji(emoji string) string {
+	return strings.Trim(strings.TrimSpace(emoji), ":")
+}
+
+// CreateReactionMutation represents the reaction create mutation
+type CreateReactionMutation struct {
+	ReactionCreate struct {
+		Success  bool `graphql:"success"`
+		Reaction struct {
+			ID    string `graphql:"id"`
+			Emoji string `graphql:"emoji"`
+		} `graphql:"reaction"`
+	} `graphql:"reactionCreate(input: $input)"`
+}
+
+// CreateReactionInput represents input for reacting to an issue or comment
+type CreateReactionInput struct {
+	IssueID   *string `json:"issueId,omitempty"`
+	CommentID *string `json:"commentId,omitempty"`
+	Emoji     string  `json:"emoji"`
+}
+
+// ReactIssue adds an emoji reaction to an issue
+func (c *Client) ReactIssue(ctx context.Context, issueID, emoji string) error {
+	emoji = NormalizeEmoji(emoji)
+	if emoji == "" {
+		return fmt.Errorf("emoji is required")
+	}
+
+	variables := map[string]interface{}{
+		"input": &CreateReactionInput{
+			IssueID: &issueID,
+			Emoji:   emoji,
+		},
+	}
+
+	var mutation CreateReactionMutation
+	if err := c.Mutate(ctx, &mutation, variables); err != nil {
+		return err
+	}
+
+	if !mutation.ReactionCreate.Success {
+		return fmt.Errorf("failed to create reaction")
+	}
+
+	return nil
+}
+
 // ResolveIssueID resolves an issue identifier (ENG-123 or UUID) to an ID
 func (c *Client) ResolveIssueID(ctx context.Context, identifier string) (string, error) {
-	// If it looks like a UUID, return as-is
-	if len(identifier) > 20 && !strings.Contains(identifier, "-") {
+	// If it is a UUID, return as-is
+	if IsUUID(identifier) {
 		return identifier, nil
 	}
 
@@ -341,7 +935,7 @@ func (c *Client) ResolveIssueID(ctx context.Context, identifier string) (string,
 	}
 
 	if query.Issue.ID == "" {
-		return "", fmt.Errorf("issue not found: %s", identifier)
+		return "", fmt.Errorf("%w: %s", ErrIssueNotFound, identifier)
 	}
 
 	return query.Issue.ID, nil
@@ -362,17 +956,30 @@ type CreateIssueMutation struct {
 
 // CreateIssueInput represents input for creating an issue
 type CreateIssueInput struct {
-	TeamID      string  `json:"teamId"`
-	Title       string  `json:"title"`
-	Description *string `json:"description,omitempty"`
-	Priority    *int    `json:"priority,omitempty"`
-	StateID     *string `json:"stateId,omitempty"`
-	AssigneeID  *string `json:"assigneeId,omitempty"`
-	ProjectID   *string `json:"projectId,omitempty"`
-	ParentID    *string `json:"parentId,omitempty"`
+	TeamID      string    `json:"teamId"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description,omitempty"`
+	Priority    *int      `json:"priority,omitempty"`
+	StateID     *string   `json:"stateId,omitempty"`
+	AssigneeID  *string   `json:"assigneeId,omitempty"`
+	ProjectID   *string   `json:"projectId,omitempty"`
+	ParentID    *string   `json:"parentId,omitempty"`
 	LabelIDs    *[]string `json:"labelIds,omitempty"`
 }
 
+// InheritFromParent defaults a sub-issue's team and project to its
+// parent's, for issue create --parent without --team. Fields already set
+// are kept.
+func (in *CreateIssueInput) InheritFromParent(parent *model.Issue) {
+	if in.TeamID == "" && parent.Team != nil {
+		in.TeamID = parent.Team.ID
+	}
+	if in.ProjectID == nil && parent.Project != nil {
+		projectID := parent.Project.ID
+		in.ProjectID = &projectID
+	}
+}
+
 // CreateIssue creates a new issue
 func (c *Client) CreateIssue(ctx context.Context, input *CreateIssueInput) (*model.Issue, error) {
 	variables := map[string]interface{}{
@@ -410,21 +1017,88 @@ type UpdateIssueMutation struct {
 
 // UpdateIssueInput represents input for updating an issue
 type UpdateIssueInput struct {
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Priority    *int    `json:"priority,omitempty"`
-	StateID     *string `json:"stateId,omitempty"`
-	AssigneeID  *string `json:"assigneeId,omitempty"`
-	ProjectID   *string `json:"projectId,omitempty"`
-	ParentID    *string `json:"parentId,omitempty"`
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Priority    *int      `json:"priority,omitempty"`
+	StateID     *string   `json:"stateId,omitempty"`
+	AssigneeID  *string   `json:"assigneeId,omitempty"`
+	ProjectID   *string   `json:"projectId,omitempty"`
+	ParentID    *string   `json:"parentId,omitempty"`
 	LabelIDs    *[]string `json:"labelIds,omitempty"`
+	TeamID      *string   `json:"teamId,omitempty"` // move to another team
+
+	// AddedLabelIDs and RemovedLabelIDs change labels without replacing the
+	// rest, unlike LabelIDs
+	AddedLabelIDs   []string `json:"addedLabelIds,omitempty"`
+	RemovedLabelIDs []string `json:"removedLabelIds,omitempty"`
+
+	// Clear lists input fields to send as explicit nulls (e.g. ClearProject),
+	// since unset pointer fields are omitted rather than nulled
+	Clear []string `json:"-"`
+}
+
+// Input fields of UpdateIssueInput that can be cleared
+const (
+	ClearAssignee = "assigneeId"
+	ClearProject  = "projectId"
+	ClearParent   = "parentId"
+	ClearPriority = "priority"
+	ClearDueDate  = "dueDate"
+)
+
+// issueUpdateInput is the input variable of issueUpdate and
+// issueBatchUpdate. It is a map rather than UpdateIssueInput itself so that
+// cleared fields can be sent as explicit nulls.
+type issueUpdateInput map[string]interface{}
+
+// GetGraphQLType declares the variable as Linear's IssueUpdateInput, which
+// the GraphQL client cannot infer from a map
+func (issueUpdateInput) GetGraphQLType() string { return "IssueUpdateInput" }
+
+// toMap converts the input into the mutation's input variable, adding an
+// explicit null for each cleared field
+func (input *UpdateIssueInput) toMap() (issueUpdateInput, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := issueUpdateInput{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, field := range input.Clear {
+		fields[field] = nil
+	}
+	return fields, nil
+}
+
+// ExtendDescription sets the description to current with text appended,
+// or prepended when prepend is set, separated by a newline. An empty
+// current description is simply replaced by text.
+func (input *UpdateIssueInput) ExtendDescription(current, text string, prepend bool) {
+	description := text
+	if current = strings.TrimRight(current, "\n"); current != "" {
+		if prepend {
+			description = text + "\n" + current
+		} else {
+			description = current + "\n" + text
+		}
+	}
+	input.Description = &description
 }
 
 // UpdateIssue updates an existing issue
 func (c *Client) UpdateIssue(ctx context.Context, id string, input *UpdateIssueInput) error {
+	inputMap, err := input.toMap()
+	if err != nil {
+		return fmt.Errorf("failed to encode issue input: %w", err)
+	}
+
 	variables := map[string]interface{}{
 		"id":    id,
-		"input": input,
+		"input": inputMap,
 	}
 
 	var mutation UpdateIssueMutation
@@ -439,6 +1113,86 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, input *UpdateIssueI
 	return nil
 }
 
+// batchUpdateSize is the most issues issueBatchUpdate accepts in one call
+const batchUpdateSize = 50
+
+// UUID is an ID argument Linear types as UUID rather than String, such as
+// the ids of issueBatchUpdate
+type UUID string
+
+// BatchUpdateIssueMutation represents the issue batch update mutation
+type BatchUpdateIssueMutation struct {
+	IssueBatchUpdate struct {
+		Success bool `graphql:"success"`
+	} `graphql:"issueBatchUpdate(ids: $ids, input: $input)"`
+}
+
+// BatchUpdateIssues applies the same update to many issues with
+// issueBatchUpdate, one mutation per batchUpdateSize issues instead of one
+// per issue. ids must be issue UUIDs
+func (c *Client) BatchUpdateIssues(ctx context.Context, ids []string, input *UpdateIssueInput) error {
+	inputMap, err := input.toMap()
+	if err != nil {
+		return fmt.Errorf("failed to encode issue input: %w", err)
+	}
+
+	for start := 0; start < len(ids); start += batchUpdateSize {
+		end := start + batchUpdateSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := make([]UUID, 0, end-start)
+		for _, id := range ids[start:end] {
+			batch = append(batch, UUID(id))
+		}
+
+		variables := map[string]interface{}{
+			"ids":   batch,
+			"input": inputMap,
+		}
+
+		var mutation BatchUpdateIssueMutation
+		if err := c.Mutate(ctx, &mutation, variables); err != nil {
+			return err
+		}
+
+		if !mutation.IssueBatchUpdate.Success {
+			return fmt.Errorf("failed to update issues %d-%d of %d", start+1, end, len(ids))
+		}
+	}
+
+	return nil
+}
+
+// UpdateIssues applies the same update to many issues, batched with
+// BatchUpdateIssues. A batch that fails is retried one issue at a time with
+// UpdateIssue, so an issue the batch rejects does not fail the others. It
+// returns the error for each issue that could not be updated, keyed by ID.
+func (c *Client) UpdateIssues(ctx context.Context, ids []string, input *UpdateIssueInput) map[string]error {
+	failed := map[string]error{}
+
+	for start := 0; start < len(ids); start += batchUpdateSize {
+		end := start + batchUpdateSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := ids[start:end]
+		if err := c.BatchUpdateIssues(ctx, batch, input); err == nil {
+			continue
+		}
+
+		for _, id := range batch {
+			if err := c.UpdateIssue(ctx, id, input); err != nil {
+				failed[id] = err
+			}
+		}
+	}
+
+	return failed
+}
+
 // ArchiveIssueMutation represents the issue archive mutation
 type ArchiveIssueMutation struct {
 	IssueArchive struct {
@@ -527,6 +1281,131 @@ func (c *Client) ListWorkflowStates(ctx context.Context, teamID string) ([]model
 	return states, nil
 }
 
+// CyclesQuery represents the GraphQL cycles query for a team
+type CyclesQuery struct {
+	Cycles struct {
+		Nodes []struct {
+			ID          string  `graphql:"id"`
+			Name        *string `graphql:"name"`
+			Number      float64 `graphql:"number"`
+			StartsAt    string  `graphql:"startsAt"`
+			EndsAt      string  `graphql:"endsAt"`
+			CompletedAt *string `graphql:"completedAt"`
+			Progress    float64 `graphql:"progress"`
+		} `graphql:"nodes"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"cycles(first: $first, after: $after, filter: {team: {id: {eq: $teamId}}})"`
+}
+
+// ListCycles fetches a team's cycles, oldest first
+func (c *Client) ListCycles(ctx context.Context, teamID string) ([]model.Cycle, error) {
+	cycles, err := pages(ctx, c, func(first int, after *string) ([]model.Cycle, pageInfo, error) {
+		variables := map[string]interface{}{
+			"teamId": teamID,
+			"first":  first,
+			"after":  after,
+		}
+
+		var query CyclesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		cycles := make([]model.Cycle, 0, len(query.Cycles.Nodes))
+		for _, node := range query.Cycles.Nodes {
+			cycle := model.Cycle{
+				ID:        node.ID,
+				Number:    int(node.Number),
+				StartsAt:  parseTime(node.StartsAt),
+				EndsAt:    parseTime(node.EndsAt),
+				Completed: node.CompletedAt != nil,
+				Progress:  node.Progress,
+			}
+			if node.Name != nil {
+				cycle.Name = *node.Name
+			}
+			cycles = append(cycles, cycle)
+		}
+
+		return cycles, query.Cycles.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		return cycles[i].StartsAt.Before(cycles[j].StartsAt)
+	})
+	return cycles, nil
+}
+
+// Cycle selectors accepted by SelectCycle
+const (
+	CycleCurrent  = "current"
+	CycleNext     = "next"
+	CyclePrevious = "previous"
+)
+
+// SelectCycle picks the cycle running at now (current), the first to start
+// after now (next), or the last to end by now (previous) from their date
+// ranges
+func SelectCycle(cycles []model.Cycle, which string, now time.Time) (*model.Cycle, error) {
+	if which != CycleCurrent && which != CycleNext && which != CyclePrevious {
+		return nil, fmt.Errorf("unknown cycle selector %q", which)
+	}
+
+	var selected *model.Cycle
+	for i := range cycles {
+		cycle := &cycles[i]
+		switch which {
+		case CycleCurrent:
+			if !now.Before(cycle.StartsAt) && now.Before(cycle.EndsAt) {
+				return cycle, nil
+			}
+		case CycleNext:
+			if cycle.StartsAt.After(now) && (selected == nil || cycle.StartsAt.Before(selected.StartsAt)) {
+				selected = cycle
+			}
+		case CyclePrevious:
+			if !cycle.EndsAt.After(now) && (selected == nil || cycle.EndsAt.After(selected.EndsAt)) {
+				selected = cycle
+			}
+		}
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("no %s cycle", which)
+	}
+	return selected, nil
+}
+
+// FindCycle picks a cycle by selector (current, next, previous; see
+// SelectCycle) or by number
+func FindCycle(cycles []model.Cycle, ref string, now time.Time) (*model.Cycle, error) {
+	number, err := strconv.Atoi(ref)
+	if err != nil {
+		return SelectCycle(cycles, ref, now)
+	}
+
+	for i := range cycles {
+		if cycles[i].Number == number {
+			return &cycles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cycle not found: %d", number)
+}
+
+// FindStateByType returns the first workflow state of the given type (e.g.
+// triage), or nil if the team has none
+func FindStateByType(states []model.State, stateType string) *model.State {
+	for i := range states {
+		if states[i].Type == stateType {
+			return &states[i]
+		}
+	}
+	return nil
+}
+
 // ProjectsQuery represents the GraphQL projects query
 type ProjectsQuery struct {
 	Projects struct {
@@ -540,42 +1419,107 @@ type ProjectsQuery struct {
 				ID   string `graphql:"id"`
 				Name string `graphql:"name"`
 			} `graphql:"lead"`
-			CreatedAt string `graphql:"createdAt"`
-			UpdatedAt string `graphql:"updatedAt"`
-			URL       string `graphql:"url"`
+			Progress   float64 `graphql:"progress"`
+			StartDate  *string `graphql:"startDate"`
+			TargetDate *string `graphql:"targetDate"`
+			CreatedAt  string  `graphql:"createdAt"`
+			UpdatedAt  string  `graphql:"updatedAt"`
+			URL        string  `graphql:"url"`
 		} `graphql:"nodes"`
-	} `graphql:"projects"`
-}
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"projects(filter: $filter, first: $first, after: $after)"`
+}
+
+// ListProjects fetches all projects, optionally only those in state
+func (c *Client) ListProjects(ctx context.Context, state string) ([]model.Project, error) {
+	return pages(ctx, c, func(first int, after *string) ([]model.Project, pageInfo, error) {
+		var filter *ProjectFilter
+		if state != "" {
+			filter = &ProjectFilter{
+				"state": map[string]interface{}{
+					"eq": state,
+				},
+			}
+		}
 
-// ListProjects fetches all projects
-func (c *Client) ListProjects(ctx context.Context) ([]model.Project, error) {
-	var query ProjectsQuery
-	if err := c.Query(ctx, &query, nil); err != nil {
-		return nil, err
-	}
+		variables := map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		}
 
-	projects := make([]model.Project, 0, len(query.Projects.Nodes))
-	for _, node := range query.Projects.Nodes {
-		project := model.Project{
-			ID:          node.ID,
-			Name:        node.Name,
-			Description: node.Description,
-			State:       node.State,
-			Priority:    node.Priority,
-			URL:         node.URL,
+		var query ProjectsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
 
-		if node.Lead != nil {
-			project.Lead = &model.User{
-				ID:   node.Lead.ID,
-				Name: node.Lead.Name,
+		projects := make([]model.Project, 0, len(query.Projects.Nodes))
+		for _, node := range query.Projects.Nodes {
+			project := model.Project{
+				ID:          node.ID,
+				Name:        node.Name,
+				Description: node.Description,
+				State:       node.State,
+				Priority:    node.Priority,
+				Progress:    node.Progress,
+				StartDate:   parseDate(node.StartDate),
+				TargetDate:  parseDate(node.TargetDate),
+				URL:         node.URL,
+			}
+
+			if node.Lead != nil {
+				project.Lead = &model.User{
+					ID:   node.Lead.ID,
+					Name: node.Lead.Name,
+				}
 			}
+
+			projects = append(projects, project)
 		}
 
-		projects = append(projects, project)
+		return projects, query.Projects.PageInfo, nil
+	})
+}
+
+// ProjectSortFields are the valid values for project list --sort
+var ProjectSortFields = []string{"target-date", "name", "progress", "priority"}
+
+// SortProjects orders projects in place by target-date (the default), name,
+// progress, or priority (Urgent first, No Priority last), ascending unless
+// reverse. Projects without a target date always sort last so the list
+// reads as a roadmap.
+func SortProjects(projects []model.Project, by string, reverse bool) {
+	rank := func(priority int) int {
+		if priority == 0 {
+			return 5
+		}
+		return priority
+	}
+	compare := func(a, b model.Project) int {
+		switch by {
+		case "name":
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case "progress":
+			return cmp.Compare(a.Progress, b.Progress)
+		case "priority":
+			return cmp.Compare(rank(a.Priority), rank(b.Priority))
+		default:
+			return a.TargetDate.Compare(*b.TargetDate)
+		}
 	}
 
-	return projects, nil
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		if by == "target-date" || by == "" {
+			if a.TargetDate == nil || b.TargetDate == nil {
+				return a.TargetDate != nil && b.TargetDate == nil
+			}
+		}
+		if reverse {
+			return compare(a, b) > 0
+		}
+		return compare(a, b) < 0
+	})
 }
 
 // ProjectQuery represents a single project query
@@ -598,9 +1542,11 @@ type ProjectQuery struct {
 				Name string `graphql:"name"`
 			} `graphql:"nodes"`
 		} `graphql:"members"`
-		CreatedAt string `graphql:"createdAt"`
-		UpdatedAt string `graphql:"updatedAt"`
-		URL       string `graphql:"url"`
+		StartDate  *string `graphql:"startDate"`
+		TargetDate *string `graphql:"targetDate"`
+		CreatedAt  string  `graphql:"createdAt"`
+		UpdatedAt  string  `graphql:"updatedAt"`
+		URL        string  `graphql:"url"`
 	} `graphql:"project(id: $id)"`
 }
 
@@ -621,6 +1567,8 @@ func (c *Client) GetProject(ctx context.Context, id string) (*model.Project, err
 		Description: query.Project.Description,
 		State:       query.Project.State,
 		Priority:    query.Project.Priority,
+		StartDate:   parseDate(query.Project.StartDate),
+		TargetDate:  parseDate(query.Project.TargetDate),
 		URL:         query.Project.URL,
 	}
 
@@ -636,6 +1584,34 @@ func (c *Client) GetProject(ctx context.Context, id string) (*model.Project, err
 	return project, nil
 }
 
+// FindProject picks the project named (case-insensitively) or identified by
+// nameOrID. Project names need not be unique, so a name shared by several
+// projects is an error asking for the ID.
+func FindProject(projects []model.Project, nameOrID string) (*model.Project, error) {
+	matches := []model.Project{}
+	for _, project := range projects {
+		if project.ID == nameOrID {
+			return &project, nil
+		}
+		if strings.EqualFold(project.Name, nameOrID) {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("project not found: %s", nameOrID)
+	case 1:
+		return &matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, project := range matches {
+		ids[i] = project.ID
+	}
+	return nil, fmt.Errorf("project %q is ambiguous (IDs: %s) - pass the project ID", nameOrID, strings.Join(ids, ", "))
+}
+
 // CreateProjectMutation represents the project creation mutation
 type CreateProjectMutation struct {
 	ProjectCreate struct {
@@ -650,12 +1626,14 @@ type CreateProjectMutation struct {
 
 // CreateProjectInput represents input for creating a project
 type CreateProjectInput struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	State       *string `json:"state,omitempty"`
-	Priority    *int    `json:"priority,omitempty"`
-	LeadID      *string `json:"leadId,omitempty"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	State       *string   `json:"state,omitempty"`
+	Priority    *int      `json:"priority,omitempty"`
+	LeadID      *string   `json:"leadId,omitempty"`
 	TeamIDs     *[]string `json:"teamIds,omitempty"`
+	StartDate   *string   `json:"startDate,omitempty"`  // YYYY-MM-DD
+	TargetDate  *string   `json:"targetDate,omitempty"` // YYYY-MM-DD
 }
 
 // CreateProject creates a new project
@@ -693,11 +1671,14 @@ type UpdateProjectMutation struct {
 
 // UpdateProjectInput represents input for updating a project
 type UpdateProjectInput struct {
-	Name        *string `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
-	State       *string `json:"state,omitempty"`
-	Priority    *int    `json:"priority,omitempty"`
-	LeadID      *string `json:"leadId,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	State       *string   `json:"state,omitempty"`
+	Priority    *int      `json:"priority,omitempty"`
+	LeadID      *string   `json:"leadId,omitempty"`
+	MemberIDs   *[]string `json:"memberIds,omitempty"`
+	StartDate   *string   `json:"startDate,omitempty"`  // YYYY-MM-DD
+	TargetDate  *string   `json:"targetDate,omitempty"` // YYYY-MM-DD
 }
 
 // UpdateProject updates an existing project
@@ -785,43 +1766,48 @@ type ProjectIssuesQuery struct {
 					Name string `graphql:"name"`
 				} `graphql:"assignee"`
 			} `graphql:"nodes"`
-		} `graphql:"issues"`
+			PageInfo pageInfo `graphql:"pageInfo"`
+		} `graphql:"issues(first: $first, after: $after)"`
 	} `graphql:"project(id: $id)"`
 }
 
 // ListProjectIssues fetches issues for a project
 func (c *Client) ListProjectIssues(ctx context.Context, projectID string) ([]model.Issue, error) {
-	variables := map[string]interface{}{
-		"id": projectID,
-	}
-
-	var query ProjectIssuesQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
-	}
+	return pages(ctx, c, func(first int, after *string) ([]model.Issue, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+			"id":    projectID,
+		}
 
-	issues := make([]model.Issue, 0, len(query.Project.Issues.Nodes))
-	for _, node := range query.Project.Issues.Nodes {
-		issue := model.Issue{
-			ID:         node.ID,
-			Identifier: node.Identifier,
-			Title:      node.Title,
-			State: &model.State{
-				Name: node.State.Name,
-				Type: node.State.Type,
-			},
+		var query ProjectIssuesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
 
-		if node.Assignee != nil {
-			issue.Assignee = &model.User{
-				Name: node.Assignee.Name,
+		issues := make([]model.Issue, 0, len(query.Project.Issues.Nodes))
+		for _, node := range query.Project.Issues.Nodes {
+			issue := model.Issue{
+				ID:         node.ID,
+				Identifier: node.Identifier,
+				Title:      node.Title,
+				State: &model.State{
+					Name: node.State.Name,
+					Type: node.State.Type,
+				},
 			}
-		}
 
-		issues = append(issues, issue)
-	}
+			if node.Assignee != nil {
+				issue.Assignee = &model.User{
+					Name: node.Assignee.Name,
+				}
+			}
+
+			issues = append(issues, issue)
+		}
 
-	return issues, nil
+		return issues, query.Project.Issues.PageInfo, nil
+	})
 }
 
 // MilestonesQuery represents the GraphQL milestones query
@@ -838,44 +1824,86 @@ type MilestonesQuery struct {
 			} `graphql:"project"`
 			CreatedAt string `graphql:"createdAt"`
 		} `graphql:"nodes"`
-	} `graphql:"milestones(filter: $filter)"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"milestones(filter: $filter, first: $first, after: $after)"`
 }
 
 // ListMilestones fetches milestones, optionally filtered by project
 func (c *Client) ListMilestones(ctx context.Context, projectID string) ([]model.Milestone, error) {
-	variables := map[string]interface{}{}
-
-	if projectID != "" {
-		variables["filter"] = map[string]interface{}{
-			"project": map[string]interface{}{
-				"id": map[string]interface{}{
-					"eq": projectID,
+	return pages(ctx, c, func(first int, after *string) ([]model.Milestone, pageInfo, error) {
+		var filter *ProjectMilestoneFilter
+		if projectID != "" {
+			filter = &ProjectMilestoneFilter{
+				"project": map[string]interface{}{
+					"id": map[string]interface{}{
+						"eq": projectID,
+					},
 				},
-			},
+			}
 		}
-	}
 
-	var query MilestonesQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
-	}
+		variables := map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		}
 
-	milestones := make([]model.Milestone, 0, len(query.Milestones.Nodes))
-	for _, node := range query.Milestones.Nodes {
-		milestone := model.Milestone{
-			ID:          node.ID,
-			Name:        node.Name,
-			Description: node.Description,
-			Project: &model.Project{
-				ID:   node.Project.ID,
-				Name: node.Project.Name,
-			},
+		var query MilestonesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
 
-		milestones = append(milestones, milestone)
+		milestones := make([]model.Milestone, 0, len(query.Milestones.Nodes))
+		for _, node := range query.Milestones.Nodes {
+			milestone := model.Milestone{
+				ID:          node.ID,
+				Name:        node.Name,
+				Description: node.Description,
+				TargetDate:  parseDate(node.TargetDate),
+				Project: &model.Project{
+					ID:   node.Project.ID,
+					Name: node.Project.Name,
+				},
+				CreatedAt: parseTime(node.CreatedAt),
+			}
+
+			milestones = append(milestones, milestone)
+		}
+
+		return milestones, query.Milestones.PageInfo, nil
+	})
+}
+
+// MilestoneSortFields are the fields accepted by SortMilestones
+var MilestoneSortFields = []string{"target-date", "name", "created"}
+
+// SortMilestones orders milestones in place by target-date (the default),
+// name, or created, ascending unless reverse. Milestones without a target
+// date always sort last so upcoming milestones stay at the top.
+func SortMilestones(milestones []model.Milestone, by string, reverse bool) {
+	compare := func(a, b model.Milestone) int {
+		switch by {
+		case "name":
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case "created":
+			return a.CreatedAt.Compare(b.CreatedAt)
+		default:
+			return a.TargetDate.Compare(*b.TargetDate)
+		}
 	}
 
-	return milestones, nil
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i], milestones[j]
+		if by == "target-date" || by == "" {
+			if a.TargetDate == nil || b.TargetDate == nil {
+				return a.TargetDate != nil && b.TargetDate == nil
+			}
+		}
+		if reverse {
+			return compare(a, b) > 0
+		}
+		return compare(a, b) < 0
+	})
 }
 
 // MilestoneQuery represents a single milestone query
@@ -908,10 +1936,12 @@ func (c *Client) GetMilestone(ctx context.Context, id string) (*model.Milestone,
 		ID:          query.Milestone.ID,
 		Name:        query.Milestone.Name,
 		Description: query.Milestone.Description,
+		TargetDate:  parseDate(query.Milestone.TargetDate),
 		Project: &model.Project{
 			ID:   query.Milestone.Project.ID,
 			Name: query.Milestone.Project.Name,
 		},
+		CreatedAt: parseTime(query.Milestone.CreatedAt),
 	}
 
 	return milestone, nil
@@ -1032,81 +2062,149 @@ type MilestoneIssuesQuery struct {
 					Type string `graphql:"type"`
 				} `graphql:"state"`
 			} `graphql:"nodes"`
-		} `graphql:"issues"`
+			PageInfo pageInfo `graphql:"pageInfo"`
+		} `graphql:"issues(first: $first, after: $after)"`
 	} `graphql:"milestone(id: $id)"`
 }
 
 // ListMilestoneIssues fetches issues for a milestone
 func (c *Client) ListMilestoneIssues(ctx context.Context, milestoneID string) ([]model.Issue, error) {
-	variables := map[string]interface{}{
-		"id": milestoneID,
-	}
-
-	var query MilestoneIssuesQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
-	}
+	return pages(ctx, c, func(first int, after *string) ([]model.Issue, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+			"id":    milestoneID,
+		}
 
-	milestoneIssues := make([]model.Issue, 0, len(query.Milestone.Issues.Nodes))
-	for _, node := range query.Milestone.Issues.Nodes {
-		issue := model.Issue{
-			ID:         node.ID,
-			Identifier: node.Identifier,
-			Title:      node.Title,
-			State: &model.State{
-				Name: node.State.Name,
-				Type: node.State.Type,
-			},
+		var query MilestoneIssuesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
 		}
 
-		milestoneIssues = append(milestoneIssues, issue)
-	}
+		milestoneIssues := make([]model.Issue, 0, len(query.Milestone.Issues.Nodes))
+		for _, node := range query.Milestone.Issues.Nodes {
+			issue := model.Issue{
+				ID:         node.ID,
+				Identifier: node.Identifier,
+				Title:      node.Title,
+				State: &model.State{
+					Name: node.State.Name,
+					Type: node.State.Type,
+				},
+			}
+
+			milestoneIssues = append(milestoneIssues, issue)
+		}
 
-	return milestoneIssues, nil
+		return milestoneIssues, query.Milestone.Issues.PageInfo, nil
+	})
 }
 
 // InitiativesQuery represents the GraphQL initiatives query
 type InitiativesQuery struct {
 	Initiatives struct {
 		Nodes []struct {
-			ID          string `graphql:"id"`
-			Name        string `graphql:"name"`
-			Description string `graphql:"description"`
-			CreatedAt   string `graphql:"createdAt"`
-			UpdatedAt   string `graphql:"updatedAt"`
+			ID          string       `graphql:"id"`
+			Name        string       `graphql:"name"`
+			Description string       `graphql:"description"`
+			Status      string       `graphql:"status"`
+			Owner       *userRefNode `graphql:"owner"`
+			TargetDate  *string      `graphql:"targetDate"`
+			CreatedAt   string       `graphql:"createdAt"`
+			UpdatedAt   string       `graphql:"updatedAt"`
 		} `graphql:"nodes"`
-	} `graphql:"initiatives"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"initiatives(first: $first, after: $after)"`
+}
+
+// userRefNode is a user referenced from another entity, e.g. an owner
+type userRefNode struct {
+	ID   string `graphql:"id"`
+	Name string `graphql:"name"`
+}
+
+// toModel converts the reference into a model.User, or nil if unset
+func (n *userRefNode) toModel() *model.User {
+	if n == nil {
+		return nil
+	}
+	return &model.User{ID: n.ID, Name: n.Name}
 }
 
 // ListInitiatives fetches all initiatives
 func (c *Client) ListInitiatives(ctx context.Context) ([]model.Initiative, error) {
-	var query InitiativesQuery
-	if err := c.Query(ctx, &query, nil); err != nil {
-		return nil, err
-	}
+	return pages(ctx, c, func(first int, after *string) ([]model.Initiative, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
 
-	initiatives := make([]model.Initiative, 0, len(query.Initiatives.Nodes))
-	for _, node := range query.Initiatives.Nodes {
-		initiatives = append(initiatives, model.Initiative{
-			ID:          node.ID,
-			Name:        node.Name,
-			Description: node.Description,
-		})
+		var query InitiativesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		initiatives := make([]model.Initiative, 0, len(query.Initiatives.Nodes))
+		for _, node := range query.Initiatives.Nodes {
+			initiatives = append(initiatives, model.Initiative{
+				ID:          node.ID,
+				Name:        node.Name,
+				Description: node.Description,
+				Status:      node.Status,
+				Owner:       node.Owner.toModel(),
+				TargetDate:  parseDate(node.TargetDate),
+				CreatedAt:   parseTime(node.CreatedAt),
+				UpdatedAt:   parseTime(node.UpdatedAt),
+			})
+		}
+
+		return initiatives, query.Initiatives.PageInfo, nil
+	})
+}
+
+// InitiativeSortFields are the valid values for initiative list --sort
+var InitiativeSortFields = []string{"target-date", "name"}
+
+// SortInitiatives orders initiatives in place by target-date (the default)
+// or name, ascending unless reverse. Initiatives without a target date
+// always sort last.
+func SortInitiatives(initiatives []model.Initiative, by string, reverse bool) {
+	compare := func(a, b model.Initiative) int {
+		if by == "name" {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+		return a.TargetDate.Compare(*b.TargetDate)
 	}
 
-	return initiatives, nil
+	sort.SliceStable(initiatives, func(i, j int) bool {
+		a, b := initiatives[i], initiatives[j]
+		if by != "name" && (a.TargetDate == nil || b.TargetDate == nil) {
+			return a.TargetDate != nil && b.TargetDate == nil
+		}
+		if reverse {
+			return compare(a, b) > 0
+		}
+		return compare(a, b) < 0
+	})
 }
 
 // InitiativeQuery represents a single initiative query
 type InitiativeQuery struct {
 	Initiative struct {
-		ID          string `graphql:"id"`
-		Name        string `graphql:"name"`
-		Description string `graphql:"description"`
+		ID          string       `graphql:"id"`
+		Name        string       `graphql:"name"`
+		Description string       `graphql:"description"`
+		Status      string       `graphql:"status"`
+		Health      *string      `graphql:"health"`
+		Owner       *userRefNode `graphql:"owner"`
+		TargetDate  *string      `graphql:"targetDate"`
 		Projects    struct {
 			Nodes []struct {
-				ID   string `graphql:"id"`
-				Name string `graphql:"name"`
+				ID       string  `graphql:"id"`
+				Name     string  `graphql:"name"`
+				State    string  `graphql:"state"`
+				Progress float64 `graphql:"progress"`
+				Scope    float64 `graphql:"scope"`
 			} `graphql:"nodes"`
 		} `graphql:"projects"`
 		CreatedAt string `graphql:"createdAt"`
@@ -1114,7 +2212,8 @@ type InitiativeQuery struct {
 	} `graphql:"initiative(id: $id)"`
 }
 
-// GetInitiative fetches a single initiative by ID
+// GetInitiative fetches a single initiative by ID, including its projects
+// and a progress rollup across them
 func (c *Client) GetInitiative(ctx context.Context, id string) (*model.Initiative, error) {
 	variables := map[string]interface{}{
 		"id": id,
@@ -1129,11 +2228,56 @@ func (c *Client) GetInitiative(ctx context.Context, id string) (*model.Initiativ
 		ID:          query.Initiative.ID,
 		Name:        query.Initiative.Name,
 		Description: query.Initiative.Description,
+		Status:      query.Initiative.Status,
+		Owner:       query.Initiative.Owner.toModel(),
+		TargetDate:  parseDate(query.Initiative.TargetDate),
+		CreatedAt:   parseTime(query.Initiative.CreatedAt),
+		UpdatedAt:   parseTime(query.Initiative.UpdatedAt),
+	}
+
+	if query.Initiative.Health != nil {
+		initiative.Health = *query.Initiative.Health
+	}
+
+	if len(query.Initiative.Projects.Nodes) > 0 {
+		initiative.Projects = make([]model.Project, len(query.Initiative.Projects.Nodes))
+		for i, node := range query.Initiative.Projects.Nodes {
+			initiative.Projects[i] = model.Project{
+				ID:       node.ID,
+				Name:     node.Name,
+				State:    node.State,
+				Progress: node.Progress,
+				Scope:    node.Scope,
+			}
+		}
 	}
 
+	initiative.Progress = InitiativeProgress(initiative.Projects)
+
 	return initiative, nil
 }
 
+// InitiativeProgress computes the completion fraction (0-1) across
+// projects, like a project's own progress, weighting each project's progress
+// by its scope. When no project has a scope, progress is a plain average.
+func InitiativeProgress(projects []model.Project) float64 {
+	if len(projects) == 0 {
+		return 0
+	}
+
+	var weighted, totalScope, sum float64
+	for _, p := range projects {
+		weighted += p.Progress * p.Scope
+		totalScope += p.Scope
+		sum += p.Progress
+	}
+
+	if totalScope > 0 {
+		return weighted / totalScope
+	}
+	return sum / float64(len(projects))
+}
+
 // CreateInitiativeMutation represents the initiative creation mutation
 type CreateInitiativeMutation struct {
 	InitiativeCreate struct {
@@ -1267,31 +2411,36 @@ type InitiativeProjectsQuery struct {
 				Name  string `graphql:"name"`
 				State string `graphql:"state"`
 			} `graphql:"nodes"`
-		} `graphql:"projects"`
+			PageInfo pageInfo `graphql:"pageInfo"`
+		} `graphql:"projects(first: $first, after: $after)"`
 	} `graphql:"initiative(id: $id)"`
 }
 
 // ListInitiativeProjects fetches projects for an initiative
 func (c *Client) ListInitiativeProjects(ctx context.Context, initiativeID string) ([]model.Project, error) {
-	variables := map[string]interface{}{
-		"id": initiativeID,
-	}
+	return pages(ctx, c, func(first int, after *string) ([]model.Project, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+			"id":    initiativeID,
+		}
 
-	var query InitiativeProjectsQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
-	}
+		var query InitiativeProjectsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
 
-	projects := make([]model.Project, 0, len(query.Initiative.Projects.Nodes))
-	for _, node := range query.Initiative.Projects.Nodes {
-		projects = append(projects, model.Project{
-			ID:    node.ID,
-			Name:  node.Name,
-			State: node.State,
-		})
-	}
+		projects := make([]model.Project, 0, len(query.Initiative.Projects.Nodes))
+		for _, node := range query.Initiative.Projects.Nodes {
+			projects = append(projects, model.Project{
+				ID:    node.ID,
+				Name:  node.Name,
+				State: node.State,
+			})
+		}
 
-	return projects, nil
+		return projects, query.Initiative.Projects.PageInfo, nil
+	})
 }
 
 // UsersQuery represents the GraphQL users query
@@ -1303,29 +2452,43 @@ type UsersQuery struct {
 			Email       string `graphql:"email"`
 			DisplayName string `graphql:"displayName"`
 			Active      bool   `graphql:"active"`
+			Admin       bool   `graphql:"admin"`
+			Timezone    string `graphql:"timezone"`
+			AvatarURL   string `graphql:"avatarUrl"`
 		} `graphql:"nodes"`
-	} `graphql:"users"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"users(first: $first, after: $after)"`
 }
 
 // ListUsers fetches all users
 func (c *Client) ListUsers(ctx context.Context) ([]model.User, error) {
-	var query UsersQuery
-	if err := c.Query(ctx, &query, nil); err != nil {
-		return nil, err
-	}
+	return pages(ctx, c, func(first int, after *string) ([]model.User, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
 
-	users := make([]model.User, 0, len(query.Users.Nodes))
-	for _, node := range query.Users.Nodes {
-		users = append(users, model.User{
-			ID:          node.ID,
-			Name:        node.Name,
-			Email:       node.Email,
-			DisplayName: node.DisplayName,
-			Active:      node.Active,
-		})
-	}
+		var query UsersQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		users := make([]model.User, 0, len(query.Users.Nodes))
+		for _, node := range query.Users.Nodes {
+			users = append(users, model.User{
+				ID:          node.ID,
+				Name:        node.Name,
+				Email:       node.Email,
+				DisplayName: node.DisplayName,
+				Active:      node.Active,
+				Admin:       node.Admin,
+				Timezone:    node.Timezone,
+				AvatarURL:   node.AvatarURL,
+			})
+		}
 
-	return users, nil
+		return users, query.Users.PageInfo, nil
+	})
 }
 
 // UserQuery represents a single user query
@@ -1336,11 +2499,15 @@ type UserQuery struct {
 		Email       string `graphql:"email"`
 		DisplayName string `graphql:"displayName"`
 		Active      bool   `graphql:"active"`
+		Admin       bool   `graphql:"admin"`
+		Timezone    string `graphql:"timezone"`
+		AvatarURL   string `graphql:"avatarUrl"`
 	} `graphql:"user(id: $id)"`
 }
 
-// GetUser fetches a single user by ID
-func (c *Client) GetUser(ctx context.Context, id string) (*model.User, error) {
+// GetUser fetches a single user by ID. With includeCounts, the user's
+// assigned and created issues are paginated to fill in their counts.
+func (c *Client) GetUser(ctx context.Context, id string, includeCounts bool) (*model.User, error) {
 	variables := map[string]interface{}{
 		"id": id,
 	}
@@ -1356,108 +2523,301 @@ func (c *Client) GetUser(ctx context.Context, id string) (*model.User, error) {
 		Email:       query.User.Email,
 		DisplayName: query.User.DisplayName,
 		Active:      query.User.Active,
+		Admin:       query.User.Admin,
+		Timezone:    query.User.Timezone,
+		AvatarURL:   query.User.AvatarURL,
+	}
+
+	if includeCounts {
+		assigned, assignedCapped, err := c.countUserIssues(ctx, id, UserIssuesAssigned)
+		if err != nil {
+			return nil, err
+		}
+		created, createdCapped, err := c.countUserIssues(ctx, id, UserIssuesCreated)
+		if err != nil {
+			return nil, err
+		}
+		user.AssignedIssueCount, user.AssignedIssuesCapped = &assigned, assignedCapped
+		user.CreatedIssueCount, user.CreatedIssuesCapped = &created, createdCapped
 	}
 
 	return user, nil
 }
 
-// UserIssuesQuery represents issues for a user
+// IssueCountCap is the most issues GetUser counts per relation; a user with
+// more is reported as having more than IssueCountCap
+const IssueCountCap = 500
+
+// countPageSize is the page size of ID-only count queries, Linear's maximum
+const countPageSize = 250
+
+// userIssueIDConnection is a page of a user's issue IDs, for counting
+type userIssueIDConnection struct {
+	Nodes []struct {
+		ID string `graphql:"id"`
+	} `graphql:"nodes"`
+	PageInfo pageInfo `graphql:"pageInfo"`
+}
+
+// UserIssueIDsQuery represents the IDs of issues assigned to a user
+type UserIssueIDsQuery struct {
+	User struct {
+		AssignedIssues userIssueIDConnection `graphql:"assignedIssues(first: $first, after: $after)"`
+	} `graphql:"user(id: $id)"`
+}
+
+// UserCreatedIssueIDsQuery represents the IDs of issues created by a user
+type UserCreatedIssueIDsQuery struct {
+	User struct {
+		CreatedIssues userIssueIDConnection `graphql:"createdIssues(first: $first, after: $after)"`
+	} `graphql:"user(id: $id)"`
+}
+
+// countUserIssues counts a user's assigned or created issues by fetching
+// only their IDs, stopping past IssueCountCap. capped reports that there are
+// more than IssueCountCap, which is then the count returned.
+func (c *Client) countUserIssues(ctx context.Context, userID string, relation UserIssueRelation) (count int, capped bool, err error) {
+	ids, err := collectPages(ctx, countPageSize, IssueCountCap+1, c.retry, func(first int, after *string) ([]string, pageInfo, error) {
+		variables := map[string]interface{}{
+			"id":    userID,
+			"first": first,
+			"after": after,
+		}
+
+		var conn userIssueIDConnection
+		var err error
+		if relation == UserIssuesCreated {
+			var query UserCreatedIssueIDsQuery
+			err = c.Query(ctx, &query, variables)
+			conn = query.User.CreatedIssues
+		} else {
+			var query UserIssueIDsQuery
+			err = c.Query(ctx, &query, variables)
+			conn = query.User.AssignedIssues
+		}
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		ids := make([]string, 0, len(conn.Nodes))
+		for _, node := range conn.Nodes {
+			ids = append(ids, node.ID)
+		}
+		return ids, conn.PageInfo, nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(ids) > IssueCountCap {
+		return IssueCountCap, true, nil
+	}
+	return len(ids), false, nil
+}
+
+// userIssueNode is the issue shape returned for a user's assigned or
+// created issues
+type userIssueNode struct {
+	ID         string `graphql:"id"`
+	Identifier string `graphql:"identifier"`
+	Title      string `graphql:"title"`
+	Priority   int    `graphql:"priority"`
+	State      struct {
+		Name string `graphql:"name"`
+		Type string `graphql:"type"`
+	} `graphql:"state"`
+	Team struct {
+		Key string `graphql:"key"`
+	} `graphql:"team"`
+	Project *struct {
+		ID   string `graphql:"id"`
+		Name string `graphql:"name"`
+	} `graphql:"project"`
+}
+
+// UserIssueRelation selects which of a user's issue connections to list
+type UserIssueRelation string
+
+const (
+	// UserIssuesAssigned lists issues assigned to the user
+	UserIssuesAssigned UserIssueRelation = "assigned"
+	// UserIssuesCreated lists issues the user filed
+	UserIssuesCreated UserIssueRelation = "created"
+)
+
+// userIssueConnection is a page of a user's issues
+type userIssueConnection struct {
+	Nodes    []userIssueNode `graphql:"nodes"`
+	PageInfo pageInfo        `graphql:"pageInfo"`
+}
+
+// UserIssuesQuery represents issues assigned to a user
 type UserIssuesQuery struct {
 	User struct {
-		AssignedIssues struct {
-			Nodes []struct {
-				ID         string `graphql:"id"`
-				Identifier string `graphql:"identifier"`
-				Title      string `graphql:"title"`
-				State      struct {
-					Name string `graphql:"name"`
-					Type string `graphql:"type"`
-				} `graphql:"state"`
-				Team struct {
-					Key string `graphql:"key"`
-				} `graphql:"team"`
-			} `graphql:"nodes"`
-		} `graphql:"assignedIssues"`
+		AssignedIssues userIssueConnection `graphql:"assignedIssues(filter: $filter, first: $first, after: $after)"`
 	} `graphql:"user(id: $id)"`
 }
 
-// ListUserIssues fetches issues assigned to a user
-func (c *Client) ListUserIssues(ctx context.Context, userID string) ([]model.Issue, error) {
-	variables := map[string]interface{}{
-		"id": userID,
-	}
+// UserCreatedIssuesQuery represents issues created by a user
+type UserCreatedIssuesQuery struct {
+	User struct {
+		CreatedIssues userIssueConnection `graphql:"createdIssues(filter: $filter, first: $first, after: $after)"`
+	} `graphql:"user(id: $id)"`
+}
 
-	var query UserIssuesQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
-		return nil, err
+// queryUserIssues fetches one page of the requested user issue connection
+func (c *Client) queryUserIssues(ctx context.Context, relation UserIssueRelation, variables map[string]interface{}) (userIssueConnection, error) {
+	if relation == UserIssuesCreated {
+		var query UserCreatedIssuesQuery
+		err := c.Query(ctx, &query, variables)
+		return query.User.CreatedIssues, err
 	}
+	var query UserIssuesQuery
+	err := c.Query(ctx, &query, variables)
+	return query.User.AssignedIssues, err
+}
 
-	issues := make([]model.Issue, 0, len(query.User.AssignedIssues.Nodes))
-	for _, node := range query.User.AssignedIssues.Nodes {
-		issue := model.Issue{
-			ID:         node.ID,
-			Identifier: node.Identifier,
-			Title:      node.Title,
-			State: &model.State{
-				Name: node.State.Name,
-				Type: node.State.Type,
-			},
-			Team: &model.Team{
-				Key: node.Team.Key,
-			},
+// ListUserIssues fetches issues assigned to or created by a user, optionally
+// narrowed by filters, following pagination up to the ctx limit
+func (c *Client) ListUserIssues(ctx context.Context, userID string, relation UserIssueRelation, filters *IssueFilters) ([]model.Issue, error) {
+	filter := issueFilter(filters)
+
+	return pages(ctx, c, func(first int, after *string) ([]model.Issue, pageInfo, error) {
+		variables := map[string]interface{}{
+			"id":     userID,
+			"first":  first,
+			"after":  after,
+			"filter": filter,
 		}
 
-		issues = append(issues, issue)
-	}
+		conn, err := c.queryUserIssues(ctx, relation, variables)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		issues := make([]model.Issue, 0, len(conn.Nodes))
+		for _, node := range conn.Nodes {
+			issue := model.Issue{
+				ID:         node.ID,
+				Identifier: node.Identifier,
+				Title:      node.Title,
+				Priority:   node.Priority,
+				State: &model.State{
+					Name: node.State.Name,
+					Type: node.State.Type,
+				},
+				Team: &model.Team{
+					Key: node.Team.Key,
+				},
+			}
+
+			if node.Project != nil {
+				issue.Project = &model.Project{
+					ID:   node.Project.ID,
+					Name: node.Project.Name,
+				}
+			}
+
+			issues = append(issues, issue)
+		}
 
-	return issues, nil
+		return issues, conn.PageInfo, nil
+	})
 }
 
 // CommentsQuery represents the GraphQL comments query
 type CommentsQuery struct {
 	Comments struct {
 		Nodes []struct {
-			ID        string `graphql:"id"`
-			Body      string `graphql:"body"`
-			User      struct {
+			ID   string `graphql:"id"`
+			Body string `graphql:"body"`
+			User struct {
 				ID   string `graphql:"id"`
 				Name string `graphql:"name"`
 			} `graphql:"user"`
 			CreatedAt string `graphql:"createdAt"`
 			UpdatedAt string `graphql:"updatedAt"`
 		} `graphql:"nodes"`
-	} `graphql:"comments(filter: $filter)"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"comments(filter: $filter, orderBy: $orderBy, first: $first, after: $after)"`
 }
 
-// ListIssueComments fetches comments for an issue
-func (c *Client) ListIssueComments(ctx context.Context, issueID string) ([]model.Comment, error) {
-	variables := map[string]interface{}{
-		"filter": map[string]interface{}{
-			"issue": map[string]interface{}{
-				"id": map[string]interface{}{
-					"eq": issueID,
-				},
+// PaginationOrderBy is Linear's PaginationOrderBy enum, the field a
+// connection is ordered by (createdAt or updatedAt)
+type PaginationOrderBy string
+
+// CommentOrder is the order comments are listed in
+type CommentOrder string
+
+const (
+	CommentsOldest CommentOrder = "oldest" // Chronological, for reading a discussion
+	CommentsNewest CommentOrder = "newest" // Latest first
+)
+
+// CommentOrders are the values accepted by comment list --order
+var CommentOrders = []string{string(CommentsOldest), string(CommentsNewest)}
+
+// ListIssueComments fetches comments for an issue in the given order. The
+// comments connection returns the newest first, so with a limit from ctx
+// newest-first stops after that many, while oldest-first fetches every
+// page to find the oldest ones.
+func (c *Client) ListIssueComments(ctx context.Context, issueID string, order CommentOrder) ([]model.Comment, error) {
+	limit := limitFrom(ctx)
+	if order == CommentsOldest {
+		ctx = WithLimit(ctx, 0)
+	}
+
+	filter := CommentFilter{
+		"issue": map[string]interface{}{
+			"id": map[string]interface{}{
+				"eq": issueID,
 			},
 		},
 	}
 
-	var query CommentsQuery
-	if err := c.Query(ctx, &query, variables); err != nil {
+	comments, err := pages(ctx, c, func(first int, after *string) ([]model.Comment, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first":   first,
+			"after":   after,
+			"orderBy": PaginationOrderBy("createdAt"),
+			"filter":  &filter,
+		}
+
+		var query CommentsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		comments := make([]model.Comment, 0, len(query.Comments.Nodes))
+		for _, node := range query.Comments.Nodes {
+			comments = append(comments, model.Comment{
+				ID:   node.ID,
+				Body: node.Body,
+				User: &model.User{
+					ID:   node.User.ID,
+					Name: node.User.Name,
+				},
+				CreatedAt: parseTime(node.CreatedAt),
+				UpdatedAt: parseTime(node.UpdatedAt),
+			})
+		}
+
+		return comments, query.Comments.PageInfo, nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	comments := make([]model.Comment, 0, len(query.Comments.Nodes))
-	for _, node := range query.Comments.Nodes {
-		comments = append(comments, model.Comment{
-			ID:   node.ID,
-			Body: node.Body,
-			User: &model.User{
-				ID:   node.User.ID,
-				Name: node.User.Name,
-			},
-		})
+	// The pages come newest first; sort them into the requested order
+	sort.SliceStable(comments, func(i, j int) bool {
+		if order == CommentsNewest {
+			return comments[i].CreatedAt.After(comments[j].CreatedAt)
+		}
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	if limit > 0 && len(comments) > limit {
+		comments = comments[:limit]
 	}
-
 	return comments, nil
 }
 
@@ -1558,3 +2918,302 @@ func (c *Client) DeleteComment(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// LabelsQuery represents the issue labels list query
+type LabelsQuery struct {
+	IssueLabels struct {
+		Nodes []struct {
+			ID          string `graphql:"id"`
+			Name        string `graphql:"name"`
+			Color       string `graphql:"color"`
+			Description string `graphql:"description"`
+			Team        *struct {
+				ID   string `graphql:"id"`
+				Key  string `graphql:"key"`
+				Name string `graphql:"name"`
+			} `graphql:"team"`
+		} `graphql:"nodes"`
+		PageInfo pageInfo `graphql:"pageInfo"`
+	} `graphql:"issueLabels(first: $first, after: $after)"`
+}
+
+// ListLabels fetches all issue labels, both workspace and team labels
+func (c *Client) ListLabels(ctx context.Context) ([]model.Label, error) {
+	return pages(ctx, c, func(first int, after *string) ([]model.Label, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
+
+		var query LabelsQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		labels := make([]model.Label, 0, len(query.IssueLabels.Nodes))
+		for _, node := range query.IssueLabels.Nodes {
+			label := model.Label{
+				ID:          node.ID,
+				Name:        node.Name,
+				Color:       node.Color,
+				Description: node.Description,
+			}
+			if node.Team != nil {
+				label.Team = &model.Team{
+					ID:   node.Team.ID,
+					Key:  node.Team.Key,
+					Name: node.Team.Name,
+				}
+			}
+			labels = append(labels, label)
+		}
+
+		return labels, query.IssueLabels.PageInfo, nil
+	})
+}
+
+// TeamLabels returns the labels available to a team: its own labels and
+// workspace labels, in the given order
+func TeamLabels(labels []model.Label, teamID string) []model.Label {
+	result := []model.Label{}
+	for _, label := range labels {
+		if label.Team == nil || label.Team.ID == teamID {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+// FindLabel picks the label named (case-insensitively) or identified by
+// nameOrID. Team labels can share a name across teams; teamKey, if set,
+// narrows matches to that team's labels plus workspace labels.
+func FindLabel(labels []model.Label, nameOrID, teamKey string) (*model.Label, error) {
+	matches := []model.Label{}
+	for _, label := range labels {
+		if label.ID == nameOrID {
+			return &label, nil
+		}
+		if !strings.EqualFold(label.Name, nameOrID) {
+			continue
+		}
+		if teamKey != "" && label.Team != nil && !strings.EqualFold(label.Team.Key, teamKey) {
+			continue
+		}
+		matches = append(matches, label)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("label not found: %s", nameOrID)
+	case 1:
+		return &matches[0], nil
+	}
+
+	teams := make([]string, len(matches))
+	for i, label := range matches {
+		teams[i] = "workspace"
+		if label.Team != nil {
+			teams[i] = label.Team.Key
+		}
+	}
+	return nil, fmt.Errorf("label %q is ambiguous (defined for: %s) - pass --team or the label ID", nameOrID, strings.Join(teams, ", "))
+}
+
+// AddIssueLabelMutation represents the mutation adding a label to an issue
+type AddIssueLabelMutation struct {
+	IssueAddLabel struct {
+		Success bool `graphql:"success"`
+	} `graphql:"issueAddLabel(id: $id, labelId: $labelId)"`
+}
+
+// RemoveIssueLabelMutation represents the mutation removing a label from an issue
+type RemoveIssueLabelMutation struct {
+	IssueRemoveLabel struct {
+		Success bool `graphql:"success"`
+	} `graphql:"issueRemoveLabel(id: $id, labelId: $labelId)"`
+}
+
+// AddIssueLabel adds a label to an issue, leaving its other labels intact
+func (c *Client) AddIssueLabel(ctx context.Context, issueID, labelID string) error {
+	variables := map[string]interface{}{
+		"id":      issueID,
+		"labelId": labelID,
+	}
+
+	var mutation AddIssueLabelMutation
+	if err := c.Mutate(ctx, &mutation, variables); err != nil {
+		return err
+	}
+
+	if !mutation.IssueAddLabel.Success {
+		return fmt.Errorf("failed to add label")
+	}
+
+	return nil
+}
+
+// RemoveIssueLabel removes a label from an issue, leaving its other labels intact
+func (c *Client) RemoveIssueLabel(ctx context.Context, issueID, labelID string) error {
+	variables := map[string]interface{}{
+		"id":      issueID,
+		"labelId": labelID,
+	}
+
+	var mutation RemoveIssueLabelMutation
+	if err := c.Mutate(ctx, &mutation, variables); err != nil {
+		return err
+	}
+
+	if !mutation.IssueRemoveLabel.Success {
+		return fmt.Errorf("failed to remove label")
+	}
+
+	return nil
+}
+
+// favoriteNode is the favorite shape shared by favorite queries and mutations
+type favoriteNode struct {
+	ID    string `graphql:"id"`
+	Type  string `graphql:"type"`
+	Issue *struct {
+		ID         string `graphql:"id"`
+		Identifier string `graphql:"identifier"`
+		Title      string `graphql:"title"`
+	} `graphql:"issue"`
+	Project *struct {
+		ID   string `graphql:"id"`
+		Name string `graphql:"name"`
+	} `graphql:"project"`
+	CreatedAt string `graphql:"createdAt"`
+}
+
+// toModel converts a favorite node into a model.Favorite
+func (node favoriteNode) toModel() model.Favorite {
+	favorite := model.Favorite{
+		ID:        node.ID,
+		Type:      node.Type,
+		CreatedAt: parseTime(node.CreatedAt),
+	}
+	if node.Issue != nil {
+		favorite.Issue = &model.Issue{
+			ID:         node.Issue.ID,
+			Identifier: node.Issue.Identifier,
+			Title:      node.Issue.Title,
+		}
+	}
+	if node.Project != nil {
+		favorite.Project = &model.Project{
+			ID:   node.Project.ID,
+			Name: node.Project.Name,
+		}
+	}
+	return favorite
+}
+
+// FavoritesQuery represents the viewer's favorites query
+type FavoritesQuery struct {
+	Favorites struct {
+		Nodes    []favoriteNode `graphql:"nodes"`
+		PageInfo pageInfo       `graphql:"pageInfo"`
+	} `graphql:"favorites(first: $first, after: $after)"`
+}
+
+// ListFavorites fetches the viewer's favorites. Only issue and project
+// favorites are returned; views, cycles, and other kinds are skipped.
+func (c *Client) ListFavorites(ctx context.Context) ([]model.Favorite, error) {
+	return pages(ctx, c, func(first int, after *string) ([]model.Favorite, pageInfo, error) {
+		variables := map[string]interface{}{
+			"first": first,
+			"after": after,
+		}
+
+		var query FavoritesQuery
+		if err := c.Query(ctx, &query, variables); err != nil {
+			return nil, pageInfo{}, err
+		}
+
+		favorites := make([]model.Favorite, 0, len(query.Favorites.Nodes))
+		for _, node := range query.Favorites.Nodes {
+			if node.Issue == nil && node.Project == nil {
+				continue
+			}
+			favorites = append(favorites, node.toModel())
+		}
+
+		return favorites, query.Favorites.PageInfo, nil
+	})
+}
+
+// CreateFavoriteMutation represents the favorite creation mutation
+type CreateFavoriteMutation struct {
+	FavoriteCreate struct {
+		Success  bool         `graphql:"success"`
+		Favorite favoriteNode `graphql:"favorite"`
+	} `graphql:"favoriteCreate(input: $input)"`
+}
+
+// CreateFavoriteInput represents input for favoriting an issue or project
+type CreateFavoriteInput struct {
+	IssueID   *string `json:"issueId,omitempty"`
+	ProjectID *string `json:"projectId,omitempty"`
+}
+
+// CreateFavorite adds an issue or project to the viewer's favorites
+func (c *Client) CreateFavorite(ctx context.Context, input *CreateFavoriteInput) (*model.Favorite, error) {
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var mutation CreateFavoriteMutation
+	if err := c.Mutate(ctx, &mutation, variables); err != nil {
+		return nil, err
+	}
+
+	if !mutation.FavoriteCreate.Success {
+		return nil, fmt.Errorf("failed to create favorite")
+	}
+
+	favorite := mutation.FavoriteCreate.Favorite.toModel()
+	return &favorite, nil
+}
+
+// DeleteFavoriteMutation represents the favorite deletion mutation
+type DeleteFavoriteMutation struct {
+	FavoriteDelete struct {
+		Success bool `graphql:"success"`
+	} `graphql:"favoriteDelete(id: $id)"`
+}
+
+// DeleteFavorite removes a favorite by its favorite ID
+func (c *Client) DeleteFavorite(ctx context.Context, id string) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var mutation DeleteFavoriteMutation
+	if err := c.Mutate(ctx, &mutation, variables); err != nil {
+		return err
+	}
+
+	if !mutation.FavoriteDelete.Success {
+		return fmt.Errorf("failed to delete favorite")
+	}
+
+	return nil
+}
+
+// FindFavorite picks the favorite with the given favorite ID, or whose
+// issue (by ID or case-insensitive identifier) or project (by ID) is ref
+func FindFavorite(favorites []model.Favorite, ref string) (*model.Favorite, error) {
+	for _, favorite := range favorites {
+		switch {
+		case favorite.ID == ref:
+		case favorite.Issue != nil && (favorite.Issue.ID == ref || strings.EqualFold(favorite.Issue.Identifier, ref)):
+		case favorite.Project != nil && favorite.Project.ID == ref:
+		default:
+			continue
+		}
+		return &favorite, nil
+	}
+	return nil, fmt.Errorf("not a favorite: %s", ref)
+}
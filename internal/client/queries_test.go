@@ -0,0 +1,1643 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// TestBuildIssueFilter verifies that IssueFilters are translated into the
+// Linear IssueFilter shape, including null clauses for unset fields.
+func TestBuildIssueFilter(t *testing.T) {
+	teamID := "team-1"
+	teamKey := "ENG"
+	assigneeID := "user-1"
+	projectID := "project-1"
+	milestoneID := "milestone-1"
+	parentID := "issue-100"
+	stateID := "state-1"
+	stateType := "started"
+	updatedAfter := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filters  *IssueFilters
+		expected map[string]interface{}
+	}{
+		{
+			name:     "Nil filters",
+			filters:  nil,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:    "Assignee by ID",
+			filters: &IssueFilters{AssigneeID: &assigneeID},
+			expected: map[string]interface{}{
+				"assignee": map[string]interface{}{"id": map[string]interface{}{"eq": assigneeID}},
+			},
+		},
+		{
+			name:    "Unassigned",
+			filters: &IssueFilters{Unassigned: true},
+			expected: map[string]interface{}{
+				"assignee": map[string]interface{}{"null": true},
+			},
+		},
+		{
+			name:    "Unassigned takes precedence over assignee ID",
+			filters: &IssueFilters{Unassigned: true, AssigneeID: &assigneeID},
+			expected: map[string]interface{}{
+				"assignee": map[string]interface{}{"null": true},
+			},
+		},
+		{
+			name:    "Creator by ID",
+			filters: &IssueFilters{CreatorID: &assigneeID},
+			expected: map[string]interface{}{
+				"creator": map[string]interface{}{"id": map[string]interface{}{"eq": assigneeID}},
+			},
+		},
+		{
+			name:    "Creator with assignee",
+			filters: &IssueFilters{CreatorID: &assigneeID, Unassigned: true},
+			expected: map[string]interface{}{
+				"assignee": map[string]interface{}{"null": true},
+				"creator":  map[string]interface{}{"id": map[string]interface{}{"eq": assigneeID}},
+			},
+		},
+		{
+			name:    "Milestone by ID",
+			filters: &IssueFilters{MilestoneID: &milestoneID},
+			expected: map[string]interface{}{
+				"projectMilestone": map[string]interface{}{"id": map[string]interface{}{"eq": milestoneID}},
+			},
+		},
+		{
+			name:    "Project and milestone",
+			filters: &IssueFilters{ProjectID: &projectID, MilestoneID: &milestoneID},
+			expected: map[string]interface{}{
+				"project":          map[string]interface{}{"id": map[string]interface{}{"eq": projectID}},
+				"projectMilestone": map[string]interface{}{"id": map[string]interface{}{"eq": milestoneID}},
+			},
+		},
+		{
+			name:    "Multiple teams",
+			filters: &IssueFilters{TeamIDs: []string{"team-1", "team-2"}},
+			expected: map[string]interface{}{
+				"team": map[string]interface{}{"id": map[string]interface{}{"in": []string{"team-1", "team-2"}}},
+			},
+		},
+		{
+			name:    "Multiple teams take precedence over one",
+			filters: &IssueFilters{TeamID: &teamID, TeamIDs: []string{"team-2", "team-3"}},
+			expected: map[string]interface{}{
+				"team": map[string]interface{}{"id": map[string]interface{}{"in": []string{"team-2", "team-3"}}},
+			},
+		},
+		{
+			name:    "Parent by ID",
+			filters: &IssueFilters{ParentID: &parentID},
+			expected: map[string]interface{}{
+				"parent": map[string]interface{}{"id": map[string]interface{}{"eq": parentID}},
+			},
+		},
+		{
+			name:    "Parent with other filters",
+			filters: &IssueFilters{ParentID: &parentID, Unassigned: true},
+			expected: map[string]interface{}{
+				"parent":   map[string]interface{}{"id": map[string]interface{}{"eq": parentID}},
+				"assignee": map[string]interface{}{"null": true},
+			},
+		},
+		{
+			name:    "Project by ID",
+			filters: &IssueFilters{ProjectID: &projectID},
+			expected: map[string]interface{}{
+				"project": map[string]interface{}{"id": map[string]interface{}{"eq": projectID}},
+			},
+		},
+		{
+			name:    "Updated after",
+			filters: &IssueFilters{UpdatedAfter: &updatedAfter},
+			expected: map[string]interface{}{
+				"updatedAt": map[string]interface{}{"gt": "2026-01-02T03:04:05Z"},
+			},
+		},
+		{
+			name:    "State type",
+			filters: &IssueFilters{StateType: &stateType},
+			expected: map[string]interface{}{
+				"state": map[string]interface{}{"type": map[string]interface{}{"eq": stateType}},
+			},
+		},
+		{
+			name:    "State ID and type",
+			filters: &IssueFilters{StateID: &stateID, StateType: &stateType},
+			expected: map[string]interface{}{
+				"state": map[string]interface{}{
+					"id":   map[string]interface{}{"eq": stateID},
+					"type": map[string]interface{}{"eq": stateType},
+				},
+			},
+		},
+		{
+			name:    "Overdue",
+			filters: &IssueFilters{Overdue: &updatedAfter},
+			expected: map[string]interface{}{
+				"dueDate": map[string]interface{}{"lt": "2026-01-02"},
+				"state":   map[string]interface{}{"type": map[string]interface{}{"nin": []string{"completed", "canceled"}}},
+			},
+		},
+		{
+			name:    "Overdue in a state",
+			filters: &IssueFilters{Overdue: &updatedAfter, StateID: &stateID},
+			expected: map[string]interface{}{
+				"dueDate": map[string]interface{}{"lt": "2026-01-02"},
+				"state": map[string]interface{}{
+					"id":   map[string]interface{}{"eq": stateID},
+					"type": map[string]interface{}{"nin": []string{"completed", "canceled"}},
+				},
+			},
+		},
+		{
+			name:    "No due date",
+			filters: &IssueFilters{NoDueDate: true},
+			expected: map[string]interface{}{
+				"dueDate": map[string]interface{}{"null": true},
+			},
+		},
+		{
+			name:    "No labels",
+			filters: &IssueFilters{NoLabels: true},
+			expected: map[string]interface{}{
+				"labels": map[string]interface{}{"length": map[string]interface{}{"eq": 0}},
+			},
+		},
+		{
+			name:    "Has labels with team",
+			filters: &IssueFilters{TeamID: &teamID, HasLabels: true},
+			expected: map[string]interface{}{
+				"team":   map[string]interface{}{"id": map[string]interface{}{"eq": teamID}},
+				"labels": map[string]interface{}{"length": map[string]interface{}{"gt": 0}},
+			},
+		},
+		{
+			name:    "No project with team",
+			filters: &IssueFilters{TeamID: &teamID, NoProject: true},
+			expected: map[string]interface{}{
+				"team":    map[string]interface{}{"id": map[string]interface{}{"eq": teamID}},
+				"project": map[string]interface{}{"null": true},
+			},
+		},
+		{
+			name:    "Numbers in a team by key",
+			filters: &IssueFilters{TeamKey: &teamKey, Numbers: []int{99, 998}},
+			expected: map[string]interface{}{
+				"team":   map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": teamKey}},
+				"number": map[string]interface{}{"in": []int{99, 998}},
+			},
+		},
+		{
+			name:    "Created in a range",
+			filters: &IssueFilters{CreatedIn: &TimeRange{Start: updatedAfter, End: updatedAfter.Add(7 * 24 * time.Hour)}},
+			expected: map[string]interface{}{
+				"createdAt": map[string]interface{}{"gte": "2026-01-02T03:04:05Z", "lt": "2026-01-09T03:04:05Z"},
+			},
+		},
+		{
+			name:    "Completed in a range",
+			filters: &IssueFilters{CompletedIn: &TimeRange{Start: updatedAfter, End: updatedAfter.Add(7 * 24 * time.Hour)}},
+			expected: map[string]interface{}{
+				"completedAt": map[string]interface{}{"gte": "2026-01-02T03:04:05Z", "lt": "2026-01-09T03:04:05Z"},
+				"state":       map[string]interface{}{"type": map[string]interface{}{"eq": "completed"}},
+			},
+		},
+		{
+			name:    "Stale in a team",
+			filters: &IssueFilters{TeamID: &teamID, StaleSince: &updatedAfter},
+			expected: map[string]interface{}{
+				"team":      map[string]interface{}{"id": map[string]interface{}{"eq": teamID}},
+				"updatedAt": map[string]interface{}{"lt": "2026-01-02T03:04:05Z"},
+				"state":     map[string]interface{}{"type": map[string]interface{}{"nin": []string{"completed", "canceled"}}},
+			},
+		},
+		{
+			name:    "Stale in a state type",
+			filters: &IssueFilters{StaleSince: &updatedAfter, StateType: &stateType},
+			expected: map[string]interface{}{
+				"updatedAt": map[string]interface{}{"lt": "2026-01-02T03:04:05Z"},
+				"state":     map[string]interface{}{"type": map[string]interface{}{"eq": stateType}},
+			},
+		},
+		{
+			name:    "Team ID wins over team key",
+			filters: &IssueFilters{TeamID: &teamID, TeamKey: &teamKey},
+			expected: map[string]interface{}{
+				"team": map[string]interface{}{"id": map[string]interface{}{"eq": teamID}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildIssueFilter(tt.filters)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("buildIssueFilter() = %#v, want %#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInitiativeProgress verifies the scope-weighted progress rollup.
+func TestInitiativeProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []model.Project
+		expected float64
+	}{
+		{
+			name:     "No projects",
+			projects: nil,
+			expected: 0,
+		},
+		{
+			name: "Weighted by scope",
+			projects: []model.Project{
+				{Progress: 1.0, Scope: 10},
+				{Progress: 0.0, Scope: 30},
+			},
+			expected: 0.25,
+		},
+		{
+			name: "Plain average without scope",
+			projects: []model.Project{
+				{Progress: 0.5},
+				{Progress: 1.0},
+			},
+			expected: 0.75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InitiativeProgress(tt.projects)
+			if math.Abs(got-tt.expected) > 0.001 {
+				t.Errorf("InitiativeProgress() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMergeIssues verifies that incremental updates replace existing issues
+// in place and prepend new ones.
+func TestMergeIssues(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	existing := []model.Issue{
+		{ID: "a", Title: "A", UpdatedAt: t1},
+		{ID: "b", Title: "B", UpdatedAt: t1},
+	}
+	updates := []model.Issue{
+		{ID: "b", Title: "B2", UpdatedAt: t2},
+		{ID: "c", Title: "C", UpdatedAt: t2},
+	}
+
+	merged := MergeIssues(existing, updates, []string{"b", "c"})
+
+	var titles []string
+	for _, issue := range merged {
+		titles = append(titles, issue.Title)
+	}
+	if want := []string{"C", "A", "B2"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("MergeIssues() titles = %v, want %v", titles, want)
+	}
+
+	if existing[1].Title != "B" {
+		t.Errorf("MergeIssues() modified the existing slice")
+	}
+
+	if got := LatestUpdate(merged); !got.Equal(t2) {
+		t.Errorf("LatestUpdate() = %v, want %v", got, t2)
+	}
+}
+
+// TestMergeIssuesMovedOut verifies that cached issues updated so they no
+// longer match the filters are dropped, while changes to issues that were
+// never cached are ignored.
+func TestMergeIssuesMovedOut(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	existing := []model.Issue{
+		{ID: "a", Title: "A", UpdatedAt: t1},
+		{ID: "b", Title: "B", UpdatedAt: t1},
+		{ID: "c", Title: "C", UpdatedAt: t1},
+	}
+	// a was moved out of the filter; x changed but was never listed
+	updates := []model.Issue{
+		{ID: "b", Title: "B2", UpdatedAt: t2},
+		{ID: "d", Title: "D", UpdatedAt: t2},
+	}
+	changed := []string{"a", "b", "d", "x"}
+
+	var titles []string
+	for _, issue := range MergeIssues(existing, updates, changed) {
+		titles = append(titles, issue.Title)
+	}
+	if want := []string{"D", "B2", "C"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("MergeIssues() titles = %v, want %v", titles, want)
+	}
+}
+
+// TestListIssueIDs verifies the ID-only issues query declares a typed
+// $filter and returns the IDs.
+func TestListIssueIDs(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var ids []string
+	request := captureRequest(t, `{"data":{"issues":{"nodes":[{"id":"a"},{"id":"b"}],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+		var err error
+		ids, err = c.ListIssueIDs(context.Background(), &IssueFilters{UpdatedAfter: &since})
+		return err
+	})
+
+	want := map[string]interface{}{"updatedAt": map[string]interface{}{"gt": "2026-01-01T00:00:00Z"}}
+	assertFilter(t, request, "IssueFilter", want)
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("ListIssueIDs() = %v, want [a b]", ids)
+	}
+}
+
+// TestSummarizeReactions verifies that reactions are counted per emoji in
+// first-seen order and that colon-wrapped shortcodes are normalized.
+func TestSummarizeReactions(t *testing.T) {
+	tests := []struct {
+		name     string
+		emojis   []string
+		expected []model.Reaction
+	}{
+		{
+			name:     "No reactions",
+			emojis:   nil,
+			expected: nil,
+		},
+		{
+			name:   "Counts per emoji",
+			emojis: []string{"eyes", "+1", "eyes"},
+			expected: []model.Reaction{
+				{Emoji: "eyes", Count: 2},
+				{Emoji: "+1", Count: 1},
+			},
+		},
+		{
+			name:   "Colons normalized",
+			emojis: []string{":tada:", "tada"},
+			expected: []model.Reaction{
+				{Emoji: "tada", Count: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeReactions(tt.emojis); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("summarizeReactions() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFindLabel verifies label resolution by ID or case-insensitive name,
+// and that same-named team labels are disambiguated by team key.
+func TestFindLabel(t *testing.T) {
+	labels := []model.Label{
+		{ID: "l1", Name: "Bug"},
+		{ID: "l2", Name: "Needs Design", Team: &model.Team{Key: "ENG"}},
+		{ID: "l3", Name: "Needs Design", Team: &model.Team{Key: "DES"}},
+	}
+
+	tests := []struct {
+		name     string
+		nameOrID string
+		teamKey  string
+		wantID   string
+		wantErr  bool
+	}{
+		{name: "By name", nameOrID: "bug", wantID: "l1"},
+		{name: "By ID", nameOrID: "l3", wantID: "l3"},
+		{name: "Workspace label with team", nameOrID: "Bug", teamKey: "ENG", wantID: "l1"},
+		{name: "Ambiguous without team", nameOrID: "needs design", wantErr: true},
+		{name: "Disambiguated by team", nameOrID: "needs design", teamKey: "des", wantID: "l3"},
+		{name: "Not found", nameOrID: "Feature", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, err := FindLabel(labels, tt.nameOrID, tt.teamKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && label.ID != tt.wantID {
+				t.Errorf("FindLabel() = %s, want %s", label.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	projects := []model.Project{
+		{ID: "p1", Name: "Mobile App"},
+		{ID: "p2", Name: "Q3 Launch"},
+		{ID: "p3", Name: "Q3 Launch"},
+	}
+
+	tests := []struct {
+		name     string
+		nameOrID string
+		wantID   string
+		wantErr  bool
+	}{
+		{name: "By name", nameOrID: "mobile app", wantID: "p1"},
+		{name: "By ID", nameOrID: "p3", wantID: "p3"},
+		{name: "Ambiguous name", nameOrID: "Q3 Launch", wantErr: true},
+		{name: "Not found", nameOrID: "Web", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := FindProject(projects, tt.nameOrID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && project.ID != tt.wantID {
+				t.Errorf("FindProject() = %s, want %s", project.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestTeamLabels(t *testing.T) {
+	labels := []model.Label{
+		{ID: "l1", Name: "Bug"},
+		{ID: "l2", Name: "Backend", Team: &model.Team{ID: "eng"}},
+		{ID: "l3", Name: "Research", Team: &model.Team{ID: "des"}},
+	}
+
+	got := TeamLabels(labels, "eng")
+	ids := []string{}
+	for _, label := range got {
+		ids = append(ids, label.ID)
+	}
+	if want := []string{"l1", "l2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("TeamLabels() = %v, want %v", ids, want)
+	}
+}
+
+// TestResolveTeamIDs verifies team keys and IDs resolve to IDs in order
+// without duplicates, and that the create input carries them as teamIds.
+func TestResolveTeamIDs(t *testing.T) {
+	teams := []model.Team{
+		{ID: "t1", Key: "ENG"},
+		{ID: "t2", Key: "OPS"},
+	}
+
+	tests := []struct {
+		name     string
+		refs     []string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Keys", refs: []string{"ENG", "OPS"}, expected: []string{"t1", "t2"}},
+		{name: "Case-insensitive key", refs: []string{"ops"}, expected: []string{"t2"}},
+		{name: "ID and duplicate key", refs: []string{"t1", "ENG"}, expected: []string{"t1"}},
+		{name: "Unknown team", refs: []string{"ENG", "XYZ"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := ResolveTeamIDs(teams, tt.refs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveTeamIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("ResolveTeamIDs() = %v, want %v", ids, tt.expected)
+			}
+
+			input, err := json.Marshal(&CreateProjectInput{Name: "P", TeamIDs: &ids})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded map[string]interface{}
+			if err := json.Unmarshal(input, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint(decoded["teamIds"]); got != fmt.Sprint(tt.expected) {
+				t.Errorf("input teamIds = %s, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFindTeamAndUser verifies lookups by ID, key, email and name, and that
+// unknown values fail with the available choices.
+func TestFindTeamAndUser(t *testing.T) {
+	teams := []model.Team{
+		{ID: "t1", Key: "ENG"},
+		{ID: "t2", Key: "DES"},
+	}
+	users := []model.User{
+		{ID: "u1", Name: "Ada Lovelace", DisplayName: "ada", Email: "ada@example.com"},
+		{ID: "u2", Name: "Grace Hopper", Email: "grace@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		find    func(ref string) (string, error)
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{name: "team key", find: teamFinder(teams), ref: "eng", want: "t1"},
+		{name: "team ID", find: teamFinder(teams), ref: "t2", want: "t2"},
+		{name: "unknown team", find: teamFinder(teams), ref: "XYZ", wantErr: "team 'XYZ' not found (available: DES, ENG)"},
+		{name: "unknown team ID", find: teamFinder(teams), ref: "00000000-0000-0000-0000-000000000000", wantErr: "team '00000000-0000-0000-0000-000000000000' not found"},
+		{name: "user email", find: userFinder(users), ref: "GRACE@example.com", want: "u2"},
+		{name: "user display name", find: userFinder(users), ref: "ada", want: "u1"},
+		{name: "user name", find: userFinder(users), ref: "grace hopper", want: "u2"},
+		{name: "unknown user", find: userFinder(users), ref: "bob", wantErr: "user 'bob' not found (available: Grace Hopper, ada)"},
+		{name: "no teams", find: teamFinder(nil), ref: "ENG", wantErr: "team 'ENG' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.find(tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func teamFinder(teams []model.Team) func(string) (string, error) {
+	return func(ref string) (string, error) {
+		team, err := FindTeam(teams, ref)
+		if err != nil {
+			return "", err
+		}
+		return team.ID, nil
+	}
+}
+
+func userFinder(users []model.User) func(string) (string, error) {
+	return func(ref string) (string, error) {
+		user, err := FindUser(users, ref)
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	}
+}
+
+// TestSortMilestones verifies target-date ordering with undated milestones
+// last (also when reversed), and sorting by name and creation time.
+func TestSortMilestones(t *testing.T) {
+	date := func(s string) *time.Time {
+		return parseDate(&s)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	milestones := func() []model.Milestone {
+		return []model.Milestone{
+			{ID: "a", Name: "beta", TargetDate: date("2026-06-01"), CreatedAt: base.Add(2 * time.Hour)},
+			{ID: "b", Name: "Alpha", CreatedAt: base},
+			{ID: "c", Name: "gamma", TargetDate: date("2026-03-01"), CreatedAt: base.Add(time.Hour)},
+			{ID: "d", Name: "delta", TargetDate: date("2026-09-01"), CreatedAt: base.Add(3 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		by       string
+		reverse  bool
+		expected []string
+	}{
+		{name: "Default is target date", by: "", expected: []string{"c", "a", "d", "b"}},
+		{name: "Target date", by: "target-date", expected: []string{"c", "a", "d", "b"}},
+		{name: "Target date reversed keeps undated last", by: "target-date", reverse: true, expected: []string{"d", "a", "c", "b"}},
+		{name: "Name is case-insensitive", by: "name", expected: []string{"b", "a", "d", "c"}},
+		{name: "Created", by: "created", expected: []string{"b", "c", "a", "d"}},
+		{name: "Created reversed", by: "created", reverse: true, expected: []string{"d", "a", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := milestones()
+			SortMilestones(list, tt.by, tt.reverse)
+
+			got := make([]string, len(list))
+			for i, m := range list {
+				got[i] = m.ID
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortMilestones(%q, %v) = %v, want %v", tt.by, tt.reverse, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSortProjects verifies roadmap ordering by target date with undated
+// projects last, and sorting by name, progress, and priority.
+func TestSortProjects(t *testing.T) {
+	date := func(s string) *time.Time {
+		return parseDate(&s)
+	}
+
+	projects := func() []model.Project {
+		return []model.Project{
+			{ID: "a", Name: "beta", Priority: 3, Progress: 0.5, TargetDate: date("2026-06-01")},
+			{ID: "b", Name: "Alpha", Priority: 0, Progress: 0.9},
+			{ID: "c", Name: "gamma", Priority: 1, Progress: 0.1, TargetDate: date("2026-03-01")},
+			{ID: "d", Name: "delta", Priority: 4, Progress: 0, TargetDate: date("2026-09-01")},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		by       string
+		reverse  bool
+		expected []string
+	}{
+		{name: "Default is target date", by: "", expected: []string{"c", "a", "d", "b"}},
+		{name: "Target date reversed keeps undated last", by: "target-date", reverse: true, expected: []string{"d", "a", "c", "b"}},
+		{name: "Name is case-insensitive", by: "name", expected: []string{"b", "a", "d", "c"}},
+		{name: "Progress", by: "progress", expected: []string{"d", "c", "a", "b"}},
+		{name: "Progress reversed", by: "progress", reverse: true, expected: []string{"b", "a", "c", "d"}},
+		{name: "Priority puts No Priority last", by: "priority", expected: []string{"c", "a", "d", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := projects()
+			SortProjects(list, tt.by, tt.reverse)
+
+			got := make([]string, len(list))
+			for i, p := range list {
+				got[i] = p.ID
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortProjects(%q, %v) = %v, want %v", tt.by, tt.reverse, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSortInitiatives verifies target-date ordering with undated
+// initiatives last (also when reversed), and sorting by name.
+func TestSortInitiatives(t *testing.T) {
+	date := func(s string) *time.Time {
+		return parseDate(&s)
+	}
+
+	initiatives := func() []model.Initiative {
+		return []model.Initiative{
+			{ID: "a", Name: "beta", TargetDate: date("2026-06-01")},
+			{ID: "b", Name: "Alpha"},
+			{ID: "c", Name: "gamma", TargetDate: date("2026-03-01")},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		by       string
+		reverse  bool
+		expected []string
+	}{
+		{name: "Default is target date", by: "", expected: []string{"c", "a", "b"}},
+		{name: "Target date reversed keeps undated last", by: "target-date", reverse: true, expected: []string{"a", "c", "b"}},
+		{name: "Name is case-insensitive", by: "name", expected: []string{"b", "a", "c"}},
+		{name: "Name reversed", by: "name", reverse: true, expected: []string{"c", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := initiatives()
+			SortInitiatives(list, tt.by, tt.reverse)
+
+			got := make([]string, len(list))
+			for i, initiative := range list {
+				got[i] = initiative.ID
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortInitiatives(%q, %v) = %v, want %v", tt.by, tt.reverse, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInheritFromParent verifies a sub-issue takes its parent's team and
+// project unless they were set explicitly.
+func TestInheritFromParent(t *testing.T) {
+	parent := &model.Issue{
+		Identifier: "ENG-100",
+		Team:       &model.Team{ID: "team-eng", Key: "ENG"},
+		Project:    &model.Project{ID: "project-parent"},
+	}
+	explicit := "project-explicit"
+
+	tests := []struct {
+		name        string
+		input       CreateIssueInput
+		parent      *model.Issue
+		wantTeam    string
+		wantProject string
+	}{
+		{name: "Team and project from parent", parent: parent, wantTeam: "team-eng", wantProject: "project-parent"},
+		{name: "Explicit project kept", input: CreateIssueInput{ProjectID: &explicit}, parent: parent, wantTeam: "team-eng", wantProject: explicit},
+		{name: "Explicit team kept", input: CreateIssueInput{TeamID: "team-ops"}, parent: parent, wantTeam: "team-ops", wantProject: "project-parent"},
+		{name: "Parent without project", parent: &model.Issue{Team: parent.Team}, wantTeam: "team-eng"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			input.InheritFromParent(tt.parent)
+
+			if input.TeamID != tt.wantTeam {
+				t.Errorf("TeamID = %q, want %q", input.TeamID, tt.wantTeam)
+			}
+			gotProject := ""
+			if input.ProjectID != nil {
+				gotProject = *input.ProjectID
+			}
+			if gotProject != tt.wantProject {
+				t.Errorf("ProjectID = %q, want %q", gotProject, tt.wantProject)
+			}
+		})
+	}
+}
+
+// TestUpdateIssueInputClear verifies each cleared field is sent as an
+// explicit null while unset fields stay omitted.
+func TestUpdateIssueInputClear(t *testing.T) {
+	title := "New title"
+
+	tests := []struct {
+		name  string
+		clear string
+	}{
+		{name: "Project", clear: ClearProject},
+		{name: "Parent", clear: ClearParent},
+		{name: "Priority", clear: ClearPriority},
+		{name: "Due date", clear: ClearDueDate},
+		{name: "Assignee", clear: ClearAssignee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &UpdateIssueInput{Title: &title, Clear: []string{tt.clear}}
+			fields, err := input.toMap()
+			if err != nil {
+				t.Fatalf("toMap() error = %v", err)
+			}
+
+			value, ok := fields[tt.clear]
+			if !ok || value != nil {
+				t.Errorf("input[%q] = %v (present %v), want explicit null", tt.clear, value, ok)
+			}
+			if fields["title"] != title {
+				t.Errorf("input[title] = %v, want %q", fields["title"], title)
+			}
+			if len(fields) != 2 {
+				t.Errorf("input has %d fields, want 2: %v", len(fields), fields)
+			}
+
+			// The null must survive encoding into the request body, which
+			// declares the input with its Linear type
+			var seen []string
+			transport := requestTransport{&seen, `{"data":{"issueUpdate":{"success":true}}}`}
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := c.UpdateIssue(context.Background(), "issue-1", input); err != nil {
+				t.Fatalf("UpdateIssue() error = %v", err)
+			}
+			if want := fmt.Sprintf(`"%s":null`, tt.clear); !strings.Contains(seen[0], want) {
+				t.Errorf("request %s missing %s", seen[0], want)
+			}
+			assertDeclares(t, seen[0], "$input:IssueUpdateInput!")
+		})
+	}
+}
+
+// TestExtendDescription verifies the description sent by issue edit
+// --append-description and --prepend-description joins the current body and
+// the new text with a newline.
+func TestExtendDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		text    string
+		prepend bool
+		want    string
+	}{
+		{name: "Append", current: "Steps to reproduce", text: "Update: fixed in v2", want: "Steps to reproduce\nUpdate: fixed in v2"},
+		{name: "Prepend", current: "Steps to reproduce", text: "**Blocked**", prepend: true, want: "**Blocked**\nSteps to reproduce"},
+		{name: "Trailing newline", current: "Log:\n", text: "line 2", want: "Log:\nline 2"},
+		{name: "Empty description", current: "", text: "First note", want: "First note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			transport := requestTransport{&seen, `{"data":{"issueUpdate":{"success":true}}}`}
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			input := &UpdateIssueInput{}
+			input.ExtendDescription(tt.current, tt.text, tt.prepend)
+			if err := c.UpdateIssue(context.Background(), "issue-1", input); err != nil {
+				t.Fatalf("UpdateIssue() error = %v", err)
+			}
+			if len(seen) != 1 {
+				t.Fatalf("sent %d requests, want 1", len(seen))
+			}
+
+			var request graphQLRequest
+			if err := json.Unmarshal([]byte(seen[0]), &request); err != nil {
+				t.Fatalf("request body is not JSON: %v", err)
+			}
+			sent, _ := request.Variables["input"].(map[string]interface{})
+			if sent["description"] != tt.want {
+				t.Errorf("sent description %q, want %q", sent["description"], tt.want)
+			}
+			assertDeclares(t, seen[0], "$input:IssueUpdateInput!")
+		})
+	}
+}
+
+// TestApplyMemberships verifies teams are annotated with the viewer's role
+// and that mineOnly drops teams the viewer does not belong to.
+func TestApplyMemberships(t *testing.T) {
+	teams := []model.Team{
+		{ID: "t1", Key: "ENG"},
+		{ID: "t2", Key: "OPS"},
+		{ID: "t3", Key: "DES"},
+	}
+	roles := map[string]string{"t1": TeamRoleOwner, "t3": TeamRoleMember}
+
+	tests := []struct {
+		name     string
+		mineOnly bool
+		expected []string // key:membership
+	}{
+		{name: "All teams", expected: []string{"ENG:owner", "OPS:", "DES:member"}},
+		{name: "Mine only", mineOnly: true, expected: []string{"ENG:owner", "DES:member"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, team := range ApplyMemberships(teams, roles, tt.mineOnly) {
+				got = append(got, team.Key+":"+team.Membership)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ApplyMemberships() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if teams[0].Membership != "" {
+		t.Error("ApplyMemberships() modified the input slice")
+	}
+}
+
+// TestFindFavorite verifies favorites match by favorite ID, issue ID or
+// case-insensitive identifier, and project ID.
+func TestFindFavorite(t *testing.T) {
+	favorites := []model.Favorite{
+		{ID: "f1", Type: "issue", Issue: &model.Issue{ID: "i1", Identifier: "ENG-1"}},
+		{ID: "f2", Type: "project", Project: &model.Project{ID: "p1", Name: "Launch"}},
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "By favorite ID", ref: "f2", wantID: "f2"},
+		{name: "By issue identifier", ref: "eng-1", wantID: "f1"},
+		{name: "By issue ID", ref: "i1", wantID: "f1"},
+		{name: "By project ID", ref: "p1", wantID: "f2"},
+		{name: "Project name is not a ref", ref: "Launch", wantErr: true},
+		{name: "Not found", ref: "ENG-2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			favorite, err := FindFavorite(favorites, tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindFavorite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && favorite.ID != tt.wantID {
+				t.Errorf("FindFavorite() = %s, want %s", favorite.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+// TestValidateDateRange verifies project dates must be YYYY-MM-DD and the
+// start may not fall after the target; either date may be omitted.
+func TestValidateDateRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		target  string
+		wantErr string
+	}{
+		{name: "Neither date"},
+		{name: "Start only", start: "2026-01-05"},
+		{name: "Target only", target: "2026-03-31"},
+		{name: "Ordered range", start: "2026-01-05", target: "2026-03-31"},
+		{name: "Same day", start: "2026-03-31", target: "2026-03-31"},
+		{name: "Start after target", start: "2026-04-01", target: "2026-03-31", wantErr: "after"},
+		{name: "Malformed start", start: "2026/01/05", wantErr: "--start-date"},
+		{name: "Impossible target", target: "2026-02-30", wantErr: "--target-date"},
+		{name: "Timestamp is not a date", target: "2026-03-31T00:00:00Z", wantErr: "--target-date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDateRange(tt.start, tt.target)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateDateRange() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateDateRange() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSelectCycle verifies the current, next, and previous cycles are
+// picked from date ranges, including at the boundary between two cycles.
+func TestSelectCycle(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	cycles := []model.Cycle{
+		{ID: "c1", StartsAt: day(1), EndsAt: day(8)},
+		{ID: "c2", StartsAt: day(8), EndsAt: day(15)},
+		{ID: "c3", StartsAt: day(22), EndsAt: day(29)},
+	}
+
+	tests := []struct {
+		name    string
+		which   string
+		now     time.Time
+		wantID  string
+		wantErr bool
+	}{
+		{name: "current", which: CycleCurrent, now: day(10), wantID: "c2"},
+		{name: "current at boundary", which: CycleCurrent, now: day(8), wantID: "c2"},
+		{name: "no current in gap", which: CycleCurrent, now: day(18), wantErr: true},
+		{name: "next", which: CycleNext, now: day(10), wantID: "c3"},
+		{name: "next in gap", which: CycleNext, now: day(18), wantID: "c3"},
+		{name: "no next", which: CycleNext, now: day(25), wantErr: true},
+		{name: "previous", which: CyclePrevious, now: day(10), wantID: "c1"},
+		{name: "previous in gap", which: CyclePrevious, now: day(18), wantID: "c2"},
+		{name: "no previous", which: CyclePrevious, now: day(3), wantErr: true},
+		{name: "unknown selector", which: "last", now: day(10), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle, err := SelectCycle(cycles, tt.which, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectCycle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cycle.ID != tt.wantID {
+				t.Errorf("SelectCycle() = %s, want %s", cycle.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+// TestFindCycle verifies cycles are found by selector or by number.
+func TestFindCycle(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	cycles := []model.Cycle{
+		{ID: "c1", Number: 41, StartsAt: day(1), EndsAt: day(8)},
+		{ID: "c2", Number: 42, StartsAt: day(8), EndsAt: day(15)},
+	}
+
+	tests := []struct {
+		ref     string
+		wantID  string
+		wantErr bool
+	}{
+		{ref: "current", wantID: "c2"},
+		{ref: "previous", wantID: "c1"},
+		{ref: "41", wantID: "c1"},
+		{ref: "99", wantErr: true},
+		{ref: "last", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			cycle, err := FindCycle(cycles, tt.ref, day(10))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindCycle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cycle.ID != tt.wantID {
+				t.Errorf("FindCycle() = %s, want %s", cycle.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+// requestTransport records the body of each request it answers with body
+type requestTransport struct {
+	seen *[]string
+	body string
+}
+
+func (r requestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	*r.seen = append(*r.seen, string(body))
+	return bodyTransport{http.StatusOK, r.body}.RoundTrip(req)
+}
+
+// graphQLRequest is the decoded body of a GraphQL request
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// captureRequest runs call against a client answering every request with
+// response and returns the first request it sent
+func captureRequest(t *testing.T, response string, call func(*Client) error) graphQLRequest {
+	t.Helper()
+	var seen []string
+	c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: requestTransport{&seen, response}}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := call(c); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if len(seen) == 0 {
+		t.Fatal("no request sent")
+	}
+
+	var request graphQLRequest
+	if err := json.Unmarshal([]byte(seen[0]), &request); err != nil {
+		t.Fatalf("request body is not JSON: %v", err)
+	}
+	return request
+}
+
+// assertDeclares fails the test unless the GraphQL request body declares
+// the variable with the given type, e.g. $filter:IssueFilter!. Variables the
+// GraphQL client cannot type (maps) are declared with no type at all.
+func assertDeclares(t *testing.T, body, declaration string) {
+	t.Helper()
+	var request graphQLRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		t.Fatalf("request body is not JSON: %v", err)
+	}
+	if !strings.Contains(request.Query, declaration) {
+		t.Errorf("query does not declare %s: %s", declaration, request.Query)
+	}
+}
+
+// assertFilter fails the test unless the request declares $filter with the
+// named Linear filter type (nullable) and sends want as its value, nil for
+// no filter
+func assertFilter(t *testing.T, request graphQLRequest, filterType string, want interface{}) {
+	t.Helper()
+	if !strings.Contains(request.Query, "$filter:"+filterType) {
+		t.Errorf("query does not declare $filter:%s: %s", filterType, request.Query)
+	}
+	if got, ok := request.Variables["filter"]; !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("filter = %v (present %v), want %v", got, ok, want)
+	}
+}
+
+// TestListIssueCommentsOrder verifies the comments query is ordered by
+// creation time, that comments come back in the requested order, and that a
+// limit keeps the newest or the oldest comments accordingly.
+func TestListIssueCommentsOrder(t *testing.T) {
+	// Served newest first, like the API
+	body := `{"data":{"comments":{"nodes":[` +
+		`{"id":"c3","body":"third","user":{"id":"u1","name":"Ada"},"createdAt":"2026-03-03T00:00:00Z","updatedAt":"2026-03-03T00:00:00Z"},` +
+		`{"id":"c2","body":"second","user":{"id":"u1","name":"Ada"},"createdAt":"2026-03-02T00:00:00Z","updatedAt":"2026-03-02T00:00:00Z"},` +
+		`{"id":"c1","body":"first","user":{"id":"u1","name":"Ada"},"createdAt":"2026-03-01T00:00:00Z","updatedAt":"2026-03-01T00:00:00Z"}` +
+		`],"pageInfo":{"hasNextPage":false,"endCursor":""}}}}`
+
+	tests := []struct {
+		name      string
+		order     CommentOrder
+		limit     int
+		want      []string
+		wantFirst float64 // page size requested
+	}{
+		{name: "Oldest", order: CommentsOldest, want: []string{"c1", "c2", "c3"}, wantFirst: DefaultPageSize},
+		{name: "Newest", order: CommentsNewest, want: []string{"c3", "c2", "c1"}, wantFirst: DefaultPageSize},
+		{name: "Oldest with limit fetches every page", order: CommentsOldest, limit: 2, want: []string{"c1", "c2"}, wantFirst: DefaultPageSize},
+		{name: "Newest with limit", order: CommentsNewest, limit: 2, want: []string{"c3", "c2"}, wantFirst: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: requestTransport{&seen, body}}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			comments, err := c.ListIssueComments(WithLimit(context.Background(), tt.limit), "issue-1", tt.order)
+			if err != nil {
+				t.Fatalf("ListIssueComments() error = %v", err)
+			}
+			got := []string{}
+			for _, comment := range comments {
+				got = append(got, comment.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("comments = %v, want %v", got, tt.want)
+			}
+
+			var request graphQLRequest
+			if err := json.Unmarshal([]byte(seen[0]), &request); err != nil {
+				t.Fatalf("request body is not JSON: %v", err)
+			}
+			if !strings.Contains(request.Query, "orderBy: $orderBy") || !strings.Contains(request.Query, "$orderBy:PaginationOrderBy!") {
+				t.Errorf("query = %s, want ordered by $orderBy", request.Query)
+			}
+			if request.Variables["orderBy"] != "createdAt" {
+				t.Errorf("orderBy = %v, want createdAt", request.Variables["orderBy"])
+			}
+			if request.Variables["first"] != tt.wantFirst {
+				t.Errorf("first = %v, want %v", request.Variables["first"], tt.wantFirst)
+			}
+		})
+	}
+}
+
+// TestListIssueCommentsFilter verifies the comments query declares a typed
+// $filter narrowing comments to the issue.
+func TestListIssueCommentsFilter(t *testing.T) {
+	request := captureRequest(t, `{"data":{"comments":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+		_, err := c.ListIssueComments(context.Background(), "issue-1", CommentsOldest)
+		return err
+	})
+	want := map[string]interface{}{"issue": map[string]interface{}{"id": map[string]interface{}{"eq": "issue-1"}}}
+	assertFilter(t, request, "CommentFilter", want)
+}
+
+// TestParseAge verifies --older-than values in days, weeks, and Go
+// durations, and that non-positive or malformed ages are rejected.
+func TestParseAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "3d", want: time.Date(2026, 3, 7, 15, 30, 0, 0, time.UTC)},
+		{value: "2w", want: time.Date(2026, 2, 24, 15, 30, 0, 0, time.UTC)},
+		{value: "36h", want: time.Date(2026, 3, 9, 3, 30, 0, 0, time.UTC)},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseAge(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAge(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseAge(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestListIssuesDescription verifies that list queries only request
+// descriptions when IncludeDescription is set.
+func TestListIssuesDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters *IssueFilters
+		want    bool
+	}{
+		{name: "Nil filters", filters: nil, want: false},
+		{name: "Omitted by default", filters: &IssueFilters{}, want: false},
+		{name: "Included on request", filters: &IssueFilters{IncludeDescription: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			transport := requestTransport{&seen, `{"data":{"issues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`}
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if _, err := c.ListIssues(context.Background(), tt.filters); err != nil {
+				t.Fatalf("ListIssues() error = %v", err)
+			}
+			if len(seen) != 1 {
+				t.Fatalf("sent %d requests, want 1", len(seen))
+			}
+
+			var request graphQLRequest
+			if err := json.Unmarshal([]byte(seen[0]), &request); err != nil {
+				t.Fatalf("request body is not JSON: %v", err)
+			}
+			if !strings.Contains(request.Query, "description @include(if: $withDescription)") {
+				t.Errorf("query does not make description conditional: %s", request.Query)
+			}
+			if got := request.Variables["withDescription"]; got != tt.want {
+				t.Errorf("withDescription = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBatchUpdateIssues verifies that a batch update sends every ID with
+// the shared input, split into batches of batchUpdateSize.
+func TestBatchUpdateIssues(t *testing.T) {
+	ids := make([]string, batchUpdateSize+2)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("issue-%d", i)
+	}
+	stateID := "state-1"
+
+	tests := []struct {
+		name    string
+		ids     []string
+		input   *UpdateIssueInput
+		batches []int
+		want    map[string]interface{}
+	}{
+		{
+			name:    "Single batch",
+			ids:     ids[:3],
+			input:   &UpdateIssueInput{StateID: &stateID},
+			batches: []int{3},
+			want:    map[string]interface{}{"stateId": stateID},
+		},
+		{
+			name:    "Cleared field",
+			ids:     ids[:1],
+			input:   &UpdateIssueInput{Clear: []string{ClearAssignee}},
+			batches: []int{1},
+			want:    map[string]interface{}{"assigneeId": nil},
+		},
+		{
+			name:    "Split into batches",
+			ids:     ids,
+			input:   &UpdateIssueInput{StateID: &stateID},
+			batches: []int{batchUpdateSize, 2},
+			want:    map[string]interface{}{"stateId": stateID},
+		},
+		{
+			name:  "No issues",
+			ids:   nil,
+			input: &UpdateIssueInput{StateID: &stateID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			transport := requestTransport{&seen, `{"data":{"issueBatchUpdate":{"success":true}}}`}
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := c.BatchUpdateIssues(context.Background(), tt.ids, tt.input); err != nil {
+				t.Fatalf("BatchUpdateIssues() error = %v", err)
+			}
+			if len(seen) != len(tt.batches) {
+				t.Fatalf("sent %d mutations, want %d", len(seen), len(tt.batches))
+			}
+
+			sent := 0
+			for i, body := range seen {
+				var request graphQLRequest
+				if err := json.Unmarshal([]byte(body), &request); err != nil {
+					t.Fatalf("request body is not JSON: %v", err)
+				}
+				if !strings.Contains(request.Query, "issueBatchUpdate(ids: $ids, input: $input)") {
+					t.Errorf("query = %s, want issueBatchUpdate", request.Query)
+				}
+				assertDeclares(t, body, "$ids:[UUID!]!")
+				assertDeclares(t, body, "$input:IssueUpdateInput!")
+
+				batch, _ := request.Variables["ids"].([]interface{})
+				if len(batch) != tt.batches[i] {
+					t.Fatalf("batch %d has %d ids, want %d", i, len(batch), tt.batches[i])
+				}
+				for j, id := range batch {
+					if id != tt.ids[sent+j] {
+						t.Errorf("batch %d id %d = %v, want %s", i, j, id, tt.ids[sent+j])
+					}
+				}
+				sent += len(batch)
+
+				if !reflect.DeepEqual(request.Variables["input"], tt.want) {
+					t.Errorf("input = %v, want %v", request.Variables["input"], tt.want)
+				}
+			}
+		})
+	}
+}
+
+// batchFallbackTransport fails every issueBatchUpdate and every issueUpdate
+// of the issues in reject, answering other issueUpdates with success
+type batchFallbackTransport struct {
+	seen   *[]string
+	reject map[string]bool
+}
+
+func (b batchFallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	*b.seen = append(*b.seen, string(body))
+
+	var request graphQLRequest
+	_ = json.Unmarshal(body, &request)
+	id, _ := request.Variables["id"].(string)
+	if strings.Contains(request.Query, "issueBatchUpdate") || b.reject[id] {
+		return bodyTransport{http.StatusOK, `{"data":null,"errors":[{"message":"rejected"}]}`}.RoundTrip(req)
+	}
+	return bodyTransport{http.StatusOK, `{"data":{"issueUpdate":{"success":true}}}`}.RoundTrip(req)
+}
+
+// TestUpdateIssuesFallback verifies that a rejected batch is retried issue
+// by issue, and only the issues that fail on their own are reported.
+func TestUpdateIssuesFallback(t *testing.T) {
+	var seen []string
+	transport := batchFallbackTransport{&seen, map[string]bool{"issue-2": true}}
+	c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	input := &UpdateIssueInput{AddedLabelIDs: []string{"label-1"}}
+	failed := c.UpdateIssues(context.Background(), []string{"issue-1", "issue-2", "issue-3"}, input)
+
+	if len(failed) != 1 || failed["issue-2"] == nil {
+		t.Errorf("failed = %v, want only issue-2", failed)
+	}
+	// One batch, then one update per issue
+	if len(seen) != 4 {
+		t.Fatalf("sent %d requests, want 4", len(seen))
+	}
+	for _, body := range seen[1:] {
+		var request graphQLRequest
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		want := map[string]interface{}{"addedLabelIds": []interface{}{"label-1"}}
+		if !reflect.DeepEqual(request.Variables["input"], want) {
+			t.Errorf("input = %v, want %v", request.Variables["input"], want)
+		}
+	}
+}
+
+// TestListProjectsFilter verifies the projects query always declares a
+// typed $filter, null unless a state is given.
+func TestListProjectsFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  interface{}
+	}{
+		{name: "No state", want: nil},
+		{name: "State", state: "started", want: map[string]interface{}{"state": map[string]interface{}{"eq": "started"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, `{"data":{"projects":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+				_, err := c.ListProjects(context.Background(), tt.state)
+				return err
+			})
+			assertFilter(t, request, "ProjectFilter", tt.want)
+		})
+	}
+}
+
+// TestListMilestonesFilter verifies the milestones query always declares a
+// typed $filter, null unless a project is given.
+func TestListMilestonesFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		projectID string
+		want      interface{}
+	}{
+		{name: "No project", want: nil},
+		{name: "Project", projectID: "project-1", want: map[string]interface{}{"project": map[string]interface{}{"id": map[string]interface{}{"eq": "project-1"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, `{"data":{"milestones":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+				_, err := c.ListMilestones(context.Background(), tt.projectID)
+				return err
+			})
+			assertFilter(t, request, "ProjectMilestoneFilter", tt.want)
+		})
+	}
+}
+
+// TestListIssuesFilter verifies the issues query always declares a typed
+// $filter: null without filters, else the Linear IssueFilter, e.g. a null
+// assignee for --assignee none.
+func TestListIssuesFilter(t *testing.T) {
+	creatorID := "user-1"
+
+	tests := []struct {
+		name    string
+		filters *IssueFilters
+		want    interface{}
+	}{
+		{name: "No filters", filters: nil, want: nil},
+		{name: "Empty filters", filters: &IssueFilters{}, want: nil},
+		{name: "Unassigned", filters: &IssueFilters{Unassigned: true}, want: map[string]interface{}{"assignee": map[string]interface{}{"null": true}}},
+		{name: "No project", filters: &IssueFilters{NoProject: true}, want: map[string]interface{}{"project": map[string]interface{}{"null": true}}},
+		{name: "IDs", filters: &IssueFilters{IDs: []string{"issue-1", "issue-2"}}, want: map[string]interface{}{"id": map[string]interface{}{"in": []interface{}{"issue-1", "issue-2"}}}},
+		{name: "Creator", filters: &IssueFilters{CreatorID: &creatorID}, want: map[string]interface{}{"creator": map[string]interface{}{"id": map[string]interface{}{"eq": "user-1"}}}},
+		{name: "No labels", filters: &IssueFilters{NoLabels: true}, want: map[string]interface{}{"labels": map[string]interface{}{"length": map[string]interface{}{"eq": float64(0)}}}},
+		{name: "Has labels", filters: &IssueFilters{HasLabels: true}, want: map[string]interface{}{"labels": map[string]interface{}{"length": map[string]interface{}{"gt": float64(0)}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, `{"data":{"issues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+				_, err := c.ListIssues(context.Background(), tt.filters)
+				return err
+			})
+			assertFilter(t, request, "IssueFilter", tt.want)
+		})
+	}
+}
+
+// TestListMyIssuesFilter verifies the viewer's assignedIssues query always
+// declares a typed $filter, null without filters.
+func TestListMyIssuesFilter(t *testing.T) {
+	stateType := "started"
+
+	tests := []struct {
+		name    string
+		filters *IssueFilters
+		want    interface{}
+	}{
+		{name: "No filters", filters: nil, want: nil},
+		{name: "State type", filters: &IssueFilters{StateType: &stateType}, want: map[string]interface{}{"state": map[string]interface{}{"type": map[string]interface{}{"eq": "started"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, `{"data":{"viewer":{"assignedIssues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`, func(c *Client) error {
+				_, err := c.ListMyIssues(context.Background(), tt.filters)
+				return err
+			})
+			assertFilter(t, request, "IssueFilter", tt.want)
+		})
+	}
+}
+
+func TestListUserIssuesFilter(t *testing.T) {
+	stateType := "started"
+
+	tests := []struct {
+		name     string
+		relation UserIssueRelation
+		filters  *IssueFilters
+		response string
+		want     interface{}
+	}{
+		{name: "Assigned, no filters", relation: UserIssuesAssigned, response: `{"data":{"user":{"assignedIssues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`, want: nil},
+		{name: "Created, state type", relation: UserIssuesCreated, filters: &IssueFilters{StateType: &stateType}, response: `{"data":{"user":{"createdIssues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}`, want: map[string]interface{}{"state": map[string]interface{}{"type": map[string]interface{}{"eq": "started"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, tt.response, func(c *Client) error {
+				_, err := c.ListUserIssues(context.Background(), "user-1", tt.relation, tt.filters)
+				return err
+			})
+			assertFilter(t, request, "IssueFilter", tt.want)
+		})
+	}
+}
+
+// answerTransport answers each GraphQL request with the body answer returns
+// for it, recording the requests sent
+type answerTransport struct {
+	seen   *[]graphQLRequest
+	answer func(request graphQLRequest) string
+}
+
+func (a answerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	var request graphQLRequest
+	_ = json.Unmarshal(body, &request)
+	*a.seen = append(*a.seen, request)
+	return bodyTransport{http.StatusOK, a.answer(request)}.RoundTrip(req)
+}
+
+// TestGetUserCounts verifies issue counts come from ID-only pages of the
+// largest size and stop past IssueCountCap.
+func TestGetUserCounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodes      int
+		more       bool
+		wantCount  int
+		wantCapped bool
+	}{
+		{name: "Under the cap", nodes: 3, wantCount: 3},
+		{name: "Over the cap", nodes: countPageSize, more: true, wantCount: IssueCountCap, wantCapped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := make([]string, tt.nodes)
+			for i := range nodes {
+				nodes[i] = fmt.Sprintf(`{"id":"i%d"}`, i)
+			}
+			connection := fmt.Sprintf(`{"nodes":[%s],"pageInfo":{"hasNextPage":%t,"endCursor":"next"}}`, strings.Join(nodes, ","), tt.more)
+
+			var seen []graphQLRequest
+			transport := answerTransport{&seen, func(request graphQLRequest) string {
+				switch {
+				case strings.Contains(request.Query, "assignedIssues"):
+					return `{"data":{"user":{"assignedIssues":` + connection + `}}}`
+				case strings.Contains(request.Query, "createdIssues"):
+					return `{"data":{"user":{"createdIssues":` + connection + `}}}`
+				default:
+					return `{"data":{"user":{"id":"u1","name":"Ada","email":"","displayName":"","active":true,"admin":false,"timezone":"","avatarUrl":""}}}`
+				}
+			}}
+			c, err := New("lin_api_test", WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			user, err := c.GetUser(context.Background(), "u1", true)
+			if err != nil {
+				t.Fatalf("GetUser() error = %v", err)
+			}
+			if *user.AssignedIssueCount != tt.wantCount || user.AssignedIssuesCapped != tt.wantCapped {
+				t.Errorf("assigned = %d (capped %v), want %d (capped %v)", *user.AssignedIssueCount, user.AssignedIssuesCapped, tt.wantCount, tt.wantCapped)
+			}
+			if *user.CreatedIssueCount != tt.wantCount || user.CreatedIssuesCapped != tt.wantCapped {
+				t.Errorf("created = %d (capped %v), want %d (capped %v)", *user.CreatedIssueCount, user.CreatedIssuesCapped, tt.wantCount, tt.wantCapped)
+			}
+
+			for _, request := range seen[1:] {
+				if strings.Contains(request.Query, "title") || request.Variables["first"].(float64) > countPageSize {
+					t.Errorf("count query = %s with first %v, want IDs only, at most %d", request.Query, request.Variables["first"], countPageSize)
+				}
+			}
+			if first := seen[1].Variables["first"]; first != float64(countPageSize) {
+				t.Errorf("first = %v, want %d", first, countPageSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// ErrIssueNotFound is returned (wrapped) when an identifier names no issue
+var ErrIssueNotFound = errors.New("issue not found")
+
+// maxSuggestions caps the issues SuggestIssues returns
+const maxSuggestions = 5
+
+// identifierPattern splits an issue identifier into team key and number
+var identifierPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-([0-9]+)$`)
+
+// branchIdentifierPattern finds an issue identifier in a git branch name
+var branchIdentifierPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9]*-[0-9]+)`)
+
+// BranchIdentifier returns the issue identifier named by a git branch, such
+// as ENG-123 for Linear's alice/eng-123-fix-login. Only the last path
+// segment is searched, so a prefix like release-2024/ is not mistaken for
+// an issue. ok is false if the branch names no issue.
+func BranchIdentifier(branch string) (identifier string, ok bool) {
+	segment := branch[strings.LastIndex(branch, "/")+1:]
+	m := branchIdentifierPattern.FindStringSubmatch(segment)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToUpper(m[1]), true
+}
+
+// IdentifierNeighbors returns the team key of an identifier such as
+// ENG-999 and the issue numbers one typo away from its number: a digit
+// dropped, added, changed, or two adjacent digits swapped. ok is false if
+// identifier is not shaped like an issue identifier.
+func IdentifierNeighbors(identifier string) (teamKey string, numbers []int, ok bool) {
+	m := identifierPattern.FindStringSubmatch(identifier)
+	if m == nil {
+		return "", nil, false
+	}
+	digits := m[2]
+
+	candidates := []string{}
+	for i := range digits {
+		candidates = append(candidates, digits[:i]+digits[i+1:])
+		for d := '0'; d <= '9'; d++ {
+			candidates = append(candidates, digits[:i]+string(d)+digits[i+1:])
+		}
+		if i+1 < len(digits) {
+			candidates = append(candidates, digits[:i]+digits[i+1:i+2]+digits[i:i+1]+digits[i+2:])
+		}
+	}
+	for i := 0; i <= len(digits); i++ {
+		for d := '0'; d <= '9'; d++ {
+			candidates = append(candidates, digits[:i]+string(d)+digits[i:])
+		}
+	}
+
+	seen := map[int]bool{}
+	for _, candidate := range candidates {
+		if candidate == "" || candidate[0] == '0' || candidate == digits {
+			continue
+		}
+		n, err := strconv.Atoi(candidate)
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	return strings.ToUpper(m[1]), numbers, true
+}
+
+// SuggestIssues returns issues a reference that matched nothing may have
+// meant. For an identifier these are the team's issues whose number is one
+// typo away, closest number first; anything else is searched for in issue
+// content.
+func (c *Client) SuggestIssues(ctx context.Context, ref string) ([]model.Issue, error) {
+	teamKey, numbers, ok := IdentifierNeighbors(ref)
+	if !ok {
+		return c.ListIssues(WithLimit(ctx, maxSuggestions), &IssueFilters{Search: &ref})
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+
+	issues, err := c.ListIssues(WithLimit(ctx, len(numbers)), &IssueFilters{TeamKey: &teamKey, Numbers: numbers})
+	if err != nil {
+		return nil, err
+	}
+
+	want, _ := strconv.Atoi(identifierPattern.FindStringSubmatch(ref)[2])
+	SortByNumberDistance(issues, want)
+	if len(issues) > maxSuggestions {
+		issues = issues[:maxSuggestions]
+	}
+	return issues, nil
+}
+
+// SortByNumberDistance orders issues by how far their identifier's number
+// is from want, lower numbers first on ties
+func SortByNumberDistance(issues []model.Issue, want int) {
+	distance := func(issue model.Issue) (int, int) {
+		m := identifierPattern.FindStringSubmatch(issue.Identifier)
+		if m == nil {
+			return int(^uint(0) >> 1), 0
+		}
+		n, _ := strconv.Atoi(m[2])
+		if n > want {
+			return n - want, n
+		}
+		return want - n, n
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		di, ni := distance(issues[i])
+		dj, nj := distance(issues[j])
+		if di != dj {
+			return di < dj
+		}
+		return ni < nj
+	})
+}
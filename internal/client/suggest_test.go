@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// TestIdentifierNeighbors verifies the numbers one typo away from an
+// identifier's number, and that non-identifiers are rejected.
+func TestIdentifierNeighbors(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		wantKey    string
+		wantOK     bool
+		include    []int
+		exclude    []int
+	}{
+		{name: "Extra digit", identifier: "ENG-999", wantKey: "ENG", wantOK: true, include: []int{99, 998, 9990}, exclude: []int{999}},
+		{name: "Swapped digits", identifier: "eng-132", wantKey: "ENG", wantOK: true, include: []int{123, 312, 13, 1320}},
+		{name: "No leading zeros", identifier: "ENG-10", wantKey: "ENG", wantOK: true, include: []int{1, 11, 100}, exclude: []int{0, 10}},
+		{name: "Title", identifier: "login bug", wantOK: false},
+		{name: "Missing number", identifier: "ENG-", wantOK: false},
+		{name: "UUID-like", identifier: "8c7e0b0a-1234", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, numbers, ok := IdentifierNeighbors(tt.identifier)
+			if ok != tt.wantOK || key != tt.wantKey {
+				t.Fatalf("IdentifierNeighbors(%q) = %q, _, %v, want %q, _, %v", tt.identifier, key, ok, tt.wantKey, tt.wantOK)
+			}
+
+			found := map[int]int{}
+			for _, n := range numbers {
+				found[n]++
+			}
+			for _, n := range tt.include {
+				if found[n] == 0 {
+					t.Errorf("numbers missing %d: %v", n, numbers)
+				}
+			}
+			for _, n := range tt.exclude {
+				if found[n] > 0 {
+					t.Errorf("numbers include %d: %v", n, numbers)
+				}
+			}
+			for n, count := range found {
+				if count > 1 {
+					t.Errorf("number %d listed %d times", n, count)
+				}
+			}
+		})
+	}
+}
+
+// TestBranchIdentifier verifies the issue identifier is found in Linear's
+// branch names and is not taken from other parts of the name.
+func TestBranchIdentifier(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "alice/eng-123-fix-login", want: "ENG-123"},
+		{branch: "ENG-7", want: "ENG-7"},
+		{branch: "feature/fix-eng-42", want: "ENG-42"},
+		{branch: "release-2024/ops2-9-hotfix", want: "OPS2-9"},
+		{branch: "release-2024/cleanup"},
+		{branch: "main"},
+		{branch: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, ok := BranchIdentifier(tt.branch)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("BranchIdentifier(%q) = %q, %v, want %q", tt.branch, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+// TestSortByNumberDistance verifies suggestions are ordered by closeness
+// to the mistyped number.
+func TestSortByNumberDistance(t *testing.T) {
+	issues := []model.Issue{
+		{Identifier: "ENG-99"},
+		{Identifier: "ENG-9999"},
+		{Identifier: "ENG-1000"},
+		{Identifier: "ENG-998"},
+	}
+
+	SortByNumberDistance(issues, 999)
+
+	got := make([]string, len(issues))
+	for i, issue := range issues {
+		got[i] = issue.Identifier
+	}
+	want := []string{"ENG-998", "ENG-1000", "ENG-99", "ENG-9999"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+// TestSuggestIssuesFilter verifies both kinds of suggestion query send their
+// criteria as a typed $filter.
+func TestSuggestIssuesFilter(t *testing.T) {
+	_, neighbors, _ := IdentifierNeighbors("ENG-12")
+	numbers := make([]interface{}, len(neighbors))
+	for i, n := range neighbors {
+		numbers[i] = float64(n)
+	}
+
+	tests := []struct {
+		name string
+		ref  string
+		want map[string]interface{}
+	}{
+		{name: "Identifier", ref: "eng-12", want: map[string]interface{}{
+			"team":   map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": "ENG"}},
+			"number": map[string]interface{}{"in": numbers},
+		}},
+		{name: "Text", ref: "login bug", want: map[string]interface{}{
+			"searchableContent": map[string]interface{}{"containsIgnoreCase": "login bug"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := captureRequest(t, `{"data":{"issues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`, func(c *Client) error {
+				_, err := c.SuggestIssues(context.Background(), tt.ref)
+				return err
+			})
+			assertFilter(t, request, "IssueFilter", tt.want)
+		})
+	}
+}
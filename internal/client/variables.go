@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseVariable parses a `lirt api --var` argument into a name and a typed
+// value. The form key=value infers the type: true/false become booleans,
+// integers and decimals become numbers, null becomes nil, values starting
+// with [ or { are decoded as JSON, and anything else stays a string. The
+// form key:type=value forces the type, one of string, int, float, bool, or
+// json (e.g. id:string=123 to keep a numeric-looking ID a string).
+func ParseVariable(arg string) (string, interface{}, error) {
+	key, raw, ok := strings.Cut(arg, "=")
+	if !ok || key == "" {
+		return "", nil, fmt.Errorf("invalid variable format: %s (expected key=value or key:type=value)", arg)
+	}
+
+	name, typ, typed := strings.Cut(key, ":")
+	if name == "" {
+		return "", nil, fmt.Errorf("invalid variable format: %s (missing name)", arg)
+	}
+	if !typed {
+		return name, inferVariable(raw), nil
+	}
+
+	var value interface{}
+	var err error
+	switch typ {
+	case "string":
+		value = raw
+	case "int":
+		value, err = strconv.Atoi(raw)
+	case "float":
+		var f float64
+		if f, err = strconv.ParseFloat(raw, 64); err == nil && !isFinite(f) {
+			err = fmt.Errorf("%s is not a finite number", raw)
+		}
+		value = f
+	case "bool":
+		value, err = strconv.ParseBool(raw)
+	case "json":
+		err = json.Unmarshal([]byte(raw), &value)
+	default:
+		return "", nil, fmt.Errorf("invalid variable type %q for %s (must be string, int, float, bool, or json)", typ, name)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid %s value for %s: %s", typ, name, raw)
+	}
+	return name, value, nil
+}
+
+// inferVariable guesses the type of an untyped variable value
+func inferVariable(raw string) interface{} {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if i, err := strconv.Atoi(raw); err == nil {
+		return i
+	}
+	// ParseFloat also accepts inf and nan, which JSON cannot encode
+	if f, err := strconv.ParseFloat(raw, 64); err == nil && isFinite(f) {
+		return f
+	}
+
+	if strings.HasPrefix(raw, "[") || strings.HasPrefix(raw, "{") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err == nil {
+			return value
+		}
+	}
+
+	return raw
+}
+
+// isFinite reports whether f is neither infinite nor NaN
+func isFinite(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
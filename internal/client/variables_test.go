@@ -0,0 +1,57 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseVariable verifies type inference for key=value and explicit
+// typing for key:type=value.
+func TestParseVariable(t *testing.T) {
+	tests := []struct {
+		name      string
+		arg       string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		{name: "String", arg: "id=ENG-123", wantKey: "id", wantValue: "ENG-123"},
+		{name: "Value containing equals", arg: "q=a=b", wantKey: "q", wantValue: "a=b"},
+		{name: "Int", arg: "first=5", wantKey: "first", wantValue: 5},
+		{name: "Float", arg: "estimate=2.5", wantKey: "estimate", wantValue: 2.5},
+		{name: "Infinity stays string", arg: "name=Infinity", wantKey: "name", wantValue: "Infinity"},
+		{name: "NaN stays string", arg: "q=nan", wantKey: "q", wantValue: "nan"},
+		{name: "Bool", arg: "includeArchived=true", wantKey: "includeArchived", wantValue: true},
+		{name: "Null", arg: "after=null", wantKey: "after", wantValue: nil},
+		{name: "JSON array", arg: `ids=["a","b"]`, wantKey: "ids", wantValue: []interface{}{"a", "b"}},
+		{name: "JSON object", arg: `filter={"priority":{"eq":1}}`, wantKey: "filter", wantValue: map[string]interface{}{"priority": map[string]interface{}{"eq": float64(1)}}},
+		{name: "Invalid JSON stays string", arg: "title=[WIP", wantKey: "title", wantValue: "[WIP"},
+		{name: "Explicit string", arg: "id:string=123", wantKey: "id", wantValue: "123"},
+		{name: "Explicit int", arg: "first:int=10", wantKey: "first", wantValue: 10},
+		{name: "Explicit bool", arg: "flag:bool=false", wantKey: "flag", wantValue: false},
+		{name: "Explicit json", arg: `ids:json=["a"]`, wantKey: "ids", wantValue: []interface{}{"a"}},
+		{name: "Bad explicit int", arg: "first:int=ten", wantErr: true},
+		{name: "Explicit float infinity", arg: "estimate:float=-inf", wantErr: true},
+		{name: "Unknown type", arg: "x:date=today", wantErr: true},
+		{name: "Missing equals", arg: "first", wantErr: true},
+		{name: "Missing name", arg: "=5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, err := ParseVariable(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVariable(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if key != tt.wantKey {
+				t.Errorf("ParseVariable(%q) key = %q, want %q", tt.arg, key, tt.wantKey)
+			}
+			if !reflect.DeepEqual(value, tt.wantValue) {
+				t.Errorf("ParseVariable(%q) value = %#v, want %#v", tt.arg, value, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// AliasSection is the config file section holding command aliases. Aliases
+// are shared by all profiles.
+const AliasSection = "alias"
+
+// ValidateProfileName rejects profile names that collide with other config
+// file sections, such as the alias section
+func ValidateProfileName(profile string) error {
+	if profile == AliasSection {
+		return fmt.Errorf("%q is reserved for command aliases and cannot be used as a profile name", profile)
+	}
+	return nil
+}
+
+// positionalPattern matches $1-style references in an alias expansion
+var positionalPattern = regexp.MustCompile(`\$[0-9]+`)
+
+// LoadAliases returns the configured aliases, keyed by name
+func LoadAliases() (map[string]string, error) {
+	aliases := make(map[string]string)
+
+	configFile := GetConfigFile()
+	if _, err := os.Stat(configFile); err != nil {
+		return aliases, nil
+	}
+	iniFile, err := ini.Load(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	if iniFile.HasSection(AliasSection) {
+		for _, key := range iniFile.Section(AliasSection).Keys() {
+			aliases[key.Name()] = key.String()
+		}
+	}
+	return aliases, nil
+}
+
+// SaveAlias stores an alias expansion in the config file
+func SaveAlias(name, expansion string) error {
+	return updateConfigFile(func(iniFile *ini.File) {
+		iniFile.Section(AliasSection).Key(name).SetValue(expansion)
+	})
+}
+
+// DeleteAlias removes an alias from the config file
+func DeleteAlias(name string) error {
+	configFile := GetConfigFile()
+	iniFile, err := ini.Load(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no such alias: %s", name)
+		}
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	section := iniFile.Section(AliasSection)
+	if !section.HasKey(name) {
+		return fmt.Errorf("no such alias: %s", name)
+	}
+	section.DeleteKey(name)
+
+	if err := saveAtomic(iniFile, configFile, 0644); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+	return nil
+}
+
+// ExpandAlias turns an alias expansion and the arguments given after the
+// alias name into the full argument list. $1, $2, ... are replaced with the
+// matching argument; arguments not referenced are appended at the end.
+func ExpandAlias(expansion string, args []string) ([]string, error) {
+	words, err := SplitArgs(expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]bool, len(args))
+	expanded := make([]string, 0, len(words)+len(args))
+	for _, word := range words {
+		var substErr error
+		word = positionalPattern.ReplaceAllStringFunc(word, func(ref string) string {
+			n, _ := strconv.Atoi(ref[1:])
+			if n < 1 || n > len(args) {
+				substErr = fmt.Errorf("alias expects argument %s but got %d argument(s)", ref, len(args))
+				return ref
+			}
+			used[n-1] = true
+			return args[n-1]
+		})
+		if substErr != nil {
+			return nil, substErr
+		}
+		expanded = append(expanded, word)
+	}
+
+	for i, arg := range args {
+		if !used[i] {
+			expanded = append(expanded, arg)
+		}
+	}
+	return expanded, nil
+}
+
+// SplitArgs splits a command string into words the way a POSIX shell would
+// for simple cases: whitespace separates words, single quotes are literal,
+// and double quotes and backslashes escape whitespace and quotes.
+func SplitArgs(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			if i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+				inWord = true
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, s)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
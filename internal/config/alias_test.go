@@ -0,0 +1,150 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSplitArgs verifies shell-style word splitting of alias expansions
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Plain words", input: "issue list --team ENG", expected: []string{"issue", "list", "--team", "ENG"}},
+		{name: "Extra whitespace", input: "  issue\tlist  ", expected: []string{"issue", "list"}},
+		{name: "Double quotes", input: `issue list --search "login bug"`, expected: []string{"issue", "list", "--search", "login bug"}},
+		{name: "Single quotes are literal", input: `api '{ viewer { id } }' --var 'a=\n'`, expected: []string{"api", "{ viewer { id } }", "--var", `a=\n`}},
+		{name: "Backslash escape", input: `issue list --search login\ bug`, expected: []string{"issue", "list", "--search", "login bug"}},
+		{name: "Empty quoted word", input: `issue edit "" x`, expected: []string{"issue", "edit", "", "x"}},
+		{name: "Empty", input: "", expected: nil},
+		{name: "Unterminated quote", input: `issue list --search "login`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SplitArgs(%q) expected error, got %q", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitArgs(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestExpandAlias verifies $N substitution, appending of unreferenced
+// arguments, and errors for missing positional arguments.
+func TestExpandAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		expansion string
+		args      []string
+		expected  []string
+		wantErr   bool
+	}{
+		{
+			name:      "No arguments",
+			expansion: "issue list --label bug",
+			expected:  []string{"issue", "list", "--label", "bug"},
+		},
+		{
+			name:      "Extra arguments appended",
+			expansion: "issue list --label bug",
+			args:      []string{"--team", "ENG"},
+			expected:  []string{"issue", "list", "--label", "bug", "--team", "ENG"},
+		},
+		{
+			name:      "Positional substitution",
+			expansion: "issue list --team $1 --label $2",
+			args:      []string{"ENG", "bug"},
+			expected:  []string{"issue", "list", "--team", "ENG", "--label", "bug"},
+		},
+		{
+			name:      "Positional inside a word",
+			expansion: "issue view ENG-$1",
+			args:      []string{"42", "--raw"},
+			expected:  []string{"issue", "view", "ENG-42", "--raw"},
+		},
+		{
+			name:      "Argument keeps its spaces",
+			expansion: "issue list --search $1",
+			args:      []string{"login bug"},
+			expected:  []string{"issue", "list", "--search", "login bug"},
+		},
+		{
+			name:      "Missing positional",
+			expansion: "issue list --team $2",
+			args:      []string{"ENG"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandAlias(tt.expansion, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExpandAlias() expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandAlias() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExpandAlias() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestAliasStore verifies aliases round-trip through the config file,
+// including quoted expansions, and are not reported as profiles.
+func TestAliasStore(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	t.Setenv("LIRT_CONFIG_FILE", "")
+
+	expansion := `issue list --search "login bug" --team $1`
+	if err := SaveAlias("bugs", expansion); err != nil {
+		t.Fatalf("SaveAlias() error = %v", err)
+	}
+	if err := SaveConfigValue("default", "team", "ENG"); err != nil {
+		t.Fatalf("SaveConfigValue() error = %v", err)
+	}
+
+	aliases, err := LoadAliases()
+	if err != nil {
+		t.Fatalf("LoadAliases() error = %v", err)
+	}
+	if aliases["bugs"] != expansion {
+		t.Errorf("LoadAliases()[bugs] = %q, want %q", aliases["bugs"], expansion)
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if _, ok := profiles[AliasSection]; ok {
+		t.Errorf("ListProfiles() = %v, should not include the alias section", profiles)
+	}
+
+	if err := DeleteAlias("bugs"); err != nil {
+		t.Fatalf("DeleteAlias() error = %v", err)
+	}
+	if err := DeleteAlias("bugs"); err == nil {
+		t.Error("DeleteAlias() of a missing alias expected error")
+	}
+	if aliases, _ := LoadAliases(); len(aliases) != 0 {
+		t.Errorf("LoadAliases() after delete = %v, want none", aliases)
+	}
+}
@@ -4,19 +4,104 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/ini.v1"
 )
 
 // Config represents lirt configuration
 type Config struct {
-	Profile   string
-	APIKey    string
-	Team      string
-	Format    string
-	CacheTTL  string
-	PageSize  int
-	Workspace string // Display-only, set by auth login
+	Profile           string
+	APIKey            string
+	Team              string
+	Format            string
+	CacheTTL          string
+	PageSize          int
+	IncrementalMaxAge string // Max cache age for incremental refresh before a full refetch
+	Workspace         string // Display-only, set by auth login
+	Favorites         string // Where favorites are kept: linear or local
+	AutoJSON          bool   // Switch to JSON when piped and no format is configured
+	Timezone          string // IANA zone for displayed timestamps, e.g. Europe/Berlin
+	RetryAttempts     int    // Retries of a page that fails transiently
+	RetryBaseDelay    string // Wait before the first retry, growing by this much per retry
+	RetryMaxDelay     string // Longest wait between retries
+	ProjectFile       string // Path of the .lirt file applied, if any
+
+	// CommandFormats maps dotted command paths (e.g. "issue.list") to a
+	// format override read from keys like "issue.list.format"
+	CommandFormats map[string]string
+
+	// TeamDefaults maps upper-case team keys to the issue create defaults
+	// from [team.<KEY>] sections
+	TeamDefaults map[string]TeamDefaults
+
+	// formatSet records that Format came from a config or project file
+	// rather than the built-in default
+	formatSet bool
+}
+
+// ProjectConfigFile is the name of the project-local config file
+const ProjectConfigFile = ".lirt"
+
+// ProjectConfig represents settings pinned by a project-local .lirt file
+type ProjectConfig struct {
+	Path    string
+	Profile string
+	Team    string
+	Format  string
+}
+
+// FindProjectConfig walks up from dir looking for a .lirt file and loads the
+// first one found. Returns nil if no project file exists.
+func FindProjectConfig(dir string) (*ProjectConfig, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			iniFile, err := ini.Load(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load project config %s: %w", path, err)
+			}
+
+			// Keys may be top-level or under a [default] section
+			sec := iniFile.Section(ini.DefaultSection)
+			if len(sec.Keys()) == 0 && iniFile.HasSection("default") {
+				sec = iniFile.Section("default")
+			}
+			return &ProjectConfig{
+				Path:    path,
+				Profile: sec.Key("profile").String(),
+				Team:    sec.Key("team").String(),
+				Format:  sec.Key("format").String(),
+			}, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// loadProjectConfig finds the project config for the current directory,
+// ignoring lookup errors so a broken .lirt never blocks a command
+func loadProjectConfig() *ProjectConfig {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	project, err := FindProjectConfig(cwd)
+	if err != nil {
+		return nil
+	}
+	return project
 }
 
 // GetConfigDir returns the lirt config directory
@@ -56,10 +141,16 @@ func EnsureConfigDir() error {
 // LoadConfig loads configuration for the given profile
 func LoadConfig(profile string) (*Config, error) {
 	cfg := &Config{
-		Profile:  profile,
-		Format:   "table",
-		CacheTTL: "5m",
-		PageSize: 50,
+		Profile:           profile,
+		Format:            "table",
+		CacheTTL:          "5m",
+		PageSize:          50,
+		IncrementalMaxAge: "24h",
+		Favorites:         FavoritesLinear,
+		AutoJSON:          true,
+		RetryAttempts:     3,
+		RetryBaseDelay:    "500ms",
+		RetryMaxDelay:     "5s",
 	}
 
 	// Load config file
@@ -70,6 +161,8 @@ func LoadConfig(profile string) (*Config, error) {
 			return nil, fmt.Errorf("failed to load config file: %w", err)
 		}
 
+		cfg.TeamDefaults = loadTeamDefaults(iniFile)
+
 		section := "default"
 		if profile != "default" {
 			section = "profile " + profile
@@ -85,6 +178,7 @@ func LoadConfig(profile string) (*Config, error) {
 			}
 			if sec.HasKey("format") {
 				cfg.Format = sec.Key("format").String()
+				cfg.formatSet = true
 			}
 			if sec.HasKey("cache_ttl") {
 				cfg.CacheTTL = sec.Key("cache_ttl").String()
@@ -92,6 +186,51 @@ func LoadConfig(profile string) (*Config, error) {
 			if sec.HasKey("page_size") {
 				cfg.PageSize, _ = sec.Key("page_size").Int()
 			}
+			if sec.HasKey("incremental_max_age") {
+				cfg.IncrementalMaxAge = sec.Key("incremental_max_age").String()
+			}
+			if sec.HasKey("favorites") {
+				cfg.Favorites = sec.Key("favorites").String()
+			}
+			if sec.HasKey("auto_json") {
+				cfg.AutoJSON = sec.Key("auto_json").MustBool(true)
+			}
+			if sec.HasKey("timezone") {
+				cfg.Timezone = sec.Key("timezone").String()
+			}
+			if sec.HasKey("retry_attempts") {
+				// Not a number: -1 is reported by Validate
+				cfg.RetryAttempts = sec.Key("retry_attempts").MustInt(-1)
+			}
+			if sec.HasKey("retry_base_delay") {
+				cfg.RetryBaseDelay = sec.Key("retry_base_delay").String()
+			}
+			if sec.HasKey("retry_max_delay") {
+				cfg.RetryMaxDelay = sec.Key("retry_max_delay").String()
+			}
+			for _, key := range sec.Keys() {
+				name := key.Name()
+				if strings.HasSuffix(name, ".format") {
+					if cfg.CommandFormats == nil {
+						cfg.CommandFormats = make(map[string]string)
+					}
+					cfg.CommandFormats[strings.TrimSuffix(name, ".format")] = key.String()
+				}
+			}
+		}
+	}
+
+	// Project file settings take precedence over user config. A project
+	// format supersedes per-command formats from the user config too.
+	if project := loadProjectConfig(); project != nil {
+		cfg.ProjectFile = project.Path
+		if project.Team != "" {
+			cfg.Team = project.Team
+		}
+		if project.Format != "" {
+			cfg.Format = project.Format
+			cfg.CommandFormats = nil
+			cfg.formatSet = true
 		}
 	}
 
@@ -104,14 +243,125 @@ func LoadConfig(profile string) (*Config, error) {
 	return cfg, nil
 }
 
+// FormatFor returns the output format for a command path such as
+// ["issue", "list"], preferring the most specific per-command override
+// ("issue.list.format", then "issue.format") over the global format.
+func (c *Config) FormatFor(commandPath []string) string {
+	if format := c.ConfiguredFormatFor(commandPath); format != "" {
+		return format
+	}
+	return c.Format
+}
+
+// ConfiguredFormatFor is FormatFor limited to formats set in a config or
+// project file. It returns "" when only the built-in default applies, so
+// callers can pick a context-dependent default (JSON when piped).
+func (c *Config) ConfiguredFormatFor(commandPath []string) string {
+	for i := len(commandPath); i > 0; i-- {
+		if format, ok := c.CommandFormats[strings.Join(commandPath[:i], ".")]; ok && format != "" {
+			return format
+		}
+	}
+	if c.formatSet {
+		return c.Format
+	}
+	return ""
+}
+
+// validFormats are the output formats accepted by the format setting
+var validFormats = []string{"table", "json", "csv", "plain"}
+
+// Validate checks settings that are parsed lazily elsewhere and returns a
+// description of each problem found
+func (c *Config) Validate() []string {
+	problems := []string{}
+
+	formats := map[string]string{"format": c.Format}
+	for command, format := range c.CommandFormats {
+		formats[command+".format"] = format
+	}
+	for key, format := range formats {
+		valid := false
+		for _, f := range validFormats {
+			if format == f {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			problems = append(problems, fmt.Sprintf("%s: unknown format %q (must be one of: %s)", key, format, strings.Join(validFormats, ", ")))
+		}
+	}
+
+	durations := map[string]string{"cache_ttl": c.CacheTTL, "incremental_max_age": c.IncrementalMaxAge}
+	for key, value := range durations {
+		if _, err := time.ParseDuration(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid duration %q", key, value))
+		}
+	}
+
+	if _, _, _, err := c.Retry(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if c.PageSize < 1 || c.PageSize > 100 {
+		problems = append(problems, fmt.Sprintf("page_size: %d is out of range (1-100)", c.PageSize))
+	}
+
+	if c.Favorites != FavoritesLinear && c.Favorites != FavoritesLocal {
+		problems = append(problems, fmt.Sprintf("favorites: unknown mode %q (must be %s or %s)", c.Favorites, FavoritesLinear, FavoritesLocal))
+	}
+
+	if err := ValidateProfileName(c.Profile); err != nil {
+		problems = append(problems, "profile: "+err.Error())
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			problems = append(problems, fmt.Sprintf("timezone: unknown time zone %q", c.Timezone))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// maxRetryAttempts caps retry_attempts so a bad setting cannot stall a
+// command for minutes
+const maxRetryAttempts = 10
+
+// Retry parses the retry settings: how many times a failing page is
+// retried, the wait before the first retry, and the longest wait
+func (c *Config) Retry() (attempts int, baseDelay, maxDelay time.Duration, err error) {
+	if c.RetryAttempts < 0 || c.RetryAttempts > maxRetryAttempts {
+		return 0, 0, 0, fmt.Errorf("retry_attempts: must be a number from 0 to %d", maxRetryAttempts)
+	}
+	if baseDelay, err = time.ParseDuration(c.RetryBaseDelay); err != nil || baseDelay < 0 {
+		return 0, 0, 0, fmt.Errorf("retry_base_delay: invalid duration %q", c.RetryBaseDelay)
+	}
+	if maxDelay, err = time.ParseDuration(c.RetryMaxDelay); err != nil || maxDelay < 0 {
+		return 0, 0, 0, fmt.Errorf("retry_max_delay: invalid duration %q", c.RetryMaxDelay)
+	}
+	if maxDelay < baseDelay {
+		return 0, 0, 0, fmt.Errorf("retry_max_delay: %s is shorter than retry_base_delay %s", maxDelay, baseDelay)
+	}
+	return c.RetryAttempts, baseDelay, maxDelay, nil
+}
+
 // LoadAPIKey loads the API key for the given profile
-// Resolution order: LIRT_API_KEY, --api-key flag (handled by caller), credentials file, LINEAR_API_KEY
+// Resolution order: LIRT_API_KEY, LIRT_API_KEY_FILE, --api-key flag (handled by caller), credentials file, LINEAR_API_KEY
 func LoadAPIKey(profile string) (string, error) {
 	// Check LIRT_API_KEY env var (highest priority)
 	if key := os.Getenv("LIRT_API_KEY"); key != "" {
 		return key, nil
 	}
 
+	// A key file that is named but unreadable is an error rather than a
+	// silent fall through to another key
+	if path := os.Getenv("LIRT_API_KEY_FILE"); path != "" {
+		return ReadAPIKeyFile(path)
+	}
+
 	// Load from credentials file
 	credFile := GetCredentialsFile()
 	if _, err := os.Stat(credFile); err == nil {
@@ -136,6 +386,21 @@ func LoadAPIKey(profile string) (string, error) {
 	return "", fmt.Errorf("no API key found for profile %q", profile)
 }
 
+// ReadAPIKeyFile reads an API key from a file such as one written by a
+// secrets manager, trimming surrounding whitespace
+func ReadAPIKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+	return key, nil
+}
+
 // SaveAPIKey saves an API key to the credentials file
 func SaveAPIKey(profile, apiKey string) error {
 	if err := EnsureConfigDir(); err != nil {
@@ -171,6 +436,31 @@ func SaveAPIKey(profile, apiKey string) error {
 
 // SaveConfigValue saves a config value to the config file
 func SaveConfigValue(profile, key, value string) error {
+	return SaveConfigValues(profile, map[string]string{key: value})
+}
+
+// SaveConfigValues saves several config values for a profile with a single
+// load and atomic write of the config file
+func SaveConfigValues(profile string, values map[string]string) error {
+	return updateConfigFile(func(iniFile *ini.File) {
+		section := iniFile.Section(profileSection(profile))
+		for key, value := range values {
+			section.Key(key).SetValue(value)
+		}
+	})
+}
+
+// profileSection returns the config file section name for a profile
+func profileSection(profile string) string {
+	if profile == "default" {
+		return "default"
+	}
+	return "profile " + profile
+}
+
+// updateConfigFile loads the config file (or starts an empty one), applies
+// update, and atomically replaces the file with the result
+func updateConfigFile(update func(*ini.File)) error {
 	if err := EnsureConfigDir(); err != nil {
 		return err
 	}
@@ -187,24 +477,45 @@ func SaveConfigValue(profile, key, value string) error {
 		iniFile = ini.Empty()
 	}
 
-	sectionName := "default"
-	if profile != "default" {
-		sectionName = "profile " + profile
-	}
+	update(iniFile)
 
-	section, err := iniFile.NewSection(sectionName)
-	if err != nil {
-		section = iniFile.Section(sectionName)
+	// Set readable permissions for config
+	if err := saveAtomic(iniFile, configFile, 0644); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
 	}
+	return nil
+}
 
-	section.Key(key).SetValue(value)
+// saveAtomic writes iniFile to a temporary file beside path and renames it
+// into place, so readers never see a partially written file. It does not
+// lock: two concurrent load-update-save cycles can still lose one update.
+// A symlinked path is resolved first so the link itself is preserved and
+// the temporary file lands on the target's filesystem.
+func saveAtomic(iniFile *ini.File, path string, perm os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	} else if !os.IsNotExist(err) {
+		return err
+	}
 
-	if err := iniFile.SaveTo(configFile); err != nil {
-		return fmt.Errorf("failed to save config file: %w", err)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
 	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
 
-	// Set readable permissions for config
-	return os.Chmod(configFile, 0644)
+	if _, err := iniFile.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 // DeleteProfile removes a profile from both credentials and config files
@@ -255,7 +566,7 @@ func ListProfiles() (map[string]string, error) {
 
 		for _, section := range iniFile.Sections() {
 			name := section.Name()
-			if name == ini.DefaultSection || name == "" {
+			if name == ini.DefaultSection || name == "" || name == AliasSection || isTeamSection(name) {
 				continue
 			}
 
@@ -285,7 +596,8 @@ func ListProfiles() (map[string]string, error) {
 	return profiles, nil
 }
 
-// GetProfile returns the profile name to use based on flags and env vars
+// GetProfile returns the profile name to use based on flags, env vars, and
+// the project-local .lirt file
 func GetProfile(profileFlag string) string {
 	if profileFlag != "" {
 		return profileFlag
@@ -293,5 +605,8 @@ func GetProfile(profileFlag string) string {
 	if profile := os.Getenv("LIRT_PROFILE"); profile != "" {
 		return profile
 	}
+	if project := loadProjectConfig(); project != nil && project.Profile != "" {
+		return project.Profile
+	}
 	return "default"
 }
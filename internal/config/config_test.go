@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/dixson3/lirt/internal/testutil"
@@ -161,3 +162,315 @@ func setUmask(mask int) int {
 	// For portable tests, we just document the behavior
 	return 0
 }
+
+// TestFormatFor verifies that the most specific per-command format wins and
+// that the global format is the fallback.
+func TestFormatFor(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config")
+	content := `[default]
+format = table
+issue.format = csv
+issue.list.format = json
+`
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("LIRT_CONFIG_FILE", configFile)
+	t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+
+	cfg, err := LoadConfig("default")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{path: []string{"issue", "list"}, expected: "json"},
+		{path: []string{"issue", "view"}, expected: "csv"},
+		{path: []string{"team", "list"}, expected: "table"},
+		{path: []string{}, expected: "table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.path, "."), func(t *testing.T) {
+			if got := cfg.FormatFor(tt.path); got != tt.expected {
+				t.Errorf("FormatFor(%v) = %q, want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConfiguredFormatFor verifies that only formats set in a config file
+// count as configured, so the built-in table default does not stop piped
+// output from switching to JSON.
+func TestConfiguredFormatFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		path    []string
+		want    string
+	}{
+		{name: "no config", content: "", path: []string{"issue", "list"}, want: ""},
+		{name: "global format", content: "[default]\nformat = csv\n", path: []string{"issue", "list"}, want: "csv"},
+		{name: "explicit table", content: "[default]\nformat = table\n", path: []string{"team", "list"}, want: "table"},
+		{name: "per-command only", content: "[default]\nissue.list.format = table\n", path: []string{"issue", "list"}, want: "table"},
+		{name: "other command", content: "[default]\nissue.list.format = csv\n", path: []string{"team", "list"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configFile := filepath.Join(tempDir, "config")
+			if err := os.WriteFile(configFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			t.Setenv("LIRT_CONFIG_FILE", configFile)
+			t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+			t.Chdir(tempDir)
+
+			cfg, err := LoadConfig("default")
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if got := cfg.ConfiguredFormatFor(tt.path); got != tt.want {
+				t.Errorf("ConfiguredFormatFor(%v) = %q, want %q", tt.path, got, tt.want)
+			}
+			if tt.want == "" && cfg.FormatFor(tt.path) != "table" {
+				t.Errorf("FormatFor(%v) = %q, want the table default", tt.path, cfg.FormatFor(tt.path))
+			}
+		})
+	}
+}
+
+// TestAutoJSON verifies that auto_json defaults to on and that setting it to
+// false disables the switch to JSON when piped.
+func TestAutoJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "default", content: "", want: true},
+		{name: "disabled", content: "[default]\nauto_json = false\n", want: false},
+		{name: "enabled", content: "[default]\nauto_json = true\n", want: true},
+		{name: "unset", content: "[default]\nauto_json =\n", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configFile := filepath.Join(tempDir, "config")
+			if err := os.WriteFile(configFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			t.Setenv("LIRT_CONFIG_FILE", configFile)
+			t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+			t.Chdir(tempDir)
+
+			cfg, err := LoadConfig("default")
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if cfg.AutoJSON != tt.want {
+				t.Errorf("AutoJSON = %v, want %v", cfg.AutoJSON, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadAPIKeyFile verifies LIRT_API_KEY_FILE is read and trimmed, ranks
+// below LIRT_API_KEY and above the credentials file, and fails loudly when
+// the file is missing or empty.
+func TestLoadAPIKeyFile(t *testing.T) {
+	tempDir := t.TempDir()
+	keyFile := filepath.Join(tempDir, "linear-key")
+	if err := os.WriteFile(keyFile, []byte("  lin_api_from_file\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+	emptyFile := filepath.Join(tempDir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+	t.Setenv("LINEAR_API_KEY", "")
+	if err := SaveAPIKey("default", "lin_api_from_credentials"); err != nil {
+		t.Fatalf("SaveAPIKey failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		envKey  string
+		keyFile string
+		want    string
+		wantErr string
+	}{
+		{name: "key file", keyFile: keyFile, want: "lin_api_from_file"},
+		{name: "env key wins", envKey: "lin_api_from_env", keyFile: keyFile, want: "lin_api_from_env"},
+		{name: "no key file", want: "lin_api_from_credentials"},
+		{name: "missing file", keyFile: filepath.Join(tempDir, "missing"), wantErr: "failed to read API key file"},
+		{name: "empty file", keyFile: emptyFile, wantErr: "is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LIRT_API_KEY", tt.envKey)
+			t.Setenv("LIRT_API_KEY_FILE", tt.keyFile)
+
+			got, err := LoadAPIKey("default")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadAPIKey() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadAPIKey() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LoadAPIKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFindProjectConfig verifies that the nearest .lirt file is found by
+// walking up from a nested directory and that its settings are parsed.
+func TestFindProjectConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	nested := filepath.Join(tempDir, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create dirs: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "top-level keys", content: "profile = work\nteam = ENG\nformat = json\n"},
+		{name: "default section", content: "[default]\nprofile = work\nteam = ENG\nformat = json\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, ProjectConfigFile)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write project config: %v", err)
+			}
+
+			project, err := FindProjectConfig(nested)
+			if err != nil {
+				t.Fatalf("FindProjectConfig failed: %v", err)
+			}
+			if project == nil {
+				t.Fatal("FindProjectConfig returned nil, want project config")
+			}
+			if project.Path != path {
+				t.Errorf("Path = %q, want %q", project.Path, path)
+			}
+			if project.Profile != "work" || project.Team != "ENG" || project.Format != "json" {
+				t.Errorf("got %+v, want profile=work team=ENG format=json", project)
+			}
+		})
+	}
+}
+
+// TestProjectConfigPrecedence verifies that a project file overrides user
+// config, while an explicit flag or LIRT_PROFILE still wins for the profile.
+func TestProjectConfigPrecedence(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config")
+	content := `[default]
+team = OPS
+format = table
+issue.format = csv
+`
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	projectDir := filepath.Join(tempDir, "repo")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+	project := "profile = work\nteam = ENG\nformat = json\n"
+	if err := os.WriteFile(filepath.Join(projectDir, ProjectConfigFile), []byte(project), 0644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+
+	t.Setenv("LIRT_CONFIG_FILE", configFile)
+	t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+	t.Setenv("LIRT_PROFILE", "")
+	t.Chdir(projectDir)
+
+	if got := GetProfile(""); got != "work" {
+		t.Errorf("GetProfile(\"\") = %q, want %q", got, "work")
+	}
+	if got := GetProfile("flag"); got != "flag" {
+		t.Errorf("GetProfile(\"flag\") = %q, want %q", got, "flag")
+	}
+	t.Setenv("LIRT_PROFILE", "env")
+	if got := GetProfile(""); got != "env" {
+		t.Errorf("GetProfile(\"\") with LIRT_PROFILE = %q, want %q", got, "env")
+	}
+
+	cfg, err := LoadConfig("default")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Team != "ENG" {
+		t.Errorf("Team = %q, want %q", cfg.Team, "ENG")
+	}
+	if got := cfg.FormatFor([]string{"issue", "list"}); got != "json" {
+		t.Errorf("FormatFor(issue list) = %q, want %q", got, "json")
+	}
+}
+
+// TestValidate verifies that invalid formats, durations, page sizes, and
+// favorites modes are reported, and that the defaults are valid.
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{Format: "table", CacheTTL: "5m", IncrementalMaxAge: "24h", PageSize: 50, Favorites: FavoritesLinear, RetryAttempts: 3, RetryBaseDelay: "500ms", RetryMaxDelay: "5s"}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(c *Config) {}},
+		{name: "unknown format", mutate: func(c *Config) { c.Format = "yaml" }, wantErr: "format: unknown format"},
+		{name: "unknown command format", mutate: func(c *Config) { c.CommandFormats = map[string]string{"issue.list": "xml"} }, wantErr: "issue.list.format"},
+		{name: "invalid cache_ttl", mutate: func(c *Config) { c.CacheTTL = "soon" }, wantErr: "cache_ttl"},
+		{name: "page_size too large", mutate: func(c *Config) { c.PageSize = 500 }, wantErr: "page_size"},
+		{name: "unknown favorites mode", mutate: func(c *Config) { c.Favorites = "cloud" }, wantErr: "favorites"},
+		{name: "no retries", mutate: func(c *Config) { c.RetryAttempts = 0 }},
+		{name: "negative retry_attempts", mutate: func(c *Config) { c.RetryAttempts = -1 }, wantErr: "retry_attempts"},
+		{name: "too many retry_attempts", mutate: func(c *Config) { c.RetryAttempts = 50 }, wantErr: "retry_attempts"},
+		{name: "invalid retry_base_delay", mutate: func(c *Config) { c.RetryBaseDelay = "fast" }, wantErr: "retry_base_delay"},
+		{name: "negative retry_max_delay", mutate: func(c *Config) { c.RetryMaxDelay = "-1s" }, wantErr: "retry_max_delay"},
+		{name: "retry_max_delay below base", mutate: func(c *Config) { c.RetryBaseDelay = "10s" }, wantErr: "shorter than retry_base_delay"},
+		{name: "reserved profile name", mutate: func(c *Config) { c.Profile = AliasSection }, wantErr: "reserved for command aliases"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(cfg)
+			problems := cfg.Validate()
+
+			if tt.wantErr == "" {
+				if len(problems) != 0 {
+					t.Errorf("Validate() = %v, want no problems", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.wantErr) {
+				t.Errorf("Validate() = %v, want one problem containing %q", problems, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// Favorites modes selected by the favorites setting
+const (
+	FavoritesLinear = "linear" // synced with Linear's favorites
+	FavoritesLocal  = "local"  // kept only in the local favorites file
+)
+
+// GetFavoritesFile returns the path of the local favorites file for a profile
+func GetFavoritesFile(profile string) string {
+	return filepath.Join(GetConfigDir(), "favorites", profile+".json")
+}
+
+// LoadLocalFavorites returns the profile's local favorites, oldest first
+func LoadLocalFavorites(profile string) ([]model.Favorite, error) {
+	favorites := []model.Favorite{}
+
+	data, err := os.ReadFile(GetFavoritesFile(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return favorites, nil
+		}
+		return nil, fmt.Errorf("failed to read favorites file: %w", err)
+	}
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil, fmt.Errorf("failed to parse favorites file: %w", err)
+	}
+	return favorites, nil
+}
+
+// AddLocalFavorite appends a favorite to the profile's local favorites. A
+// favorite with the same ID is replaced rather than duplicated.
+func AddLocalFavorite(profile string, favorite model.Favorite) error {
+	favorites, err := LoadLocalFavorites(profile)
+	if err != nil {
+		return err
+	}
+
+	kept := favorites[:0]
+	for _, f := range favorites {
+		if f.ID != favorite.ID {
+			kept = append(kept, f)
+		}
+	}
+	return saveLocalFavorites(profile, append(kept, favorite))
+}
+
+// RemoveLocalFavorite removes the favorite with the given ID from the
+// profile's local favorites
+func RemoveLocalFavorite(profile, id string) error {
+	favorites, err := LoadLocalFavorites(profile)
+	if err != nil {
+		return err
+	}
+
+	kept := favorites[:0]
+	for _, f := range favorites {
+		if f.ID != id {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == len(favorites) {
+		return fmt.Errorf("not a favorite: %s", id)
+	}
+	return saveLocalFavorites(profile, kept)
+}
+
+// saveLocalFavorites atomically replaces the profile's favorites file
+func saveLocalFavorites(profile string, favorites []model.Favorite) error {
+	if err := writeJSONAtomic(GetFavoritesFile(profile), favorites); err != nil {
+		return fmt.Errorf("failed to save favorites file: %w", err)
+	}
+	return nil
+}
+
+// writeJSONAtomic writes v as indented JSON to a temporary file beside path
+// and renames it into place, creating the directory if needed
+func writeJSONAtomic(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// TestLocalFavorites verifies local favorites round-trip per profile, that
+// re-adding replaces rather than duplicates, and that removal by ID works.
+func TestLocalFavorites(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+
+	if favorites, err := LoadLocalFavorites("default"); err != nil || len(favorites) != 0 {
+		t.Fatalf("LoadLocalFavorites() on missing file = %v, %v, want empty", favorites, err)
+	}
+
+	issue := model.Favorite{ID: "i1", Type: "issue", Issue: &model.Issue{ID: "i1", Identifier: "ENG-1", Title: "Old"}}
+	project := model.Favorite{ID: "p1", Type: "project", Project: &model.Project{ID: "p1", Name: "Launch"}}
+	for _, favorite := range []model.Favorite{issue, project} {
+		if err := AddLocalFavorite("default", favorite); err != nil {
+			t.Fatalf("AddLocalFavorite() error = %v", err)
+		}
+	}
+	issue.Issue.Title = "New"
+	if err := AddLocalFavorite("default", issue); err != nil {
+		t.Fatalf("AddLocalFavorite() error = %v", err)
+	}
+
+	favorites, err := LoadLocalFavorites("default")
+	if err != nil {
+		t.Fatalf("LoadLocalFavorites() error = %v", err)
+	}
+	if len(favorites) != 2 || favorites[0].ID != "p1" || favorites[1].Issue.Title != "New" {
+		t.Errorf("LoadLocalFavorites() = %+v, want project then updated issue", favorites)
+	}
+
+	if other, _ := LoadLocalFavorites("work"); len(other) != 0 {
+		t.Errorf("LoadLocalFavorites(work) = %v, want favorites kept per profile", other)
+	}
+
+	if err := RemoveLocalFavorite("default", "p1"); err != nil {
+		t.Fatalf("RemoveLocalFavorite() error = %v", err)
+	}
+	if err := RemoveLocalFavorite("default", "p1"); err == nil {
+		t.Error("RemoveLocalFavorite() of a missing favorite expected error")
+	}
+	if favorites, _ := LoadLocalFavorites("default"); len(favorites) != 1 || favorites[0].ID != "i1" {
+		t.Errorf("LoadLocalFavorites() after remove = %+v, want only i1", favorites)
+	}
+}
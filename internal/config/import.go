@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/ini.v1"
+)
+
+// profileKeys are the settings a profile section may hold. Per-command
+// format overrides ("issue.list.format") are accepted as well.
+var profileKeys = []string{"workspace", "team", "format", "cache_ttl", "page_size", "incremental_max_age", "favorites", "auto_json", "timezone", "retry_attempts", "retry_base_delay", "retry_max_delay"}
+
+// isProfileKey reports whether key is a recognized profile setting
+func isProfileKey(key string) bool {
+	if strings.HasSuffix(key, ".format") {
+		return true
+	}
+	for _, k := range profileKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportConfig merges settings from an ini or TOML file (chosen by the
+// .toml extension) into the config file. Top-level keys apply to profile;
+// sections such as [default], [profile work], [team.ENG], and [alias] are
+// merged by name. Every key is validated before anything is written.
+// Returns the number of keys imported.
+func ImportConfig(path, profile string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var sections map[string]map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		sections, err = parseTOMLSections(data, profile)
+	} else {
+		sections, err = parseINISections(data, profile)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := validateSections(sections); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = updateConfigFile(func(iniFile *ini.File) {
+		for name, keys := range sections {
+			section := iniFile.Section(name)
+			for key, value := range keys {
+				section.Key(key).SetValue(value)
+				count++
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// parseINISections reads ini data into section -> key -> value, placing
+// keys outside any section in the profile's section
+func parseINISections(data []byte, profile string) (map[string]map[string]string, error) {
+	iniFile, err := ini.Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]map[string]string)
+	for _, section := range iniFile.Sections() {
+		name := section.Name()
+		if name == ini.DefaultSection {
+			name = profileSection(profile)
+		}
+		for _, key := range section.Keys() {
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			sections[name][key.Name()] = key.String()
+		}
+	}
+	return sections, nil
+}
+
+// parseTOMLSections reads TOML data into section -> key -> value. Top-level
+// keys go to the profile's section and each table becomes a section; a
+// table named after a profile (e.g. [work]) is mapped to "profile work".
+func parseTOMLSections(data []byte, profile string) (map[string]map[string]string, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]map[string]string)
+	set := func(section, key string, value interface{}) error {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return fmt.Errorf("%s: nested tables and arrays are not supported", key)
+		}
+		if sections[section] == nil {
+			sections[section] = make(map[string]string)
+		}
+		sections[section][key] = fmt.Sprint(value)
+		return nil
+	}
+
+	for name, value := range doc {
+		table, ok := value.(map[string]interface{})
+		if !ok {
+			if err := set(profileSection(profile), name, value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		section := name
+		if name == "team" {
+			// [team.ENG] is a table of per-team tables. Files that also set a
+			// top-level team key must quote the name instead: ["team.ENG"]
+			for team, value := range table {
+				keys, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("team.%s: expected a table", team)
+				}
+				for key, v := range keys {
+					if err := set(TeamSectionPrefix+team, key, v); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+		if name != "default" && name != AliasSection && !strings.HasPrefix(name, "profile ") && !isTeamSection(name) {
+			section = profileSection(name)
+		}
+		for key, v := range table {
+			if err := set(section, key, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sections, nil
+}
+
+// validateSections checks every imported key, reporting all unknown keys at
+// once so nothing is written from a file with mistakes
+func validateSections(sections map[string]map[string]string) error {
+	var problems []string
+	for name, keys := range sections {
+		if name == AliasSection {
+			continue
+		}
+		if isTeamSection(name) {
+			for key := range keys {
+				if !isTeamKey(key) {
+					problems = append(problems, fmt.Sprintf("[%s] %s: unknown key", name, key))
+				}
+			}
+			continue
+		}
+		if name != "default" && !strings.HasPrefix(name, "profile ") {
+			problems = append(problems, fmt.Sprintf("[%s]: unknown section", name))
+			continue
+		}
+		if err := ValidateProfileName(strings.TrimPrefix(name, "profile ")); err != nil {
+			problems = append(problems, fmt.Sprintf("[%s]: %v", name, err))
+			continue
+		}
+		for key := range keys {
+			if !isProfileKey(key) {
+				problems = append(problems, fmt.Sprintf("[%s] %s: unknown key", name, key))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid config import:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestImportConfig verifies ini and TOML files merge into the config file,
+// keeping existing settings, and that invalid files write nothing.
+func TestImportConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantErr  string
+		expected map[string]string // "section.key" -> value
+	}{
+		{
+			name: "INI",
+			file: "import.ini",
+			content: `team = DES
+
+[profile work]
+format = json
+issue.list.format = csv
+
+[team.ENG]
+default_priority = high
+
+[alias]
+mine = issue list --created-by me
+`,
+			expected: map[string]string{
+				"default.team":                   "DES",
+				"default.cache_ttl":              "10m",
+				"profile work.format":            "json",
+				"profile work.issue.list.format": "csv",
+				"team.ENG.default_priority":      "high",
+				"alias.mine":                     "issue list --created-by me",
+			},
+		},
+		{
+			name: "TOML",
+			file: "import.toml",
+			content: `team = "DES"
+page_size = 25
+
+[work]
+format = "json"
+
+["team.ENG"]
+default_labels = "bug,intake"
+
+[alias]
+mine = "issue list --created-by me"
+`,
+			expected: map[string]string{
+				"default.team":            "DES",
+				"default.page_size":       "25",
+				"default.cache_ttl":       "10m",
+				"profile work.format":     "json",
+				"team.ENG.default_labels": "bug,intake",
+				"alias.mine":              "issue list --created-by me",
+			},
+		},
+		{
+			name: "TOML team table",
+			file: "teams.toml",
+			content: `[team.ENG]
+default_priority = "high"
+`,
+			expected: map[string]string{
+				"default.cache_ttl":         "10m",
+				"team.ENG.default_priority": "high",
+			},
+		},
+		{
+			name:    "Unknown keys rejected",
+			file:    "bad.ini",
+			content: "team = DES\napi_key = lin_api_x\n\n[profile work]\ncolour = red\n",
+			wantErr: "api_key: unknown key",
+		},
+		{
+			name:    "Unknown team keys rejected",
+			file:    "bad.ini",
+			content: "[team.ENG]\nformat = json\n",
+			wantErr: "[team.ENG] format: unknown key",
+		},
+		{
+			name:    "Reserved profile name rejected",
+			file:    "bad.ini",
+			content: "[profile alias]\nteam = ENG\n",
+			wantErr: "reserved for command aliases",
+		},
+		{
+			name:    "Nested TOML rejected",
+			file:    "bad.toml",
+			content: "[work.extra]\nteam = \"ENG\"\n",
+			wantErr: "nested tables",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("LIRT_CONFIG_DIR", dir)
+			t.Setenv("LIRT_CONFIG_FILE", "")
+
+			if err := SaveConfigValues("default", map[string]string{"team": "ENG", "cache_ttl": "10m"}); err != nil {
+				t.Fatalf("SaveConfigValues() error = %v", err)
+			}
+			before, _ := os.ReadFile(GetConfigFile())
+
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			count, err := ImportConfig(path, "default")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ImportConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				if after, _ := os.ReadFile(GetConfigFile()); string(after) != string(before) {
+					t.Errorf("config file changed despite invalid import:\n%s", after)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportConfig() error = %v", err)
+			}
+			if want := len(tt.expected) - 1; count != want {
+				t.Errorf("ImportConfig() imported %d keys, want %d", count, want)
+			}
+
+			sections, err := parseINISections(mustRead(t, GetConfigFile()), "default")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for path, want := range tt.expected {
+				section, key := splitSectionKey(path)
+				if got := sections[section][key]; got != want {
+					t.Errorf("%s = %q, want %q", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestSaveConfigValuesAtomic verifies several values are written in one save
+// and that no temporary files are left behind
+func TestSaveConfigValuesAtomic(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("LIRT_CONFIG_DIR", dir)
+	t.Setenv("LIRT_CONFIG_FILE", "")
+
+	values := map[string]string{"team": "ENG", "format": "json", "workspace": "acme"}
+	if err := SaveConfigValues("work", values); err != nil {
+		t.Fatalf("SaveConfigValues() error = %v", err)
+	}
+
+	cfg, err := LoadConfig("work")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Team != "ENG" || cfg.Format != "json" || cfg.Workspace != "acme" {
+		t.Errorf("LoadConfig() = team %q, format %q, workspace %q", cfg.Team, cfg.Format, cfg.Workspace)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "config" {
+			t.Errorf("unexpected file %s left in config dir", entry.Name())
+		}
+	}
+}
+
+// TestSaveConfigValuesSymlink verifies a symlinked config file keeps its
+// link and the update lands in the link's target
+func TestSaveConfigValuesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	targetDir := t.TempDir()
+	target := filepath.Join(targetDir, "lirt-config")
+	if err := os.WriteFile(target, []byte("[profile work]\nteam = OPS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LIRT_CONFIG_DIR", dir)
+	t.Setenv("LIRT_CONFIG_FILE", "")
+
+	if err := SaveConfigValues("work", map[string]string{"team": "ENG"}); err != nil {
+		t.Fatalf("SaveConfigValues() error = %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("config symlink was replaced by a regular file")
+	}
+	if !strings.Contains(string(mustRead(t, target)), "team = ENG") {
+		t.Errorf("target not updated:\n%s", mustRead(t, target))
+	}
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// splitSectionKey splits "profile work.issue.list.format" at the first dot
+func splitSectionKey(path string) (string, string) {
+	start := 0
+	if strings.HasPrefix(path, TeamSectionPrefix) {
+		start = len(TeamSectionPrefix)
+	}
+	i := start + strings.Index(path[start:], ".")
+	return path[:i], path[i+1:]
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Reminder is a local follow-up on an issue, due once Until has passed
+type Reminder struct {
+	IssueID    string    `json:"issueId"`
+	Identifier string    `json:"identifier"` // e.g. ENG-123
+	Until      time.Time `json:"until"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Due reports whether the reminder's snooze has elapsed at now
+func (r Reminder) Due(now time.Time) bool {
+	return !now.Before(r.Until)
+}
+
+// GetRemindersFile returns the path of the reminders file for a profile
+func GetRemindersFile(profile string) string {
+	return filepath.Join(GetConfigDir(), "reminders", profile+".json")
+}
+
+// LoadReminders returns the profile's reminders, soonest first
+func LoadReminders(profile string) ([]Reminder, error) {
+	reminders := []Reminder{}
+
+	data, err := os.ReadFile(GetRemindersFile(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reminders, nil
+		}
+		return nil, fmt.Errorf("failed to read reminders file: %w", err)
+	}
+	if err := json.Unmarshal(data, &reminders); err != nil {
+		return nil, fmt.Errorf("failed to parse reminders file: %w", err)
+	}
+
+	sort.SliceStable(reminders, func(i, j int) bool {
+		return reminders[i].Until.Before(reminders[j].Until)
+	})
+	return reminders, nil
+}
+
+// SaveReminder records a reminder, replacing any existing one for the issue
+func SaveReminder(profile string, reminder Reminder) error {
+	reminders, err := LoadReminders(profile)
+	if err != nil {
+		return err
+	}
+
+	kept := reminders[:0]
+	for _, r := range reminders {
+		if r.IssueID != reminder.IssueID {
+			kept = append(kept, r)
+		}
+	}
+	return saveReminders(profile, append(kept, reminder))
+}
+
+// DeleteReminders removes the reminders for the given issue IDs. IDs
+// without a reminder are ignored; it returns how many were removed.
+func DeleteReminders(profile string, issueIDs ...string) (int, error) {
+	reminders, err := LoadReminders(profile)
+	if err != nil {
+		return 0, err
+	}
+
+	remove := make(map[string]bool, len(issueIDs))
+	for _, id := range issueIDs {
+		remove[id] = true
+	}
+	kept := reminders[:0]
+	for _, r := range reminders {
+		if !remove[r.IssueID] {
+			kept = append(kept, r)
+		}
+	}
+
+	removed := len(reminders) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, saveReminders(profile, kept)
+}
+
+// saveReminders atomically replaces the profile's reminders file
+func saveReminders(profile string, reminders []Reminder) error {
+	if err := writeJSONAtomic(GetRemindersFile(profile), reminders); err != nil {
+		return fmt.Errorf("failed to save reminders file: %w", err)
+	}
+	return nil
+}
+
+// dayWeekPattern matches snooze durations in days or weeks, e.g. 2d or 1w
+var dayWeekPattern = regexp.MustCompile(`^([0-9]+)([dw])$`)
+
+// ParseSnoozeUntil converts a snooze value into the time it elapses: a
+// number of days or weeks (2d, 1w), a Go duration (90m, 4h), or a date
+// (YYYY-MM-DD, at the start of that day in now's location)
+func ParseSnoozeUntil(value string, now time.Time) (time.Time, error) {
+	if m := dayWeekPattern.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n == 0 {
+			return time.Time{}, fmt.Errorf("snooze duration must be positive: %s", value)
+		}
+		if m[2] == "w" {
+			n *= 7
+		}
+		return now.AddDate(0, 0, n), nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		if duration <= 0 {
+			return time.Time{}, fmt.Errorf("snooze duration must be positive: %s", value)
+		}
+		return now.Add(duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		if !date.After(now) {
+			return time.Time{}, fmt.Errorf("snooze date is not in the future: %s", value)
+		}
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid snooze time: %s (use e.g. 2d, 1w, 4h, or YYYY-MM-DD)", value)
+}
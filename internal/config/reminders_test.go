@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseSnoozeUntil verifies day/week counts, Go durations, and dates are
+// accepted, and that zero, past, and malformed values are rejected.
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2d", want: time.Date(2026, 3, 12, 15, 30, 0, 0, time.UTC)},
+		{value: "1w", want: time.Date(2026, 3, 17, 15, 30, 0, 0, time.UTC)},
+		{value: "4h", want: time.Date(2026, 3, 10, 19, 30, 0, 0, time.UTC)},
+		{value: "2026-04-01", want: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "2026-03-10", wantErr: true},
+		{value: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSnoozeUntil(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSnoozeUntil(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseSnoozeUntil(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestReminderStore verifies reminders are listed soonest first, that
+// snoozing again replaces an issue's reminder, and that deletes report how
+// many reminders were removed.
+func TestReminderStore(t *testing.T) {
+	t.Setenv("LIRT_CONFIG_DIR", t.TempDir())
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	reminders := []Reminder{
+		{IssueID: "i1", Identifier: "ENG-1", Until: now.Add(48 * time.Hour)},
+		{IssueID: "i2", Identifier: "ENG-2", Until: now.Add(-time.Hour)},
+		{IssueID: "i1", Identifier: "ENG-1", Until: now.Add(time.Hour)},
+	}
+	for _, r := range reminders {
+		if err := SaveReminder("default", r); err != nil {
+			t.Fatalf("SaveReminder() error = %v", err)
+		}
+	}
+
+	got, err := LoadReminders("default")
+	if err != nil {
+		t.Fatalf("LoadReminders() error = %v", err)
+	}
+	if len(got) != 2 || got[0].IssueID != "i2" || !got[1].Until.Equal(now.Add(time.Hour)) {
+		t.Fatalf("LoadReminders() = %+v, want i2 then the replaced i1", got)
+	}
+	if !got[0].Due(now) || got[1].Due(now) {
+		t.Errorf("Due() = %v, %v, want true, false", got[0].Due(now), got[1].Due(now))
+	}
+
+	removed, err := DeleteReminders("default", "i2", "missing")
+	if err != nil || removed != 1 {
+		t.Fatalf("DeleteReminders() = %d, %v, want 1, nil", removed, err)
+	}
+	if got, _ := LoadReminders("default"); len(got) != 1 || got[0].IssueID != "i1" {
+		t.Errorf("LoadReminders() after delete = %+v, want only i1", got)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// TeamSectionPrefix starts the name of a config file section holding a
+// team's defaults, e.g. [team.ENG]. Team defaults are shared by all profiles.
+const TeamSectionPrefix = "team."
+
+// teamKeys are the settings a team section may hold
+var teamKeys = []string{"default_priority", "default_labels"}
+
+// TeamDefaults are values issue create applies to a team's new issues when
+// the matching flag is not given
+type TeamDefaults struct {
+	Priority string   // default_priority, e.g. high or 2
+	Labels   []string // default_labels, comma-separated label names or IDs
+}
+
+// isTeamSection reports whether a section name holds team defaults
+func isTeamSection(name string) bool {
+	return strings.HasPrefix(name, TeamSectionPrefix) && len(name) > len(TeamSectionPrefix)
+}
+
+// isTeamKey reports whether key is a recognized team setting
+func isTeamKey(key string) bool {
+	for _, k := range teamKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTeamDefaults reads every [team.<KEY>] section, keyed by upper-case
+// team key
+func loadTeamDefaults(iniFile *ini.File) map[string]TeamDefaults {
+	var defaults map[string]TeamDefaults
+	for _, section := range iniFile.Sections() {
+		if !isTeamSection(section.Name()) {
+			continue
+		}
+
+		team := TeamDefaults{Priority: section.Key("default_priority").String()}
+		for _, label := range strings.Split(section.Key("default_labels").String(), ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				team.Labels = append(team.Labels, label)
+			}
+		}
+
+		if defaults == nil {
+			defaults = make(map[string]TeamDefaults)
+		}
+		defaults[strings.ToUpper(strings.TrimPrefix(section.Name(), TeamSectionPrefix))] = team
+	}
+	return defaults
+}
+
+// DefaultsFor returns the configured defaults for a team key, matched
+// case-insensitively. Teams without a section get empty defaults.
+func (c *Config) DefaultsFor(teamKey string) TeamDefaults {
+	return c.TeamDefaults[strings.ToUpper(teamKey)]
+}
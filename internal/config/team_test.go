@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestTeamDefaults verifies [team.<KEY>] sections are read for every
+// profile, matched case-insensitively, and kept out of the profile list.
+func TestTeamDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config")
+	content := `[default]
+team = ENG
+
+[team.ENG]
+default_priority = high
+default_labels = bug, intake,
+
+[team.des]
+default_labels = design
+`
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Setenv("LIRT_CONFIG_FILE", configFile)
+	t.Setenv("LIRT_CREDENTIALS_FILE", filepath.Join(tempDir, "credentials"))
+	t.Chdir(tempDir)
+
+	tests := []struct {
+		name    string
+		profile string
+		team    string
+		want    TeamDefaults
+	}{
+		{name: "priority and labels", profile: "default", team: "ENG", want: TeamDefaults{Priority: "high", Labels: []string{"bug", "intake"}}},
+		{name: "case-insensitive key", profile: "default", team: "eng", want: TeamDefaults{Priority: "high", Labels: []string{"bug", "intake"}}},
+		{name: "labels only", profile: "default", team: "DES", want: TeamDefaults{Labels: []string{"design"}}},
+		{name: "other profile", profile: "work", team: "ENG", want: TeamDefaults{Priority: "high", Labels: []string{"bug", "intake"}}},
+		{name: "no section", profile: "default", team: "OPS", want: TeamDefaults{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfig(tt.profile)
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if got := cfg.DefaultsFor(tt.team); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultsFor(%q) = %+v, want %+v", tt.team, got, tt.want)
+			}
+		})
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Errorf("ListProfiles() = %v, want only default", profiles)
+	}
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Format selects how log records are encoded
+type Format string
+
+const (
+	// FormatText writes key=value lines for people
+	FormatText Format = "text"
+	// FormatJSON writes one JSON object per record for log processors
+	FormatJSON Format = "json"
+)
+
+// ParseFormat parses a --log-format value
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case FormatText, FormatJSON:
+		return Format(value), nil
+	}
+	return "", fmt.Errorf("invalid log format %q (must be text or json)", value)
+}
+
+// New returns a leveled logger writing to w: debug and above when verbose,
+// otherwise only warnings and errors
+func New(w io.Writer, verbose bool, format Format) *slog.Logger {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	if format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// NewStatus returns a logger that writes status messages (e.g. "Created
+// issue ENG-1") to w as JSON records at info level and above, for
+// --log-format json. Unlike New, it does not depend on --verbose since
+// status is shown unless --quiet.
+func NewStatus(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}))
+}
+
+// Discard returns a logger that drops every record, for library code that
+// was not given one
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
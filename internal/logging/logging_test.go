@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestNewLevels verifies debug and info records only appear with verbose,
+// while warnings and errors always do.
+func TestNewLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+		want    []string
+		notWant []string
+	}{
+		{name: "default", verbose: false, want: []string{"warn-msg", "error-msg"}, notWant: []string{"debug-msg", "info-msg"}},
+		{name: "verbose", verbose: true, want: []string{"debug-msg", "info-msg", "warn-msg", "error-msg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(&buf, tt.verbose, FormatText)
+			logger.Debug("debug-msg")
+			logger.Info("info-msg")
+			logger.Warn("warn-msg")
+			logger.Error("error-msg")
+
+			out := buf.String()
+			for _, msg := range tt.want {
+				if !strings.Contains(out, msg) {
+					t.Errorf("output missing %q:\n%s", msg, out)
+				}
+			}
+			for _, msg := range tt.notWant {
+				if strings.Contains(out, msg) {
+					t.Errorf("output contains %q:\n%s", msg, out)
+				}
+			}
+		})
+	}
+}
+
+// TestNewJSON verifies JSON records carry the level, message, and attributes.
+func TestNewJSON(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, true, FormatJSON).Debug("cache hit", "key", "teams")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("record is not JSON: %v\n%s", err, buf.String())
+	}
+	if record["level"] != "DEBUG" || record["msg"] != "cache hit" || record["key"] != "teams" {
+		t.Errorf("record = %v", record)
+	}
+}
+
+// TestParseFormat verifies accepted and rejected --log-format values.
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		{value: "text", want: FormatText},
+		{value: "json", want: FormatJSON},
+		{value: "xml", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFormat(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
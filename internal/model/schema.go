@@ -0,0 +1,167 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON output contract described by
+// Schema. Bump it whenever a field is renamed, removed, or changes type;
+// adding fields does not need a bump.
+const SchemaVersion = 1
+
+// Field describes one field lirt emits in JSON output. Nested fields use
+// dotted paths (state.name) and array elements use [] (labels[].name).
+type Field struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"` // string, integer, number, boolean, datetime, object, array
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// Entity describes the JSON shape of one model type
+type Entity struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields"`
+}
+
+// Schema is the versioned description of lirt's JSON output
+type Schema struct {
+	Version  int      `json:"version"`
+	Entities []Entity `json:"entities"`
+}
+
+// entities maps entity names to the model type emitted for them
+var entities = map[string]interface{}{
+	"attachment":   Attachment{},
+	"comment":      Comment{},
+	"cycle":        Cycle{},
+	"favorite":     Favorite{},
+	"initiative":   Initiative{},
+	"issue":        Issue{},
+	"label":        Label{},
+	"milestone":    Milestone{},
+	"organization": Organization{},
+	"project":      Project{},
+	"reaction":     Reaction{},
+	"state":        State{},
+	"team":         Team{},
+	"user":         User{},
+	"viewer":       Viewer{},
+}
+
+// EntityNames returns the names accepted by SchemaFor, sorted
+func EntityNames() []string {
+	names := make([]string, 0, len(entities))
+	for name := range entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaFor returns the schema of the named entities, or of every entity
+// when none are given. Unknown names are skipped.
+func SchemaFor(names ...string) Schema {
+	if len(names) == 0 {
+		names = EntityNames()
+	}
+
+	schema := Schema{Version: SchemaVersion, Entities: []Entity{}}
+	for _, name := range names {
+		v, ok := entities[name]
+		if !ok {
+			continue
+		}
+		schema.Entities = append(schema.Entities, Entity{Name: name, Fields: Fields(v)})
+	}
+	return schema
+}
+
+// Fields lists the JSON fields of a struct value, descending into nested
+// structs and slices of structs
+func Fields(v interface{}) []Field {
+	fields := []Field{}
+	collectFields(reflect.TypeOf(v), "", map[reflect.Type]bool{}, &fields)
+	return fields
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func collectFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool, fields *[]Field) {
+	if visiting[t] {
+		// A type nested inside itself; stop rather than recurse forever
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		path := prefix + name
+
+		ft := sf.Type
+		optional := strings.Contains(opts, "omitempty")
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+			optional = true
+		}
+
+		typ := fieldType(ft)
+		*fields = append(*fields, Field{Path: path, Type: typ, Optional: optional})
+
+		switch {
+		case isStruct(ft):
+			collectFields(ft, path+".", visiting, fields)
+		case typ == "array" && isStruct(elem(ft.Elem())):
+			collectFields(elem(ft.Elem()), path+"[].", visiting, fields)
+		}
+	}
+}
+
+// fieldType names a Go type the way it appears in JSON output
+func fieldType(t reflect.Type) string {
+	if t == timeType {
+		return "datetime"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return t.Kind().String()
+}
+
+// isStruct reports whether t has fields of its own to describe
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// elem dereferences a pointer type
+func elem(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	fields := map[string]Field{}
+	for _, f := range Fields(Issue{}) {
+		fields[f.Path] = f
+	}
+
+	tests := []struct {
+		path     string
+		typ      string
+		optional bool
+	}{
+		{"id", "string", false},
+		{"priority", "integer", false},
+		{"createdAt", "datetime", false},
+		{"state", "object", true},
+		{"state.name", "string", false},
+		{"project.lead.email", "string", false},
+		{"project.progress", "number", true},
+		{"project.targetDate", "datetime", true},
+		{"labels", "array", true},
+		{"labels[].name", "string", false},
+		{"labels[].team.key", "string", false},
+		{"assignee.active", "boolean", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			f, ok := fields[tt.path]
+			if !ok {
+				t.Fatalf("field %q missing from schema", tt.path)
+			}
+			if f.Type != tt.typ || f.Optional != tt.optional {
+				t.Errorf("got type %q optional %v, want %q optional %v", f.Type, f.Optional, tt.typ, tt.optional)
+			}
+		})
+	}
+
+	// Datetimes are leaves, not structs to descend into
+	for path := range fields {
+		if strings.HasPrefix(path, "createdAt.") {
+			t.Errorf("unexpected field %q inside a datetime", path)
+		}
+	}
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema := SchemaFor("issue", "unknown")
+	if schema.Version != SchemaVersion {
+		t.Errorf("version = %d, want %d", schema.Version, SchemaVersion)
+	}
+	if len(schema.Entities) != 1 || schema.Entities[0].Name != "issue" {
+		t.Errorf("SchemaFor(issue, unknown) = %+v, want only issue", schema.Entities)
+	}
+
+	all := SchemaFor()
+	if len(all.Entities) != len(EntityNames()) {
+		t.Errorf("SchemaFor() returned %d entities, want %d", len(all.Entities), len(EntityNames()))
+	}
+}
@@ -4,19 +4,35 @@ import "time"
 
 // Issue represents a Linear issue
 type Issue struct {
-	ID          string    `json:"id"`
-	Identifier  string    `json:"identifier"` // e.g., "ENG-123"
-	Title       string    `json:"title"`
-	Description string    `json:"description,omitempty"`
-	Priority    int       `json:"priority"`              // 0-4
-	State       *State    `json:"state,omitempty"`
-	Assignee    *User     `json:"assignee,omitempty"`
-	Team        *Team     `json:"team,omitempty"`
-	Project     *Project  `json:"project,omitempty"`
-	Labels      []Label   `json:"labels,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	URL         string    `json:"url,omitempty"`
+	ID          string       `json:"id"`
+	Identifier  string       `json:"identifier"` // e.g., "ENG-123"
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Priority    int          `json:"priority"` // 0-4
+	State       *State       `json:"state,omitempty"`
+	Assignee    *User        `json:"assignee,omitempty"`
+	Team        *Team        `json:"team,omitempty"`
+	Project     *Project     `json:"project,omitempty"`
+	Labels      []Label      `json:"labels,omitempty"`
+	Reactions   []Reaction   `json:"reactions,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	URL         string       `json:"url,omitempty"`
+}
+
+// Attachment is a link attached to an issue, recording where it came from
+type Attachment struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	SourceType string `json:"sourceType,omitempty"` // e.g. slack, zendesk, github
+}
+
+// Reaction summarizes the emoji reactions of one kind on an issue or comment
+type Reaction struct {
+	Emoji string `json:"emoji"` // shortcode name without colons, e.g. "eyes"
+	Count int    `json:"count"`
 }
 
 // State represents a workflow state
@@ -34,8 +50,11 @@ type Team struct {
 	Key         string `json:"key"` // e.g., "ENG"
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	Icon        string `json:"icon,omitempty"`  // icon name or emoji
+	Color       string `json:"color,omitempty"` // hex, e.g. "#5e6ad2"
 	IssueCount  int    `json:"issueCount,omitempty"`
 	MemberCount int    `json:"memberCount,omitempty"`
+	Membership  string `json:"membership,omitempty"` // viewer's role: owner, member, or empty
 }
 
 // User represents a Linear user
@@ -45,19 +64,33 @@ type User struct {
 	Email       string `json:"email"`
 	DisplayName string `json:"displayName,omitempty"`
 	Active      bool   `json:"active"`
+	Admin       bool   `json:"admin"`
+	Timezone    string `json:"timezone,omitempty"` // IANA name, e.g. "Europe/Berlin"
+	AvatarURL   string `json:"avatarUrl,omitempty"`
+
+	// Issue counts, populated only when requested (e.g. user view --counts).
+	// Counting stops at a cap; a capped count means more than that many.
+	AssignedIssueCount   *int `json:"assignedIssues,omitempty"`
+	CreatedIssueCount    *int `json:"createdIssues,omitempty"`
+	AssignedIssuesCapped bool `json:"assignedIssuesCapped,omitempty"`
+	CreatedIssuesCapped  bool `json:"createdIssuesCapped,omitempty"`
 }
 
 // Project represents a Linear project
 type Project struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	State       string    `json:"state"` // backlog, planned, started, paused, completed, canceled
-	Priority    int       `json:"priority,omitempty"`
-	Lead        *User     `json:"lead,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	URL         string    `json:"url,omitempty"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state"` // backlog, planned, started, paused, completed, canceled
+	Priority    int        `json:"priority,omitempty"`
+	Lead        *User      `json:"lead,omitempty"`
+	Progress    float64    `json:"progress,omitempty"` // 0-1 completion fraction
+	Scope       float64    `json:"scope,omitempty"`    // Total estimate scope
+	StartDate   *time.Time `json:"startDate,omitempty"`
+	TargetDate  *time.Time `json:"targetDate,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	URL         string     `json:"url,omitempty"`
 }
 
 // Milestone represents a project milestone
@@ -72,11 +105,17 @@ type Milestone struct {
 
 // Initiative represents a Linear initiative
 type Initiative struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status,omitempty"` // Planned, Active, Completed
+	Health      string     `json:"health,omitempty"` // onTrack, atRisk, offTrack
+	Owner       *User      `json:"owner,omitempty"`
+	TargetDate  *time.Time `json:"targetDate,omitempty"`
+	Progress    float64    `json:"progress,omitempty"` // 0-1 completion fraction across projects
+	Projects    []Project  `json:"projects,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
 }
 
 // Label represents an issue label
@@ -85,6 +124,7 @@ type Label struct {
 	Name        string `json:"name"`
 	Color       string `json:"color"`
 	Description string `json:"description,omitempty"`
+	Team        *Team  `json:"team,omitempty"` // nil for workspace labels
 }
 
 // Comment represents a comment on an issue, project, or initiative
@@ -96,22 +136,33 @@ type Comment struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// Favorite is an issue or project starred by the user, either in Linear
+// (shown in the web app sidebar) or in the local favorites file
+type Favorite struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // issue or project
+	Issue     *Issue    `json:"issue,omitempty"`
+	Project   *Project  `json:"project,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 // Cycle represents a development cycle
 type Cycle struct {
-	ID        string     `json:"id"`
-	Name      string     `json:"name"`
-	Number    int        `json:"number"`
-	StartsAt  time.Time  `json:"startsAt"`
-	EndsAt    time.Time  `json:"endsAt"`
-	Team      *Team      `json:"team,omitempty"`
-	Completed bool       `json:"completed"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Number    int       `json:"number"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	Team      *Team     `json:"team,omitempty"`
+	Completed bool      `json:"completed"`
+	Progress  float64   `json:"progress"` // 0-1 completion fraction
 }
 
 // Organization represents a Linear workspace/organization
 type Organization struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	URLKey  string `json:"urlKey"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URLKey string `json:"urlKey"`
 }
 
 // Viewer represents the authenticated user and their organization
@@ -125,7 +176,7 @@ type Viewer struct {
 // PriorityLevel represents a priority value
 type PriorityLevel struct {
 	Value int    `json:"value"`
-	Name  string `json:"name"` // urgent, high, medium, low, none
+	Name  string `json:"name"`  // urgent, high, medium, low, none
 	Label string `json:"label"` // Urgent, High, Medium, Low, No Priority
 }
 
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+// ParseFields splits a comma-separated --json field list, dropping blanks
+func ParseFields(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// SetFields limits JSON output to the given top-level fields (--json)
+func (f *Formatter) SetFields(fields []string) {
+	f.fields = fields
+}
+
+// availableFields lists the JSON field names of an entity in declaration
+// order: the top-level fields of its model.Fields schema. Pointers and
+// slices are looked through, so an issue, *Issue, or []Issue all give the
+// issue's fields. Anything that is not a struct has no known fields.
+func availableFields(entity interface{}) []string {
+	t := reflect.TypeOf(entity)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for _, field := range model.Fields(reflect.Zero(t).Interface()) {
+		if !strings.Contains(field.Path, ".") {
+			fields = append(fields, field.Path)
+		}
+	}
+	return fields
+}
+
+// checkFields returns an error naming the first of fields the entity does
+// not have, along with the fields it does. Entities with no known fields
+// (e.g. raw API responses) accept any field.
+func checkFields(entity interface{}, fields []string) error {
+	available := availableFields(entity)
+	if len(available) == 0 {
+		return nil
+	}
+
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+	for _, field := range fields {
+		if !known[field] {
+			return fmt.Errorf("unknown field %s (available: %s)", field, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// selectFields projects data onto the given fields, keeping a list a list
+// and a single item an object. Fields an item leaves out (omitempty) are
+// null, so every object has the same keys.
+func selectFields(data interface{}, fields []string) (interface{}, error) {
+	if err := checkFields(data, fields); err != nil {
+		return nil, err
+	}
+
+	project := func(item interface{}) (map[string]interface{}, error) {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, fmt.Errorf("--json needs objects to select fields from: %w", err)
+		}
+		selected := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			selected[field] = m[field]
+		}
+		return selected, nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return project(data)
+	}
+
+	items := make([]map[string]interface{}, 0, v.Len())
+	for _, item := range itemsOf(data) {
+		selected, err := project(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, selected)
+	}
+	return items, nil
+}
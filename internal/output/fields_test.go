@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dixson3/lirt/internal/model"
+)
+
+func TestAvailableFields(t *testing.T) {
+	fields := availableFields(model.Issue{})
+	if len(fields) < 4 || !reflect.DeepEqual(fields[:4], []string{"id", "identifier", "title", "description"}) {
+		t.Fatalf("availableFields(Issue) starts %v, want id, identifier, title, description", fields)
+	}
+	for _, want := range []string{"state", "assignee", "labels", "createdAt", "url"} {
+		found := false
+		for _, f := range fields {
+			found = found || f == want
+		}
+		if !found {
+			t.Errorf("availableFields(Issue) = %v, missing %q", fields, want)
+		}
+	}
+
+	// Pointers and lists describe their element
+	if got := availableFields([]*model.Issue{}); !reflect.DeepEqual(got, fields) {
+		t.Errorf("availableFields([]*Issue) = %v, want %v", got, fields)
+	}
+	if got := availableFields(map[string]interface{}{}); got != nil {
+		t.Errorf("availableFields(map) = %v, want nil", got)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	items := []testItem{
+		{ID: "1", Priority: 2, State: &testState{Name: "Todo"}},
+		{ID: "2", Priority: 1},
+	}
+
+	tests := []struct {
+		name    string
+		data    interface{}
+		fields  string
+		want    string
+		wantErr string
+	}{
+		{name: "list", data: items, fields: "id,state", want: `[{"id":"1","state":{"name":"Todo"}},{"id":"2","state":null}]`},
+		{name: "single item", data: items[0], fields: " priority ", want: `{"priority":2}`},
+		{name: "unknown field", data: items, fields: "id,foo", wantErr: "unknown field foo (available: id, priority, state)"},
+		{name: "untyped data", data: map[string]interface{}{"a": 1, "b": 2}, fields: "a", want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := New(FormatJSON, &buf)
+			f.SetFields(ParseFields(tt.fields))
+
+			err := f.Output(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Output() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, buf.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if compact.String() != tt.want {
+				t.Errorf("Output() = %s, want %s", compact.String(), tt.want)
+			}
+		})
+	}
+}
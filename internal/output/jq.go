@@ -0,0 +1,317 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled --jq expression. lirt supports the path subset of jq
+// used for scripting: identity (.), field access (.foo, ."foo", .["foo"]),
+// array indexing (.[0], .[-1]), iteration (.[]), and pipes between paths
+// (.data | .issues.nodes[]). A trailing ? on a step suppresses type errors.
+type Query struct {
+	expr  string
+	steps []queryStep
+}
+
+type queryStepKind int
+
+const (
+	stepField queryStepKind = iota
+	stepIndex
+	stepIterate
+)
+
+// queryStep is one path component of a Query
+type queryStep struct {
+	kind     queryStepKind
+	key      string
+	index    int
+	optional bool
+}
+
+// ParseQuery compiles a --jq expression
+func ParseQuery(expr string) (*Query, error) {
+	p := &queryParser{expr: expr}
+	steps, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid --jq expression %q: %w", expr, err)
+	}
+	return &Query{expr: expr, steps: steps}, nil
+}
+
+// queryParser is a cursor over a --jq expression
+type queryParser struct {
+	expr string
+	pos  int
+}
+
+func (p *queryParser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.expr) && (p.expr[p.pos] == ' ' || p.expr[p.pos] == '\t' || p.expr[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) parse() ([]queryStep, error) {
+	steps := []queryStep{}
+
+	for {
+		p.skipSpace()
+		if p.peek() != '.' {
+			return nil, fmt.Errorf("expected '.' at position %d", p.pos+1)
+		}
+
+		termSteps, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, termSteps...)
+
+		p.skipSpace()
+		switch p.peek() {
+		case 0:
+			return steps, nil
+		case '|':
+			p.pos++
+		default:
+			return nil, fmt.Errorf("unsupported syntax at position %d (only paths and pipes are supported)", p.pos+1)
+		}
+	}
+}
+
+// parseTerm parses one path such as .data.issues.nodes[0].id
+func (p *queryParser) parseTerm() ([]queryStep, error) {
+	steps := []queryStep{}
+
+	for {
+		switch p.peek() {
+		case '.':
+			p.pos++
+			switch c := p.peek(); {
+			case c == '"':
+				key, err := p.parseString()
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, queryStep{kind: stepField, key: key})
+			case isIdentStart(c):
+				start := p.pos
+				for p.pos < len(p.expr) && isIdentChar(p.peek()) {
+					p.pos++
+				}
+				steps = append(steps, queryStep{kind: stepField, key: p.expr[start:p.pos]})
+			case c == '[':
+				// Handled by the bracket case on the next iteration
+			default:
+				// Bare "." is identity
+			}
+		case '[':
+			step, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		default:
+			return steps, nil
+		}
+
+		if p.peek() == '?' {
+			p.pos++
+			if len(steps) > 0 {
+				steps[len(steps)-1].optional = true
+			}
+		}
+	}
+}
+
+// parseBracket parses [], [n], or ["key"]
+func (p *queryParser) parseBracket() (queryStep, error) {
+	p.pos++ // [
+	p.skipSpace()
+
+	var step queryStep
+	switch c := p.peek(); {
+	case c == ']':
+		step = queryStep{kind: stepIterate}
+	case c == '"':
+		key, err := p.parseString()
+		if err != nil {
+			return step, err
+		}
+		step = queryStep{kind: stepField, key: key}
+	default:
+		start := p.pos
+		for p.pos < len(p.expr) && p.peek() != ']' {
+			p.pos++
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(p.expr[start:p.pos]))
+		if err != nil {
+			return step, fmt.Errorf("invalid index %q", p.expr[start:p.pos])
+		}
+		step = queryStep{kind: stepIndex, index: index}
+	}
+
+	p.skipSpace()
+	if p.peek() != ']' {
+		return step, fmt.Errorf("expected ']' at position %d", p.pos+1)
+	}
+	p.pos++
+	return step, nil
+}
+
+// parseString parses a double-quoted key
+func (p *queryParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	for p.pos < len(p.expr) {
+		switch p.expr[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			return strconv.Unquote(p.expr[start:p.pos])
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string at position %d", start+1)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// Run evaluates the query against decoded JSON, returning every result
+func (q *Query) Run(input interface{}) ([]interface{}, error) {
+	values := []interface{}{input}
+
+	for _, step := range q.steps {
+		next := []interface{}{}
+		for _, v := range values {
+			results, err := step.apply(v)
+			if err != nil {
+				if step.optional {
+					continue
+				}
+				return nil, fmt.Errorf("--jq %s: %w", q.expr, err)
+			}
+			next = append(next, results...)
+		}
+		values = next
+	}
+
+	return values, nil
+}
+
+// apply evaluates one step against a value
+func (s queryStep) apply(v interface{}) ([]interface{}, error) {
+	switch s.kind {
+	case stepField:
+		switch val := v.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case map[string]interface{}:
+			return []interface{}{val[s.key]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with %q", jsonType(v), s.key)
+		}
+	case stepIndex:
+		switch val := v.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case []interface{}:
+			i := s.index
+			if i < 0 {
+				i += len(val)
+			}
+			if i < 0 || i >= len(val) {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{val[i]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with number", jsonType(v))
+		}
+	default:
+		switch val := v.(type) {
+		case []interface{}:
+			return val, nil
+		case map[string]interface{}:
+			keys := make([]string, 0, len(val))
+			for k := range val {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			results := make([]interface{}, len(keys))
+			for i, k := range keys {
+				results[i] = val[k]
+			}
+			return results, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %s", jsonType(v))
+		}
+	}
+}
+
+// jsonType names the JSON type of a decoded value for error messages
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// ApplyQuery runs q over data (any JSON-marshalable value) and writes each
+// result on its own line: strings raw, everything else as indented JSON
+func ApplyQuery(w io.Writer, q *Query, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return err
+	}
+
+	results, err := q.Run(input)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(out))
+	}
+	return nil
+}
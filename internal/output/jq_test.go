@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestApplyQuery verifies the supported jq path subset: field access,
+// iteration, indexing, pipes, and optional steps, with strings printed raw.
+func TestApplyQuery(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"issues": map[string]interface{}{
+				"nodes": []interface{}{
+					map[string]interface{}{"id": "a", "priority": 1},
+					map[string]interface{}{"id": "b", "priority": 2},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Iterate field", expr: ".data.issues.nodes[].id", expected: "a\nb\n"},
+		{name: "Index", expr: ".data.issues.nodes[0].priority", expected: "1\n"},
+		{name: "Negative index", expr: ".data.issues.nodes[-1].id", expected: "b\n"},
+		{name: "Pipe", expr: ".data | .issues.nodes[1] | .id", expected: "b\n"},
+		{name: "Quoted key", expr: `.data["issues"].nodes[0]."id"`, expected: "a\n"},
+		{name: "Missing field is null", expr: ".data.missing", expected: "null\n"},
+		{name: "Identity", expr: ".data.issues.nodes[0] | .", expected: "{\n  \"id\": \"a\",\n  \"priority\": 1\n}\n"},
+		{name: "Optional suppresses error", expr: ".data.issues.nodes[0].id[]?", expected: ""},
+		{name: "Type error", expr: ".data.issues.nodes.id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.expr, err)
+			}
+
+			var buf bytes.Buffer
+			err = ApplyQuery(&buf, q, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.String() != tt.expected {
+				t.Errorf("ApplyQuery() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseQueryErrors verifies unsupported or malformed expressions are
+// rejected up front.
+func TestParseQueryErrors(t *testing.T) {
+	for _, expr := range []string{"data", ".foo[", ".foo | select(.x)", `.["unterminated]`, ".[abc]"} {
+		if _, err := ParseQuery(expr); err == nil {
+			t.Errorf("ParseQuery(%q) expected error, got nil", expr)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package output
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+)
+
+// DefaultMarkdownWidth is the wrap width used when the terminal size is unknown
+const DefaultMarkdownWidth = 80
+
+// RenderMarkdown renders markdown to ANSI for the terminal (headings, bold,
+// lists, code blocks), wrapped at width. The style follows GLAMOUR_STYLE,
+// defaulting to "dark"; a fixed style avoids querying the terminal's
+// background, which stalls when output goes through a pager.
+func RenderMarkdown(text string, width int) (string, error) {
+	if width <= 0 {
+		width = DefaultMarkdownWidth
+	}
+
+	style := os.Getenv("GLAMOUR_STYLE")
+	if style == "" {
+		style = "dark"
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStylePath(style),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := renderer.Render(text)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(rendered, "\n") + "\n", nil
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// ansiPattern matches ANSI escape sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+// TestRenderMarkdown verifies markdown syntax is rendered away while the
+// text content is kept, and that long lines wrap at the requested width.
+func TestRenderMarkdown(t *testing.T) {
+	t.Setenv("GLAMOUR_STYLE", "")
+
+	tests := []struct {
+		name       string
+		markdown   string
+		want       []string
+		wantAbsent []string
+	}{
+		{
+			name:       "Heading and bold",
+			markdown:   "## Steps\n\nThis is **important**.",
+			want:       []string{"Steps", "important"},
+			wantAbsent: []string{"**"},
+		},
+		{
+			name:     "List",
+			markdown: "- first\n- second",
+			want:     []string{"first", "second"},
+		},
+		{
+			name:     "Code block",
+			markdown: "```\ngo test ./...\n```",
+			want:     []string{"go test ./..."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := RenderMarkdown(tt.markdown, 80)
+			if err != nil {
+				t.Fatalf("RenderMarkdown() error = %v", err)
+			}
+			got := ansiPattern.ReplaceAllString(rendered, "")
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderMarkdown() = %q, missing %q", got, want)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(got, absent) {
+					t.Errorf("RenderMarkdown() = %q, should not contain %q", got, absent)
+				}
+			}
+		})
+	}
+
+	long := strings.Repeat("word ", 40)
+	rendered, err := RenderMarkdown(long, 40)
+	if err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(rendered, ""), "\n") {
+		if len(strings.TrimRight(line, " ")) > 40 {
+			t.Errorf("line %q exceeds width 40", line)
+		}
+	}
+}
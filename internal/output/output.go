@@ -5,12 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
+	"github.com/olekukonko/tablewriter/tw"
 )
 
 // Format represents an output format
@@ -23,24 +31,246 @@ const (
 	FormatPlain Format = "plain"
 )
 
+// ResolveFormat picks the output format from explicit choices given in
+// precedence order (e.g. --format, then LIRT_FORMAT, then config). The first
+// non-empty one wins even when piped; with none, output is JSON if autoJSON
+// (stdout is piped and the auto-switch is enabled) and a table otherwise.
+func ResolveFormat(autoJSON bool, explicit ...string) Format {
+	for _, format := range explicit {
+		if format != "" {
+			return Format(format)
+		}
+	}
+	if autoJSON {
+		return FormatJSON
+	}
+	return FormatTable
+}
+
 // Formatter handles output formatting
 type Formatter struct {
 	format Format
 	writer io.Writer
+	status io.Writer
 	color  bool
+	pretty bool // indent JSON output
+	query  *Query
+	fields []string // --json field selection
+	lead   []string // columns shown first in table and CSV output
+	layout TableLayout
+	csv    CSVOptions
+	loc    *time.Location // zone for timestamps in table and plain output
+
+	// statusLog, when set, receives status messages as records instead of
+	// the status writer
+	statusLog *slog.Logger
+
+	// Table rows whose highlightField timestamp is before highlightBefore
+	// are highlighted, e.g. issues not updated within an SLA
+	highlightField  string
+	highlightBefore time.Time
+}
+
+// TableLayout controls how long cells are fitted into table output
+type TableLayout struct {
+	MaxColWidth int  // cap on every column's content width, 0 for none
+	Wrap        bool // wrap long cells onto several lines instead of truncating with …
+	Width       int  // terminal width; without MaxColWidth the TITLE column is fitted to it
+}
+
+// CSVOptions controls CSV output for spreadsheets that expect something
+// other than RFC 4180 defaults
+type CSVOptions struct {
+	Delimiter  rune // field separator, ',' when zero
+	BOM        bool // start with a UTF-8 byte order mark (for Excel)
+	SingleLine bool // collapse line breaks in cells so each record is one line
 }
 
-// New creates a new formatter
+// lineBreaks matches a line break with the whitespace around it, including
+// blank lines, for collapsing multi-line cells to one line
+var lineBreaks = regexp.MustCompile(`[ \t]*(\r\n|\r|\n)\s*`)
+
+// utf8BOM marks output as UTF-8 for Excel, which otherwise assumes the
+// system code page
+const utf8BOM = "\ufeff"
+
+// minTitleWidth is the narrowest the TITLE column is shrunk to when fitting
+// a table to the terminal
+const minTitleWidth = 20
+
+// New creates a new formatter. Status messages go to stderr so stdout only
+// carries command output.
 func New(format Format, writer io.Writer) *Formatter {
 	return &Formatter{
 		format: format,
 		writer: writer,
+		status: os.Stderr,
 		color:  isTerminal(writer),
+		pretty: isTerminal(writer),
+	}
+}
+
+// SetPrettyJSON overrides whether JSON output is indented (--pretty) or
+// written on one line (--compact). By default it is indented only on a
+// terminal, where a person reads it; pipes get the smaller compact form.
+func (f *Formatter) SetPrettyJSON(pretty bool) {
+	f.pretty = pretty
+}
+
+// SetStatusWriter redirects status messages (default stderr)
+func (f *Formatter) SetStatusWriter(w io.Writer) {
+	f.status = w
+}
+
+// SetLeadingColumns shows the given columns (e.g. PROFILE) before all
+// others in table and CSV output, in the order given
+func (f *Formatter) SetLeadingColumns(columns ...string) {
+	f.lead = columns
+}
+
+// SetTableLayout sets how table output fits long cells
+func (f *Formatter) SetTableLayout(layout TableLayout) {
+	f.layout = layout
+}
+
+// ParseCSVDelimiter parses a --csv-delimiter value: a single character, or
+// `\t` or "tab" for a tab
+func ParseCSVDelimiter(value string) (rune, error) {
+	if value == `\t` || value == "tab" {
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid CSV delimiter %q: must be a single character", value)
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid CSV delimiter %q: quotes and newlines are not allowed", value)
+	}
+	return r, nil
+}
+
+// SetCSVOptions sets the CSV delimiter and byte order mark
+func (f *Formatter) SetCSVOptions(opts CSVOptions) {
+	f.csv = opts
+}
+
+// LoadLocation returns the time zone named by the first non-empty name (an
+// IANA name such as Europe/Berlin, or Local), or nil if every name is empty
+func LoadLocation(names ...string) (*time.Location, error) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", name)
+		}
+		return loc, nil
 	}
+	return nil, nil
 }
 
-// isTerminal checks if the writer is a terminal
+// SetLocation shows timestamps in table and plain output in loc instead of
+// UTC. JSON and CSV keep RFC 3339 UTC.
+func (f *Formatter) SetLocation(loc *time.Location) {
+	f.loc = loc
+}
+
+// dateOnlyFields hold calendar dates (stored as UTC midnight), which keep
+// their day rather than being shifted into another zone
+var dateOnlyFields = map[string]bool{"startDate": true, "targetDate": true, "dueDate": true}
+
+// SetHighlightBefore highlights table rows whose timestamp field (e.g.
+// updatedAt) is before cutoff. Rows are only highlighted with color.
+func (f *Formatter) SetHighlightBefore(field string, cutoff time.Time) {
+	f.highlightField = field
+	f.highlightBefore = cutoff
+}
+
+// highlighted reports which items of data have their highlight field before
+// the cutoff, or nil when no highlight is set
+func (f *Formatter) highlighted(data interface{}) []bool {
+	if f.highlightField == "" {
+		return nil
+	}
+
+	items := itemsOf(data)
+	marks := make([]bool, len(items))
+	for i, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			continue
+		}
+		value, _ := m[f.highlightField].(string)
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			marks[i] = t.Before(f.highlightBefore)
+		}
+	}
+	return marks
+}
+
+// localTime renders a flattened timestamp value in the formatter's zone,
+// reporting false for values that are not timestamps
+func (f *Formatter) localTime(key string, value string) (string, bool) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return "", false
+	}
+	if dateOnlyFields[key] {
+		return t.UTC().Format("2006-01-02"), true
+	}
+	return t.In(f.loc).Format("2006-01-02 15:04 MST"), true
+}
+
+// SetQuery filters JSON output through a --jq expression
+func (f *Formatter) SetQuery(q *Query) {
+	f.query = q
+}
+
+// Statusf writes a human-oriented status line, keeping it out of stdout
+func (f *Formatter) Statusf(format string, args ...interface{}) {
+	if f.statusLog == nil {
+		fmt.Fprintf(f.status, format, args...)
+		return
+	}
+
+	// Records carry the message without the decoration meant for people;
+	// warnings keep their level
+	msg := strings.TrimSpace(fmt.Sprintf(format, args...))
+	if warning, ok := strings.CutPrefix(msg, "Warning: "); ok {
+		f.statusLog.Warn(warning)
+		return
+	}
+	f.statusLog.Info(strings.TrimPrefix(msg, "✓ "))
+}
+
+// SetStatusLogger writes status messages as records to logger rather than
+// as text to the status writer, e.g. for --log-format json. Data output is
+// unaffected.
+func (f *Formatter) SetStatusLogger(logger *slog.Logger) {
+	f.statusLog = logger
+}
+
+// Writer returns the writer command output goes to, for commands that
+// render their own table/plain layout
+func (f *Formatter) Writer() io.Writer {
+	return f.writer
+}
+
+// Format returns the configured output format
+func (f *Formatter) Format() Format {
+	return f.format
+}
+
+// isTerminal checks if the writer is a terminal (or a Pager displaying on one)
 func isTerminal(w io.Writer) bool {
+	if t, ok := w.(interface{ IsTerminal() bool }); ok {
+		return t.IsTerminal()
+	}
 	if f, ok := w.(*os.File); ok {
 		fileInfo, err := f.Stat()
 		if err != nil {
@@ -67,24 +297,61 @@ func (f *Formatter) Output(data interface{}) error {
 	}
 }
 
+// OutputCreated writes the result of a create command. With idOnly, just the
+// new entity's identifier is printed so scripts can capture it with $(...);
+// otherwise the full object is written in the configured format.
+func (f *Formatter) OutputCreated(data interface{}, id string, idOnly bool) error {
+	if idOnly {
+		_, err := fmt.Fprintln(f.writer, id)
+		return err
+	}
+	return f.Output(data)
+}
+
 // outputJSON outputs data as JSON
 func (f *Formatter) outputJSON(data interface{}) error {
+	if len(f.fields) > 0 {
+		selected, err := selectFields(data, f.fields)
+		if err != nil {
+			return err
+		}
+		data = selected
+	}
+	if f.query != nil {
+		return ApplyQuery(f.writer, f.query, data)
+	}
 	enc := json.NewEncoder(f.writer)
-	enc.SetIndent("", "  ")
+	if f.pretty {
+		enc.SetIndent("", "  ")
+	}
 	return enc.Encode(data)
 }
 
 // outputCSV outputs data as CSV
 func (f *Formatter) outputCSV(data interface{}) error {
-	w := csv.NewWriter(f.writer)
-	defer w.Flush()
-
 	// Convert data to slice of maps
 	rows, headers := f.dataToRows(data)
+	return f.writeCSV(rows, headers)
+}
+
+// writeCSV writes rows as CSV with the given columns
+func (f *Formatter) writeCSV(rows []map[string]interface{}, headers []string) error {
 	if len(rows) == 0 {
 		return nil
 	}
 
+	w := csv.NewWriter(f.writer)
+	if f.csv.Delimiter != 0 {
+		w.Comma = f.csv.Delimiter
+	}
+	defer w.Flush()
+
+	if f.csv.BOM {
+		if _, err := io.WriteString(f.writer, utf8BOM); err != nil {
+			return err
+		}
+	}
+
 	// Write headers
 	if err := w.Write(headers); err != nil {
 		return err
@@ -94,7 +361,10 @@ func (f *Formatter) outputCSV(data interface{}) error {
 	for _, row := range rows {
 		record := make([]string, len(headers))
 		for i, header := range headers {
-			record[i] = fmt.Sprint(row[header])
+			record[i] = cellValue(row, header)
+			if f.csv.SingleLine {
+				record[i] = lineBreaks.ReplaceAllString(strings.TrimSpace(record[i]), " ")
+			}
 		}
 		if err := w.Write(record); err != nil {
 			return err
@@ -107,19 +377,44 @@ func (f *Formatter) outputCSV(data interface{}) error {
 // outputTable outputs data as an aligned table
 func (f *Formatter) outputTable(data interface{}) error {
 	rows, headers := f.dataToRows(data)
+	return f.writeTable(rows, headers, f.highlighted(data))
+}
+
+// writeTable writes rows as an aligned table with the given columns. Rows
+// marked in highlight are shown in red when color is enabled, apart from
+// cells that carry their own color.
+func (f *Formatter) writeTable(rows []map[string]interface{}, headers []string, highlight []bool) error {
 	if len(rows) == 0 {
 		return nil
 	}
 
-	table := tablewriter.NewWriter(f.writer)
+	wrap := tw.WrapTruncate
+	if f.layout.Wrap {
+		wrap = tw.WrapNormal
+	}
+	table := tablewriter.NewTable(f.writer,
+		tablewriter.WithRowAutoWrap(wrap),
+		tablewriter.WithColumnWidths(f.columnWidths(rows, headers)),
+	)
 	table.Header(headers)
 
-	for _, row := range rows {
+	for r, row := range rows {
+		stale := f.color && r < len(highlight) && highlight[r]
 		record := make([]string, len(headers))
 		for i, header := range headers {
-			val := fmt.Sprint(row[header])
-			if f.color && header == "PRIORITY" {
+			val := cellValue(row, header)
+			list, isList := row[header].(namedList)
+			switch {
+			case f.color && header == "PRIORITY":
 				val = f.colorPriority(val)
+			case f.color && header == "KEY":
+				// Teams show their key in the team color
+				hex, _ := row["COLOR"].(string)
+				val = colorHex(val, hex)
+			case f.color && isList:
+				val = f.colorNamedList(list)
+			case stale:
+				val = color.RedString(val)
 			}
 			record[i] = val
 		}
@@ -130,6 +425,57 @@ func (f *Formatter) outputTable(data interface{}) error {
 	return nil
 }
 
+// columnWidths returns the cell width (content plus padding) of each column
+// whose content must be narrowed: every column wider than MaxColWidth, or,
+// without MaxColWidth, the TITLE column when the table would overflow the
+// terminal. Other columns keep their natural width.
+func (f *Formatter) columnWidths(rows []map[string]interface{}, headers []string) tw.Mapper[int, int] {
+	const padding = 2   // one space either side of the content
+	const separator = 1 // border between columns
+
+	natural := make([]int, len(headers))
+	for i, header := range headers {
+		natural[i] = twwidth.Width(header)
+		for _, row := range rows {
+			for _, line := range strings.Split(cellValue(row, header), "\n") {
+				natural[i] = max(natural[i], twwidth.Width(line))
+			}
+		}
+	}
+
+	widths := tw.NewMapper[int, int]()
+	if f.layout.MaxColWidth > 0 {
+		for i, width := range natural {
+			if width > f.layout.MaxColWidth {
+				widths.Set(i, f.layout.MaxColWidth+padding)
+			}
+		}
+		return widths
+	}
+
+	if f.layout.Width <= 0 {
+		return widths
+	}
+	title := -1
+	total := separator
+	for i, header := range headers {
+		if header == "TITLE" {
+			title = i
+		}
+		total += natural[i] + padding + separator
+	}
+	if title < 0 || total <= f.layout.Width {
+		return widths
+	}
+
+	fit := natural[title] - (total - f.layout.Width)
+	if fit < minTitleWidth {
+		fit = min(minTitleWidth, natural[title])
+	}
+	widths.Set(title, fit+padding)
+	return widths
+}
+
 // outputPlain outputs data as plain text (one value per line)
 func (f *Formatter) outputPlain(data interface{}) error {
 	rows, _ := f.dataToRows(data)
@@ -162,23 +508,51 @@ func (f *Formatter) dataToRows(data interface{}) ([]map[string]interface{}, []st
 
 	rows := make([]map[string]interface{}, 0, v.Len())
 	headers := []string{}
+	seen := make(map[string]bool)
 
 	for i := 0; i < v.Len(); i++ {
 		item := v.Index(i).Interface()
 		row := f.structToMap(item)
 		rows = append(rows, row)
 
-		// Collect headers from first item
-		if i == 0 {
-			for k := range row {
+		// Collect headers across all items: omitempty fields (e.g. an
+		// unassigned issue) leave keys out of some rows but not others
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
 				headers = append(headers, k)
 			}
 		}
 	}
 
+	if len(f.lead) > 0 {
+		ordered := make([]string, 0, len(headers))
+		for _, column := range f.lead {
+			if seen[column] {
+				ordered = append(ordered, column)
+			}
+		}
+		for _, header := range headers {
+			if !slices.Contains(f.lead, header) {
+				ordered = append(ordered, header)
+			}
+		}
+		headers = ordered
+	}
+
 	return rows, headers
 }
 
+// cellValue returns a row's value for a column as text. Rows lacking the
+// column get an empty cell rather than "<nil>".
+func cellValue(row map[string]interface{}, header string) string {
+	val, ok := row[header]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(val)
+}
+
 // structToMap converts a struct to a map
 func (f *Formatter) structToMap(item interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
@@ -196,7 +570,16 @@ func (f *Formatter) structToMap(item interface{}) map[string]interface{} {
 
 	// Flatten nested objects for display
 	for k, v := range m {
-		if vm, ok := v.(map[string]interface{}); ok {
+		if list, ok := toNamedList(v); ok {
+			result[strings.ToUpper(k)] = list
+		} else if summary, ok := reactionSummary(v); ok && k == "reactions" {
+			result[strings.ToUpper(k)] = summary
+		} else if k == "attachments" {
+			// Show where an issue came from rather than every link
+			if sources := provenance(v); sources != "" {
+				result["PROVENANCE"] = sources
+			}
+		} else if vm, ok := v.(map[string]interface{}); ok {
 			// For nested objects, just use a representative field
 			if name, ok := vm["name"]; ok {
 				result[strings.ToUpper(k)] = name
@@ -205,6 +588,13 @@ func (f *Formatter) structToMap(item interface{}) map[string]interface{} {
 			} else {
 				result[strings.ToUpper(k)] = "<object>"
 			}
+		} else if n, ok := v.(float64); ok && k == "priority" {
+			result[strings.ToUpper(k)] = priorityLabel(int(n))
+		} else if s, ok := v.(string); ok && f.loc != nil && (f.format == FormatTable || f.format == FormatPlain) {
+			if local, ok := f.localTime(k, s); ok {
+				s = local
+			}
+			result[strings.ToUpper(k)] = s
 		} else if v != nil {
 			result[strings.ToUpper(k)] = v
 		}
@@ -213,6 +603,113 @@ func (f *Formatter) structToMap(item interface{}) map[string]interface{} {
 	return result
 }
 
+// namedItem is a flattened element of a list of named objects, keeping its
+// color for display (e.g. an issue label)
+type namedItem struct {
+	Name  string
+	Color string
+}
+
+// namedList is a flattened list of named objects that prints as
+// comma-joined names
+type namedList []namedItem
+
+func (l namedList) String() string {
+	names := make([]string, len(l))
+	for i, item := range l {
+		names[i] = item.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// toNamedList converts a decoded JSON array of objects with a name (such as
+// labels) into a namedList
+func toNamedList(v interface{}) (namedList, bool) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil, false
+	}
+
+	list := make(namedList, 0, len(arr))
+	for _, elem := range arr {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := obj["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		hex, _ := obj["color"].(string)
+		list = append(list, namedItem{Name: name, Color: hex})
+	}
+	return list, true
+}
+
+// reactionSummary renders a decoded JSON array of reactions as
+// ":emoji: count" pairs, e.g. ":eyes: 2, :+1: 1"
+func reactionSummary(v interface{}) (string, bool) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) == 0 {
+		return "", false
+	}
+
+	parts := make([]string, 0, len(arr))
+	for _, elem := range arr {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		emoji, ok := obj["emoji"].(string)
+		if !ok {
+			return "", false
+		}
+		count, _ := obj["count"].(float64)
+		parts = append(parts, fmt.Sprintf(":%s: %d", emoji, int(count)))
+	}
+	return strings.Join(parts, ", "), true
+}
+
+// provenance summarizes a decoded JSON array of attachments as their
+// distinct source types in order, e.g. "slack, zendesk"
+func provenance(v interface{}) string {
+	arr, _ := v.([]interface{})
+	seen := make(map[string]bool)
+	sources := []string{}
+	for _, elem := range arr {
+		obj, _ := elem.(map[string]interface{})
+		source, _ := obj["sourceType"].(string)
+		if source == "" || seen[source] {
+			continue
+		}
+		seen[source] = true
+		sources = append(sources, source)
+	}
+	return strings.Join(sources, ", ")
+}
+
+// colorNamedList renders each name in its own hex color
+func (f *Formatter) colorNamedList(list namedList) string {
+	names := make([]string, len(list))
+	for i, item := range list {
+		names[i] = colorHex(item.Name, item.Color)
+	}
+	return strings.Join(names, ", ")
+}
+
+// colorHex colors val with a "#rrggbb" color, returning it unchanged if the
+// color cannot be parsed
+func colorHex(val, hex string) string {
+	var r, g, b int
+	if len(hex) != 7 || hex[0] != '#' {
+		return val
+	}
+	if _, err := fmt.Sscanf(hex[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return val
+	}
+	return color.RGB(r, g, b).Sprint(val)
+}
+
 // colorPriority colors priority values
 func (f *Formatter) colorPriority(val string) string {
 	if !f.color {
@@ -230,3 +727,288 @@ func (f *Formatter) colorPriority(val string) string {
 		return val
 	}
 }
+
+// itemsOf returns the elements of a slice or array, or the value itself
+// wrapped in a slice for single items
+func itemsOf(data interface{}) []interface{} {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []interface{}{data}
+	}
+
+	items := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, v.Index(i).Interface())
+	}
+	return items
+}
+
+// fieldValue returns the display value of a field for an item, using the
+// same flattening as table output (e.g. "state" yields the state name)
+func (f *Formatter) fieldValue(item interface{}, field string) interface{} {
+	return f.structToMap(item)[strings.ToUpper(field)]
+}
+
+// SortBy returns the items of data ordered by the given field. Numeric
+// values compare numerically, everything else by string; items missing the
+// field sort last. Priority sorts by priorityRank (Urgent first, No Priority
+// last). The sort is stable so API ordering breaks ties.
+func (f *Formatter) SortBy(data interface{}, field string) []interface{} {
+	items := itemsOf(data)
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		values[i] = f.fieldValue(item, field)
+	}
+
+	indices := make([]int, len(items))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	less := lessValue
+	if strings.EqualFold(field, "priority") {
+		less = lessPriority
+	}
+
+	sort.SliceStable(indices, func(a, b int) bool {
+		return less(values[indices[a]], values[indices[b]])
+	})
+
+	sorted := make([]interface{}, len(items))
+	for i, idx := range indices {
+		sorted[i] = items[idx]
+	}
+	return sorted
+}
+
+// lessValue orders two flattened field values
+func lessValue(a, b interface{}) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+
+	an, aok := a.(float64)
+	bn, bok := b.(float64)
+	if aok && bok {
+		return an < bn
+	}
+
+	return strings.ToLower(fmt.Sprint(a)) < strings.ToLower(fmt.Sprint(b))
+}
+
+// priorityRank maps Linear's priority encoding (0=No Priority, 1=Urgent,
+// 2=High, 3=Medium, 4=Low) to a human ordering where Urgent ranks first and
+// No Priority ranks last
+func priorityRank(priority int) int {
+	if priority == 0 {
+		return 5
+	}
+	return priority
+}
+
+// priorityLabels maps Linear priority values to their display labels
+var priorityLabels = []string{"No Priority", "Urgent", "High", "Medium", "Low"}
+
+// priorityLabel returns the display label for a priority value, or the
+// number itself if it is out of range
+func priorityLabel(priority int) string {
+	if priority < 0 || priority >= len(priorityLabels) {
+		return fmt.Sprint(priority)
+	}
+	return priorityLabels[priority]
+}
+
+// priorityValue converts a flattened priority (a label or a raw number)
+// back to its numeric value
+func priorityValue(v interface{}) (int, bool) {
+	switch val := v.(type) {
+	case float64:
+		return int(val), true
+	case string:
+		for i, label := range priorityLabels {
+			if label == val {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// lessPriority orders flattened priority values by priorityRank, falling
+// back to lessValue for anything that is not a recognized priority
+func lessPriority(a, b interface{}) bool {
+	an, aok := priorityValue(a)
+	bn, bok := priorityValue(b)
+	if !aok || !bok {
+		return lessValue(a, b)
+	}
+	return priorityRank(an) < priorityRank(bn)
+}
+
+// GroupBy partitions the items of data by the display value of field. Group
+// keys are returned in order of first appearance; items missing the field
+// are grouped under "None", and items with a list value (e.g. labels)
+// appear in the group of each element.
+func (f *Formatter) GroupBy(data interface{}, field string) ([]string, map[string][]interface{}) {
+	keys := []string{}
+	groups := make(map[string][]interface{})
+
+	for _, item := range itemsOf(data) {
+		// Items with several values (e.g. labels) join every matching group
+		groupKeys := []string{"None"}
+		val := f.fieldValue(item, field)
+		if list, ok := val.(namedList); ok {
+			groupKeys = groupKeys[:0]
+			for _, named := range list {
+				groupKeys = append(groupKeys, named.Name)
+			}
+		} else if val != nil && fmt.Sprint(val) != "" {
+			groupKeys = []string{fmt.Sprint(val)}
+		}
+
+		for _, key := range groupKeys {
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], item)
+		}
+	}
+
+	return keys, groups
+}
+
+// OutputGrouped writes data partitioned by field. Table and plain output
+// print a header per group; JSON outputs an object keyed by group; CSV is
+// written ungrouped since the field is already a column.
+func (f *Formatter) OutputGrouped(data interface{}, field string) error {
+	keys, groups := f.GroupBy(data, field)
+
+	switch f.format {
+	case FormatJSON:
+		return f.outputJSON(groups)
+	case FormatTable, FormatPlain:
+		for i, key := range keys {
+			if i > 0 {
+				fmt.Fprintln(f.writer)
+			}
+			header := fmt.Sprintf("%s (%d)", key, len(groups[key]))
+			if f.color {
+				header = color.New(color.Bold).Sprint(header)
+			}
+			fmt.Fprintln(f.writer, header)
+			if err := f.Output(groups[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return f.Output(data)
+	}
+}
+
+// GroupCount is the number of items sharing a field value
+type GroupCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// CountBy counts the items of data per display value of field, grouping
+// as GroupBy does: items missing the field count under "None" and items
+// with a list value count once for each element. Counts are ordered
+// largest first, ties in order of first appearance.
+func (f *Formatter) CountBy(data interface{}, field string) []GroupCount {
+	keys, groups := f.GroupBy(data, field)
+
+	counts := make([]GroupCount, len(keys))
+	for i, key := range keys {
+		counts[i] = GroupCount{Value: key, Count: len(groups[key])}
+	}
+	sort.SliceStable(counts, func(a, b int) bool {
+		return counts[a].Count > counts[b].Count
+	})
+	return counts
+}
+
+// OutputCounts writes CountBy for field instead of the items themselves.
+// Table and CSV output have a column named after the field and a COUNT
+// column; JSON outputs an array of {value, count}; plain output prints
+// tab-separated value and count lines.
+func (f *Formatter) OutputCounts(data interface{}, field string) error {
+	counts := f.CountBy(data, field)
+
+	switch f.format {
+	case FormatJSON:
+		return f.outputJSON(counts)
+	case FormatPlain:
+		for _, c := range counts {
+			fmt.Fprintf(f.writer, "%s\t%d\n", c.Value, c.Count)
+		}
+		return nil
+	}
+
+	header := strings.ToUpper(field)
+	headers := []string{header, "COUNT"}
+	rows := make([]map[string]interface{}, len(counts))
+	for i, c := range counts {
+		rows[i] = map[string]interface{}{header: c.Value, "COUNT": c.Count}
+	}
+
+	if f.format == FormatCSV {
+		return f.writeCSV(rows, headers)
+	}
+	return f.writeTable(rows, headers, nil)
+}
+
+// RelativeTime formats t relative to now, e.g. "5 minutes ago" or "3 days ago"
+func RelativeTime(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "unknown time"
+	}
+
+	d := now.Sub(t)
+	if d < 0 {
+		d = 0
+	}
+
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day")
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month")
+	default:
+		return plural(int(d.Hours()/(24*365)), "year")
+	}
+}
+
+// Indent prefixes every line of text with prefix, preserving line breaks
+func Indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
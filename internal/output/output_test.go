@@ -0,0 +1,907 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+type testItem struct {
+	ID       string     `json:"id"`
+	Priority int        `json:"priority"`
+	State    *testState `json:"state,omitempty"`
+}
+
+type testState struct {
+	Name string `json:"name"`
+}
+
+func testItems() []testItem {
+	return []testItem{
+		{ID: "a", Priority: 3, State: &testState{Name: "Todo"}},
+		{ID: "b", Priority: 1, State: &testState{Name: "Done"}},
+		{ID: "c", Priority: 2},
+		{ID: "d", Priority: 1, State: &testState{Name: "Todo"}},
+	}
+}
+
+func ids(items []interface{}) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = item.(testItem).ID
+	}
+	return result
+}
+
+// TestGroupBy verifies grouping by a flattened nested field, preserving
+// first-appearance order and bucketing missing values under "None".
+func TestGroupBy(t *testing.T) {
+	f := New(FormatJSON, &bytes.Buffer{})
+
+	keys, groups := f.GroupBy(testItems(), "state")
+
+	if want := []string{"Todo", "Done", "None"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("GroupBy() keys = %v, want %v", keys, want)
+	}
+	if want := []string{"a", "d"}; !reflect.DeepEqual(ids(groups["Todo"]), want) {
+		t.Errorf("GroupBy() Todo = %v, want %v", ids(groups["Todo"]), want)
+	}
+	if want := []string{"c"}; !reflect.DeepEqual(ids(groups["None"]), want) {
+		t.Errorf("GroupBy() None = %v, want %v", ids(groups["None"]), want)
+	}
+}
+
+// TestSortBy verifies numeric and string sorting is stable.
+func TestSortBy(t *testing.T) {
+	f := New(FormatJSON, &bytes.Buffer{})
+
+	tests := []struct {
+		field    string
+		expected []string
+	}{
+		{field: "priority", expected: []string{"b", "d", "c", "a"}},
+		{field: "state", expected: []string{"b", "a", "d", "c"}},
+		{field: "id", expected: []string{"a", "b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got := ids(f.SortBy(testItems(), tt.field))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortBy(%q) = %v, want %v", tt.field, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLessPriority verifies that priorities order Urgent through Low with
+// No Priority (0) last, rather than by raw numeric value.
+func TestLessPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     interface{}
+		expected bool
+	}{
+		{name: "urgent before high", a: 1.0, b: 2.0, expected: true},
+		{name: "low after medium", a: 4.0, b: 3.0, expected: false},
+		{name: "low before none", a: 4.0, b: 0.0, expected: true},
+		{name: "none after urgent", a: 0.0, b: 1.0, expected: false},
+		{name: "equal priorities", a: 2.0, b: 2.0, expected: false},
+		{name: "missing sorts last", a: 0.0, b: nil, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lessPriority(tt.a, tt.b); got != tt.expected {
+				t.Errorf("lessPriority(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+
+	f := New(FormatJSON, &bytes.Buffer{})
+	items := []testItem{{ID: "none", Priority: 0}, {ID: "low", Priority: 4}, {ID: "urgent", Priority: 1}}
+	if got, want := ids(f.SortBy(items, "priority")), []string{"urgent", "low", "none"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortBy(priority) = %v, want %v", got, want)
+	}
+}
+
+// TestPriorityLabel verifies that numeric priorities render as labels in
+// table columns while JSON output keeps the raw value.
+func TestPriorityLabel(t *testing.T) {
+	tests := []struct {
+		priority int
+		expected string
+	}{
+		{priority: 0, expected: "No Priority"},
+		{priority: 1, expected: "Urgent"},
+		{priority: 2, expected: "High"},
+		{priority: 3, expected: "Medium"},
+		{priority: 4, expected: "Low"},
+		{priority: 7, expected: "7"},
+	}
+
+	f := New(FormatTable, &bytes.Buffer{})
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := priorityLabel(tt.priority); got != tt.expected {
+				t.Errorf("priorityLabel(%d) = %q, want %q", tt.priority, got, tt.expected)
+			}
+			row := f.structToMap(testItem{ID: "a", Priority: tt.priority})
+			if got := row["PRIORITY"]; got != tt.expected {
+				t.Errorf("structToMap() PRIORITY = %v, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := New(FormatJSON, &buf).Output(testItem{ID: "a", Priority: 1}); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"priority":1`) {
+		t.Errorf("JSON output = %s, want numeric priority", buf.String())
+	}
+}
+
+// TestOutputGroupedJSON verifies JSON grouped output is an object keyed by group.
+func TestOutputGroupedJSON(t *testing.T) {
+	var buf bytes.Buffer
+	f := New(FormatJSON, &buf)
+
+	if err := f.OutputGrouped(testItems(), "state"); err != nil {
+		t.Fatalf("OutputGrouped() error: %v", err)
+	}
+
+	var result map[string][]map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("OutputGrouped() produced invalid JSON: %v", err)
+	}
+	if len(result["Todo"]) != 2 || len(result["Done"]) != 1 || len(result["None"]) != 1 {
+		t.Errorf("OutputGrouped() unexpected groups: %v", result)
+	}
+}
+
+// TestOutputGroupedPlain verifies plain grouped output prints a header per group.
+func TestOutputGroupedPlain(t *testing.T) {
+	var buf bytes.Buffer
+	f := New(FormatPlain, &buf)
+
+	if err := f.OutputGrouped(testItems(), "state"); err != nil {
+		t.Fatalf("OutputGrouped() error: %v", err)
+	}
+
+	out := buf.String()
+	for _, header := range []string{"Todo (2)", "Done (1)", "None (1)"} {
+		if !strings.Contains(out, header) {
+			t.Errorf("OutputGrouped() missing header %q in:\n%s", header, out)
+		}
+	}
+}
+
+// TestCountBy verifies counts per field value, largest first with ties in
+// first-appearance order.
+func TestCountBy(t *testing.T) {
+	f := New(FormatJSON, &bytes.Buffer{})
+
+	tests := []struct {
+		field    string
+		expected []GroupCount
+	}{
+		{field: "state", expected: []GroupCount{{"Todo", 2}, {"Done", 1}, {"None", 1}}},
+		{field: "priority", expected: []GroupCount{{"Urgent", 2}, {"Medium", 1}, {"High", 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := f.CountBy(testItems(), tt.field); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CountBy(%q) = %v, want %v", tt.field, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestOutputCounts verifies count output in each format, with the field
+// and COUNT columns in a fixed order.
+func TestOutputCounts(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{format: FormatCSV, expected: "STATE,COUNT\nTodo,2\nDone,1\nNone,1\n"},
+		{format: FormatPlain, expected: "Todo\t2\nDone\t1\nNone\t1\n"},
+		{format: FormatJSON, expected: `[{"value":"Todo","count":2},{"value":"Done","count":1},{"value":"None","count":1}]
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			f := New(tt.format, &buf)
+
+			if err := f.OutputCounts(testItems(), "state"); err != nil {
+				t.Fatalf("OutputCounts() error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("OutputCounts() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+type timedItem struct {
+	ID        string     `json:"id"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+}
+
+// TestSetLocation verifies timestamps are shown in the configured zone in
+// table output, dates keep their day, and JSON stays in UTC.
+func TestSetLocation(t *testing.T) {
+	tokyo, err := LoadLocation("", "Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	item := timedItem{ID: "a", CreatedAt: time.Date(2026, 1, 2, 20, 30, 0, 0, time.UTC), DueDate: &due}
+
+	table := New(FormatTable, &bytes.Buffer{})
+	table.SetLocation(tokyo)
+	row := table.structToMap(item)
+	if got, want := row["CREATEDAT"], "2026-01-03 05:30 JST"; got != want {
+		t.Errorf("CREATEDAT = %v, want %v", got, want)
+	}
+	if got, want := row["DUEDATE"], "2026-03-01"; got != want {
+		t.Errorf("DUEDATE = %v, want %v", got, want)
+	}
+
+	var buf bytes.Buffer
+	jsonOut := New(FormatJSON, &buf)
+	jsonOut.SetLocation(tokyo)
+	if err := jsonOut.Output(item); err != nil {
+		t.Fatalf("Output() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"createdAt":"2026-01-02T20:30:00Z"`) {
+		t.Errorf("JSON output not in UTC:\n%s", buf.String())
+	}
+
+	if _, err := LoadLocation("Not/AZone"); err == nil {
+		t.Error("LoadLocation() accepted an unknown zone")
+	}
+	if loc, err := LoadLocation("", ""); loc != nil || err != nil {
+		t.Errorf("LoadLocation() with no names = %v, %v, want nil, nil", loc, err)
+	}
+}
+
+// TestHighlightBefore verifies which rows fall before the highlight cutoff
+// and that table output colors only those rows.
+func TestHighlightBefore(t *testing.T) {
+	cutoff := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	items := []timedItem{
+		{ID: "old", CreatedAt: cutoff.Add(-time.Hour)},
+		{ID: "at-cutoff", CreatedAt: cutoff},
+		{ID: "new", CreatedAt: cutoff.Add(time.Hour)},
+	}
+
+	f := New(FormatTable, &bytes.Buffer{})
+	if marks := f.highlighted(items); marks != nil {
+		t.Errorf("highlighted() without a cutoff = %v, want nil", marks)
+	}
+
+	f.SetHighlightBefore("createdAt", cutoff)
+	if got, want := f.highlighted(items), []bool{true, false, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("highlighted() = %v, want %v", got, want)
+	}
+	if got := f.highlighted(timedItem{ID: "single", CreatedAt: cutoff.Add(-time.Minute)}); !reflect.DeepEqual(got, []bool{true}) {
+		t.Errorf("highlighted() single item = %v, want [true]", got)
+	}
+
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	var buf bytes.Buffer
+	table := &Formatter{format: FormatTable, writer: &buf, color: true}
+	table.SetHighlightBefore("createdAt", cutoff)
+	if err := table.Output(items); err != nil {
+		t.Fatalf("Output() error: %v", err)
+	}
+	red := color.RedString("old")
+	if !strings.Contains(buf.String(), red) {
+		t.Errorf("stale row not highlighted:\n%q", buf.String())
+	}
+	if strings.Contains(buf.String(), color.RedString("new")) {
+		t.Errorf("fresh row highlighted:\n%q", buf.String())
+	}
+}
+
+// TestRelativeTime verifies human-readable relative timestamps.
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    time.Time
+		expected string
+	}{
+		{input: time.Time{}, expected: "unknown time"},
+		{input: now.Add(-30 * time.Second), expected: "just now"},
+		{input: now.Add(-1 * time.Minute), expected: "1 minute ago"},
+		{input: now.Add(-5 * time.Hour), expected: "5 hours ago"},
+		{input: now.Add(-3 * 24 * time.Hour), expected: "3 days ago"},
+		{input: now.Add(-60 * 24 * time.Hour), expected: "2 months ago"},
+		{input: now.Add(-800 * 24 * time.Hour), expected: "2 years ago"},
+		{input: now.Add(time.Hour), expected: "just now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := RelativeTime(tt.input, now); got != tt.expected {
+				t.Errorf("RelativeTime(%v) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestIndent verifies multi-line bodies keep their line breaks.
+func TestIndent(t *testing.T) {
+	got := Indent("first\n\nsecond\n", "  ")
+	if want := "  first\n\n  second"; got != want {
+		t.Errorf("Indent() = %q, want %q", got, want)
+	}
+}
+
+type testLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type testLabeled struct {
+	ID     string      `json:"id"`
+	Labels []testLabel `json:"labels,omitempty"`
+}
+
+// TestGroupByLabels verifies that an item with several labels appears in
+// each label's group and unlabeled items fall under "None".
+func TestGroupByLabels(t *testing.T) {
+	f := New(FormatJSON, &bytes.Buffer{})
+	items := []testLabeled{
+		{ID: "a", Labels: []testLabel{{Name: "Bug", Color: "#ff0000"}, {Name: "UI", Color: "#00ff00"}}},
+		{ID: "b"},
+		{ID: "c", Labels: []testLabel{{Name: "UI", Color: "#00ff00"}}},
+	}
+
+	keys, groups := f.GroupBy(items, "labels")
+
+	if want := []string{"Bug", "UI", "None"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("GroupBy() keys = %v, want %v", keys, want)
+	}
+	if got := len(groups["UI"]); got != 2 {
+		t.Errorf("GroupBy() UI has %d items, want 2", got)
+	}
+	if got := len(groups["None"]); got != 1 {
+		t.Errorf("GroupBy() None has %d items, want 1", got)
+	}
+}
+
+// TestLabelsColumn verifies that labels flatten to comma-joined names in CSV
+// and are colorized per label in table output.
+func TestLabelsColumn(t *testing.T) {
+	items := []testLabeled{
+		{ID: "a", Labels: []testLabel{{Name: "Bug", Color: "#ff0000"}, {Name: "UI", Color: "#00ff00"}}},
+	}
+
+	var csvBuf bytes.Buffer
+	if err := New(FormatCSV, &csvBuf).Output(items); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if !strings.Contains(csvBuf.String(), `"Bug, UI"`) {
+		t.Errorf("CSV output = %q, want comma-joined label names", csvBuf.String())
+	}
+
+	list, ok := toNamedList([]interface{}{
+		map[string]interface{}{"name": "Bug", "color": "#ff0000"},
+	})
+	if !ok {
+		t.Fatal("toNamedList() did not recognize a list of named objects")
+	}
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	f := &Formatter{format: FormatTable, color: true}
+	if got := f.colorNamedList(list); !strings.Contains(got, "38;2;255;0;0") || !strings.Contains(got, "Bug") {
+		t.Errorf("colorNamedList() = %q, want 24-bit red escape around Bug", got)
+	}
+}
+
+// TestTeamKeyColor verifies that a team's key is shown in its color in
+// table output while the icon and color stay available as columns.
+func TestTeamKeyColor(t *testing.T) {
+	type team struct {
+		Key   string `json:"key"`
+		Name  string `json:"name"`
+		Icon  string `json:"icon,omitempty"`
+		Color string `json:"color,omitempty"`
+	}
+	teams := []team{{Key: "ENG", Name: "Engineering", Icon: "Rocket", Color: "#ff0000"}, {Key: "OPS", Name: "Operations"}}
+
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	var buf bytes.Buffer
+	f := &Formatter{format: FormatTable, writer: &buf, color: true}
+	if err := f.Output(teams); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "\x1b[38;2;255;0;0mENG") {
+		t.Errorf("table output = %q, want ENG in 24-bit red", out)
+	}
+	if !strings.Contains(out, "OPS") || !strings.Contains(out, "ICON") || !strings.Contains(out, "Rocket") {
+		t.Errorf("table output = %q, want uncolored OPS and an ICON column", out)
+	}
+}
+
+// TestOutputCreated verifies that create commands keep status lines on the
+// status writer so stdout carries only the identifier or the created object.
+func TestOutputCreated(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     Format
+		idOnly     bool
+		wantStdout string
+	}{
+		{name: "quiet prints identifier only", format: FormatTable, idOnly: true, wantStdout: "ENG-1\n"},
+		{name: "json prints full object", format: FormatJSON, idOnly: false, wantStdout: "{\"id\":\"ENG-1\",\"priority\":2}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			f := New(tt.format, &stdout)
+			f.SetStatusWriter(&stderr)
+
+			f.Statusf("✓ Created issue %s\n", "ENG-1")
+			if err := f.OutputCreated(testItem{ID: "ENG-1", Priority: 2}, "ENG-1", tt.idOnly); err != nil {
+				t.Fatalf("OutputCreated() error = %v", err)
+			}
+
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != "✓ Created issue ENG-1\n" {
+				t.Errorf("stderr = %q, want status line", stderr.String())
+			}
+		})
+	}
+}
+
+// TestStatusLogger verifies that a status logger turns status lines into
+// JSON records on stderr without touching the data written to stdout.
+func TestStatusLogger(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	f := New(FormatJSON, &stdout)
+	f.SetStatusWriter(&bytes.Buffer{})
+	f.SetStatusLogger(slog.New(slog.NewJSONHandler(&stderr, nil)))
+
+	f.Statusf("✓ Created issue %s\n", "ENG-1")
+	f.Statusf("Warning: %s\n", "partial response")
+	if err := f.Output(testItem{ID: "ENG-1", Priority: 2}); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	if want := "{\"id\":\"ENG-1\",\"priority\":2}\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	want := []struct{ level, msg string }{
+		{"INFO", "Created issue ENG-1"},
+		{"WARN", "partial response"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("stderr has %d records, want %d:\n%s", len(lines), len(want), stderr.String())
+	}
+	for i, line := range lines {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("record %d is not JSON: %v\n%s", i, err, line)
+		}
+		if record["level"] != want[i].level || record["msg"] != want[i].msg {
+			t.Errorf("record %d = %v, want level %s msg %q", i, record, want[i].level, want[i].msg)
+		}
+	}
+}
+
+// TestProvenanceColumn verifies attachments render as a PROVENANCE column of
+// distinct source types, and reactions as emoji counts.
+func TestProvenanceColumn(t *testing.T) {
+	type attachment struct {
+		Title      string `json:"title"`
+		SourceType string `json:"sourceType,omitempty"`
+	}
+	type reaction struct {
+		Emoji string `json:"emoji"`
+		Count int    `json:"count"`
+	}
+	type issue struct {
+		ID          string       `json:"id"`
+		Attachments []attachment `json:"attachments,omitempty"`
+		Reactions   []reaction   `json:"reactions,omitempty"`
+	}
+
+	f := New(FormatPlain, &bytes.Buffer{})
+	row := f.structToMap(issue{
+		ID: "a",
+		Attachments: []attachment{
+			{Title: "Thread", SourceType: "slack"},
+			{Title: "Ticket", SourceType: "zendesk"},
+			{Title: "Another thread", SourceType: "slack"},
+			{Title: "Plain link"},
+		},
+		Reactions: []reaction{{Emoji: "eyes", Count: 2}, {Emoji: "+1", Count: 1}},
+	})
+
+	if got := row["PROVENANCE"]; got != "slack, zendesk" {
+		t.Errorf("PROVENANCE = %v, want %q", got, "slack, zendesk")
+	}
+	if _, ok := row["ATTACHMENTS"]; ok {
+		t.Errorf("ATTACHMENTS column should be replaced by PROVENANCE")
+	}
+	if got := row["REACTIONS"]; got != ":eyes: 2, :+1: 1" {
+		t.Errorf("REACTIONS = %v, want %q", got, ":eyes: 2, :+1: 1")
+	}
+
+	row = f.structToMap(issue{ID: "b", Attachments: []attachment{{Title: "Plain link"}}})
+	if _, ok := row["PROVENANCE"]; ok {
+		t.Errorf("PROVENANCE should be omitted when no attachment has a source")
+	}
+}
+
+// TestTableLayout verifies long cells are truncated with an ellipsis or
+// wrapped, capped by MaxColWidth, and that without a cap only TITLE is
+// narrowed to fit the terminal width.
+func TestTableLayout(t *testing.T) {
+	type titled struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	items := []titled{{ID: "ENG-1", Title: strings.Repeat("word ", 20)}}
+
+	tests := []struct {
+		name     string
+		layout   TableLayout
+		maxWidth int
+		contains string
+		lines    int
+	}{
+		{name: "No layout keeps full title", layout: TableLayout{}, maxWidth: 120, contains: strings.Repeat("word ", 19) + "word", lines: 5},
+		{name: "Truncate to terminal width", layout: TableLayout{Width: 60}, maxWidth: 60, contains: "…", lines: 5},
+		{name: "Wrap to terminal width", layout: TableLayout{Width: 60, Wrap: true}, maxWidth: 60, lines: 6},
+		{name: "Max column width", layout: TableLayout{MaxColWidth: 10, Width: 200}, maxWidth: 30, contains: "…", lines: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := New(FormatTable, &buf)
+			f.SetTableLayout(tt.layout)
+			if err := f.Output(items); err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+
+			out := strings.TrimRight(buf.String(), "\n")
+			lines := strings.Split(out, "\n")
+			for _, line := range lines {
+				if width := len([]rune(line)); width > tt.maxWidth {
+					t.Errorf("line width %d exceeds %d: %q", width, tt.maxWidth, line)
+				}
+			}
+			if tt.contains != "" && !strings.Contains(out, tt.contains) {
+				t.Errorf("output missing %q", tt.contains)
+			}
+			if tt.lines > 0 && len(lines) < tt.lines {
+				t.Errorf("got %d lines, want at least %d", len(lines), tt.lines)
+			}
+		})
+	}
+}
+
+// TestHeterogeneousRows verifies that columns are the union across rows, so
+// an assignee present only on a later row still gets a column, and rows
+// missing it get an empty cell aligned under the right header.
+func TestHeterogeneousRows(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	type issue struct {
+		ID       string `json:"id"`
+		Assignee *user  `json:"assignee,omitempty"`
+	}
+	items := []issue{
+		{ID: "ENG-1"},
+		{ID: "ENG-2", Assignee: &user{Name: "Ada"}},
+		{ID: "ENG-3"},
+	}
+
+	var csvBuf bytes.Buffer
+	if err := New(FormatCSV, &csvBuf).Output(items); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 4 || len(records[0]) != 2 {
+		t.Fatalf("CSV = %q, want a header and 3 rows of 2 columns", records)
+	}
+	col := map[string]int{}
+	for i, header := range records[0] {
+		col[header] = i
+	}
+	want := [][2]string{{"ENG-1", ""}, {"ENG-2", "Ada"}, {"ENG-3", ""}}
+	for i, w := range want {
+		row := records[i+1]
+		if row[col["ID"]] != w[0] || row[col["ASSIGNEE"]] != w[1] {
+			t.Errorf("row %d = %q, want ID %q ASSIGNEE %q", i+1, row, w[0], w[1])
+		}
+	}
+
+	var tableBuf bytes.Buffer
+	if err := New(FormatTable, &tableBuf).Output(items); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	table := tableBuf.String()
+	if !strings.Contains(table, "ASSIGNEE") || !strings.Contains(table, "Ada") {
+		t.Errorf("table = %q, want ASSIGNEE column with Ada", table)
+	}
+	if strings.Contains(table, "<nil>") {
+		t.Errorf("table = %q, missing cells should be empty", table)
+	}
+	lines := strings.Split(strings.TrimRight(table, "\n"), "\n")
+	for _, line := range lines[1:] {
+		if n := len([]rune(line)); n != len([]rune(lines[0])) {
+			t.Errorf("line %q has width %d, want %d (misaligned)", line, n, len([]rune(lines[0])))
+		}
+	}
+}
+
+// TestLeadingColumns verifies leading columns come first in the order
+// given, and that a leading column no row has is left out.
+func TestLeadingColumns(t *testing.T) {
+	items := []map[string]interface{}{
+		{"name": "Roadmap", "state": "started", "profile": "work", "workspace": "Acme"},
+		{"name": "Launch", "state": "planned", "profile": "home", "workspace": "Side"},
+	}
+
+	var buf bytes.Buffer
+	f := New(FormatCSV, &buf)
+	f.SetLeadingColumns("PROFILE", "WORKSPACE", "MISSING")
+	if err := f.Output(items); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records[0]) != 4 || records[0][0] != "PROFILE" || records[0][1] != "WORKSPACE" {
+		t.Errorf("headers = %q, want PROFILE and WORKSPACE first", records[0])
+	}
+	if records[1][0] != "work" || records[2][1] != "Side" {
+		t.Errorf("records = %q, want profile and workspace values first", records)
+	}
+}
+
+// TestCSVOptions verifies the CSV delimiter and UTF-8 byte order mark, and
+// that the defaults stay comma-separated without a BOM.
+func TestCSVOptions(t *testing.T) {
+	type item struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	items := []item{{ID: "ENG-1", Title: "Fix; then ship"}}
+
+	tests := []struct {
+		name  string
+		opts  CSVOptions
+		comma rune
+	}{
+		{"defaults", CSVOptions{}, ','},
+		{"semicolon", CSVOptions{Delimiter: ';'}, ';'},
+		{"tab with BOM", CSVOptions{Delimiter: '\t', BOM: true}, '\t'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := New(FormatCSV, &buf)
+			f.SetCSVOptions(tt.opts)
+			if err := f.Output(items); err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+			out := buf.String()
+			if got := strings.HasPrefix(out, utf8BOM); got != tt.opts.BOM {
+				t.Errorf("output = %q, BOM present = %v, want %v", out, got, tt.opts.BOM)
+			}
+
+			r := csv.NewReader(strings.NewReader(strings.TrimPrefix(out, utf8BOM)))
+			r.Comma = tt.comma
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(records) != 2 || len(records[0]) != 2 {
+				t.Fatalf("CSV = %q, want a header and 1 row of 2 columns", records)
+			}
+			for i, header := range records[0] {
+				if header == "TITLE" && records[1][i] != "Fix; then ship" {
+					t.Errorf("TITLE = %q, want %q", records[1][i], "Fix; then ship")
+				}
+			}
+		})
+	}
+}
+
+// TestCSVQuoting verifies that a description with commas, quotes, and line
+// breaks round-trips through CSV, and that --csv-single-line keeps each
+// record on one physical line.
+func TestCSVQuoting(t *testing.T) {
+	type item struct {
+		Description string `json:"description"`
+	}
+	desc := "Steps: open, click \"Save\"\r\n\n  then reload\nand wait"
+	items := []item{{Description: desc}}
+
+	tests := []struct {
+		name       string
+		singleLine bool
+		want       string
+		wantRaw    string
+	}{
+		{"multi-line", false, desc, "\"Steps: open, click \"\"Save\"\"\r\n\n  then reload\nand wait\"\n"},
+		{"single-line", true, `Steps: open, click "Save" then reload and wait`, "\"Steps: open, click \"\"Save\"\" then reload and wait\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := New(FormatCSV, &buf)
+			f.SetCSVOptions(CSVOptions{SingleLine: tt.singleLine})
+			if err := f.Output(items); err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+
+			raw := strings.TrimPrefix(buf.String(), "DESCRIPTION\n")
+			if raw != tt.wantRaw {
+				t.Errorf("record = %q, want %q", raw, tt.wantRaw)
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			// The csv reader normalizes \r\n inside quoted fields to \n
+			want := strings.ReplaceAll(tt.want, "\r\n", "\n")
+			if len(records) != 2 || records[1][0] != want {
+				t.Errorf("records = %q, want description %q", records, want)
+			}
+		})
+	}
+}
+
+func TestParseCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    rune
+		wantErr bool
+	}{
+		{",", ',', false},
+		{";", ';', false},
+		{`\t`, '\t', false},
+		{"tab", '\t', false},
+		{"", 0, true},
+		{";;", 0, true},
+		{`"`, 0, true},
+		{"\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCSVDelimiter(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCSVDelimiter(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCSVDelimiter(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResolveFormat verifies the format precedence: --format, then
+// LIRT_FORMAT, then config, and only then JSON when piped (unless the
+// auto-switch is disabled) or a table.
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		autoJSON bool
+		flag     string
+		env      string
+		config   string
+		want     Format
+	}{
+		{name: "flag beats config when piped", autoJSON: true, flag: "table", config: "csv", want: FormatTable},
+		{name: "flag beats env", flag: "plain", env: "json", want: FormatPlain},
+		{name: "env beats config", autoJSON: true, env: "json", config: "csv", want: FormatJSON},
+		{name: "config honored when piped", autoJSON: true, config: "csv", want: FormatCSV},
+		{name: "config on terminal", config: "plain", want: FormatPlain},
+		{name: "piped default", autoJSON: true, want: FormatJSON},
+		{name: "terminal or auto-switch disabled", want: FormatTable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveFormat(tt.autoJSON, tt.flag, tt.env, tt.config); got != tt.want {
+				t.Errorf("ResolveFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// terminalBuffer is a writer that reports itself as a terminal
+type terminalBuffer struct {
+	bytes.Buffer
+}
+
+func (*terminalBuffer) IsTerminal() bool { return true }
+
+// TestPrettyJSON verifies JSON is indented on a terminal and compact when
+// piped, and that SetPrettyJSON (--pretty/--compact) overrides either.
+func TestPrettyJSON(t *testing.T) {
+	const (
+		pretty  = "{\n  \"id\": \"ENG-1\",\n  \"priority\": 2\n}\n"
+		compact = "{\"id\":\"ENG-1\",\"priority\":2}\n"
+	)
+
+	tests := []struct {
+		name     string
+		terminal bool
+		override string // "pretty", "compact", or "" for the default
+		want     string
+	}{
+		{name: "terminal", terminal: true, want: pretty},
+		{name: "pipe", want: compact},
+		{name: "compact on terminal", terminal: true, override: "compact", want: compact},
+		{name: "pretty when piped", override: "pretty", want: pretty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w interface {
+				Write([]byte) (int, error)
+				String() string
+			} = &bytes.Buffer{}
+			if tt.terminal {
+				w = &terminalBuffer{}
+			}
+
+			f := New(FormatJSON, w)
+			if tt.override != "" {
+				f.SetPrettyJSON(tt.override == "pretty")
+			}
+			if err := f.Output(testItem{ID: "ENG-1", Priority: 2}); err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Output() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// DefaultPager is used when neither LIRT_PAGER nor PAGER is set. -F exits
+// immediately when output fits on one screen, -R passes colors through, and
+// -X leaves the output on screen after quitting.
+const DefaultPager = "less -FRX"
+
+// PagerCommand returns the pager to use: LIRT_PAGER, then PAGER, then
+// DefaultPager. An empty result (e.g. LIRT_PAGER="") or "cat" disables paging.
+func PagerCommand() string {
+	if pager, ok := os.LookupEnv("LIRT_PAGER"); ok {
+		return normalizePager(pager)
+	}
+	if pager, ok := os.LookupEnv("PAGER"); ok {
+		return normalizePager(pager)
+	}
+	return DefaultPager
+}
+
+// normalizePager maps pager values that mean "no pager" to ""
+func normalizePager(pager string) string {
+	pager = strings.TrimSpace(pager)
+	if pager == "cat" {
+		return ""
+	}
+	return pager
+}
+
+// Pager is a writer that pipes output through a pager process. The process
+// is started lazily on the first write, so commands that fail before
+// producing output never flash an empty pager.
+type Pager struct {
+	command string
+	out     *os.File
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	started bool
+	closed  bool // pager exited early (e.g. the user quit less)
+}
+
+// NewPager returns a writer that pages through command onto out
+func NewPager(command string, out *os.File) *Pager {
+	return &Pager{command: command, out: out}
+}
+
+// IsTerminal reports that paged output ends up on a terminal, so the
+// formatter keeps colors enabled
+func (p *Pager) IsTerminal() bool {
+	return true
+}
+
+// start launches the pager, falling back to writing to out directly if the
+// command cannot be run
+func (p *Pager) start() {
+	p.started = true
+
+	fields := strings.Fields(p.command)
+	if len(fields) == 0 {
+		return
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stdout = p.out
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		return
+	}
+
+	p.cmd = cmd
+	p.stdin = stdin
+}
+
+// Write sends data to the pager
+func (p *Pager) Write(data []byte) (int, error) {
+	if !p.started {
+		p.start()
+	}
+	if p.stdin == nil {
+		return p.out.Write(data)
+	}
+	if p.closed {
+		return len(data), nil
+	}
+
+	n, err := p.stdin.Write(data)
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+		// The user quit the pager; discard the rest of the output
+		p.closed = true
+		return len(data), nil
+	}
+	return n, err
+}
+
+// Close flushes output to the pager and waits for the user to exit it
+func (p *Pager) Close() error {
+	if p.stdin == nil {
+		return nil
+	}
+	p.stdin.Close()
+	err := p.cmd.Wait()
+	p.stdin = nil
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// A pager quit early or interrupted is not a command failure
+		return nil
+	}
+	return err
+}
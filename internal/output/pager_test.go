@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPagerCommand verifies LIRT_PAGER takes precedence over PAGER, that the
+// default is less, and that empty or "cat" disables paging.
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		lirtPager *string
+		pager     *string
+		expected  string
+	}{
+		{name: "Default", expected: DefaultPager},
+		{name: "PAGER", pager: strPtr("more"), expected: "more"},
+		{name: "LIRT_PAGER wins", lirtPager: strPtr("most"), pager: strPtr("more"), expected: "most"},
+		{name: "Empty disables", lirtPager: strPtr(""), pager: strPtr("more"), expected: ""},
+		{name: "cat disables", pager: strPtr("cat"), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setOrUnsetEnv(t, "LIRT_PAGER", tt.lirtPager)
+			setOrUnsetEnv(t, "PAGER", tt.pager)
+
+			if got := PagerCommand(); got != tt.expected {
+				t.Errorf("PagerCommand() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPager verifies output written through the pager reaches the
+// destination, and that an unrunnable pager falls back to writing directly.
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{name: "Pipes through command", command: "cat"},
+		{name: "Missing command falls back", command: "lirt-no-such-pager"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out")
+			out, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Close()
+
+			p := NewPager(tt.command, out)
+			for i := 0; i < 3; i++ {
+				fmt.Fprintf(p, "line %d\n", i)
+			}
+			if err := p.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "line 0\nline 1\nline 2\n"; string(got) != want {
+				t.Errorf("paged output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestPagerKeepsColor verifies the formatter treats a pager as a terminal
+func TestPagerKeepsColor(t *testing.T) {
+	f := New(FormatTable, NewPager("cat", os.Stdout))
+	if !f.color {
+		t.Error("formatter writing to a pager should enable color")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// setOrUnsetEnv sets key to *value for the test, or unsets it if value is nil
+func setOrUnsetEnv(t *testing.T, key string, value *string) {
+	t.Helper()
+	t.Setenv(key, "")
+	if value == nil {
+		os.Unsetenv(key)
+		return
+	}
+	os.Setenv(key, *value)
+}
@@ -12,6 +12,6 @@ var version = "dev"
 func main() {
 	if err := cmd.Execute(version); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCodeFor(err))
 	}
 }